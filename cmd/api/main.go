@@ -12,6 +12,7 @@ import (
 	"github.com/dwes123/fantasy-baseball-go/internal/db"
 	"github.com/dwes123/fantasy-baseball-go/internal/handlers"
 	"github.com/dwes123/fantasy-baseball-go/internal/middleware"
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
 	"github.com/dwes123/fantasy-baseball-go/internal/notification"
 	"github.com/dwes123/fantasy-baseball-go/internal/worker"
 	"github.com/gin-contrib/cors"
@@ -25,6 +26,7 @@ func main() {
 
 	// 1b. Initialize Email Notifications
 	notification.InitEmail()
+	mlbapi.Init()
 
 	// 2. Start Background Workers with cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -33,11 +35,14 @@ func main() {
 	worker.StartBidWorker(ctx, database)
 	worker.StartWaiverWorker(ctx, database)
 	worker.StartSeasonalWorker(ctx, database)
-	worker.StartHRMonitor(ctx, database)
+	worker.StartAlertMonitor(ctx, database)
 	worker.StartStatsWorker(ctx, database)
+	worker.StartLiveScoringWorker(ctx, database)
 	worker.StartMinorLeaguerWorker(ctx, database)
 	worker.StartComplianceWorker(ctx, database)
 	worker.StartWaiverPriorityWorker(ctx, database)
+	worker.StartDuesReminderWorker(ctx, database)
+	worker.StartPayrollSnapshotWorker(ctx, database)
 
 	// 3. Initialize Router
 	r := gin.Default()
@@ -85,6 +90,8 @@ func main() {
 	{
 		authorized.GET("/home", handlers.HomeHandler(database))
 		authorized.GET("/roster/:id", handlers.RosterHandler(database))
+		authorized.GET("/team/roster-history/:id", handlers.RosterHistoryHandler(database))
+		authorized.GET("/api/roster-snapshot", handlers.RosterSnapshotAPIHandler(database))
 
 		// League Rosters & Bid Calculator
 		authorized.GET("/league/rosters", handlers.LeagueRostersHandler(database))
@@ -103,6 +110,15 @@ func main() {
 		authorized.GET("/league/financials", handlers.LeagueFinancialsHandler(database))
 		authorized.GET("/league/irl-financials", handlers.IRLFinancialsHandler(database))
 		authorized.GET("/team/financials/:id", handlers.TeamFinancialsHandler(database))
+		authorized.GET("/api/payroll/history", handlers.PayrollHistoryHandler(database))
+		authorized.GET("/api/payroll/as-of", handlers.PayrollAsOfHandler(database))
+		authorized.GET("/api/valuation", handlers.PlayerValuationAPIHandler(database))
+		authorized.GET("/api/valuation/compare", handlers.CompareValuationAPIHandler(database))
+		authorized.GET("/team/planner/:id", handlers.PayrollPlannerHandler(database))
+		authorized.POST("/team/planner/:id/save", handlers.SavePayrollScenarioHandler(database))
+		authorized.POST("/team/planner/:id/delete", handlers.DeletePayrollScenarioHandler(database))
+		authorized.POST("/team/planner/:id/evaluate", handlers.EvaluatePayrollScenarioHandler(database))
+		authorized.GET("/api/planner/team-players", handlers.PlannerTeamPlayersHandler(database))
 
 		// Profile & Team Management
 		authorized.GET("/profile", handlers.ProfileHandler(database))
@@ -150,6 +166,7 @@ func main() {
 		authorized.POST("/roster/move/il-60", handlers.MoveToSixtyDayILHandler(database))
 		authorized.POST("/roster/move/dfa", handlers.DFAPlayerHandler(database))
 		authorized.POST("/roster/move/waive", handlers.WaivePlayerHandler(database))
+		authorized.GET("/api/dead-cap/preview", handlers.DeadCapPreviewHandler(database))
 		authorized.POST("/roster/move/position-swap", handlers.SwapPitcherPositionHandler(database))
 		authorized.POST("/roster/move/trade-block", handlers.ToggleTradeBlockHandler(database))
 		authorized.POST("/roster/depth-order", handlers.SaveDepthOrderHandler(database))
@@ -158,6 +175,14 @@ func main() {
 		authorized.GET("/arbitration", handlers.ArbitrationHandler(database))
 		authorized.POST("/arbitration/submit", handlers.SubmitArbitrationHandler(database))
 		authorized.POST("/extension/submit", handlers.SubmitArbExtensionHandler(database))
+		authorized.GET("/qualifying-offers", handlers.QualifyingOffersHandler(database))
+		authorized.POST("/qualifying-offers/tender", handlers.TenderQualifyingOfferHandler(database))
+		authorized.GET("/admin/qualifying-offers", handlers.AdminQualifyingOffersHandler(database))
+		authorized.POST("/admin/qualifying-offers/rules", handlers.AdminSaveQualifyingOfferRulesHandler(database))
+		authorized.POST("/admin/qualifying-offers/resolve", handlers.AdminResolveQualifyingOfferHandler(database))
+		authorized.GET("/admin/arbitration", handlers.AdminArbitrationHandler(database))
+		authorized.POST("/admin/arbitration/rule", handlers.AdminRuleArbitrationHandler(database))
+		authorized.POST("/admin/arbitration/formula", handlers.AdminSaveArbitrationFormulaHandler(database))
 
 		// Contracts & Options
 		authorized.GET("/team-options", handlers.TeamOptionsHandler(database))
@@ -175,8 +200,13 @@ func main() {
 		// Fantasy Stats
 		authorized.GET("/stats/pitching", handlers.StatsLeaderboardHandler(database))
 		authorized.GET("/stats/hitting", handlers.HittingLeaderboardHandler(database))
+		authorized.GET("/live", handlers.LiveScoringHandler(database))
+		authorized.GET("/api/live", handlers.LiveScoringAPIHandler(database))
 		authorized.GET("/api/player/:id/gamelog", handlers.PlayerGameLogHandler(database))
 		authorized.POST("/admin/stats/backfill", handlers.AdminBackfillStatsHandler(database))
+		authorized.POST("/admin/stats/backfill-copies", handlers.AdminBackfillStatCopiesHandler(database))
+		authorized.POST("/admin/stats/corrections", handlers.AdminStatCorrectionsHandler(database))
+		authorized.GET("/api/admin/stat-corrections", handlers.GetStatCorrectionsHandler(database))
 		authorized.POST("/admin/minor-leaguer-refresh", handlers.AdminMinorLeaguerRefreshHandler(database))
 		authorized.POST("/admin/populate-mlb-ids", handlers.AdminPopulateMLBIDsHandler(database))
 		authorized.POST("/admin/compliance-check", handlers.AdminComplianceCheckHandler(database))
@@ -203,6 +233,27 @@ func main() {
 		authorized.POST("/admin/approve-registration", handlers.AdminProcessRegistrationHandler(database))
 		authorized.GET("/admin/settings", handlers.AdminSettingsHandler(database))
 		authorized.POST("/admin/settings/save", handlers.AdminSaveSettingsHandler(database))
+		authorized.GET("/admin/rollover", handlers.AdminRolloverHandler(database))
+		authorized.POST("/admin/rollover/run", handlers.AdminRunRolloverHandler(database))
+		authorized.GET("/admin/scoring", handlers.AdminScoringHandler(database))
+		authorized.POST("/admin/scoring", handlers.AdminSaveScoringHandler(database))
+		authorized.POST("/admin/scoring/reset", handlers.AdminResetScoringHandler(database))
+		authorized.POST("/admin/scoring/recompute", handlers.AdminRecomputeScoringHandler(database))
+		authorized.GET("/admin/alerts", handlers.AdminAlertsHandler(database))
+		authorized.POST("/admin/alerts", handlers.AdminCreateAlertRuleHandler(database))
+		authorized.POST("/admin/alerts/:id/toggle", handlers.AdminToggleAlertRuleHandler(database))
+		authorized.POST("/admin/alerts/:id/delete", handlers.AdminDeleteAlertRuleHandler(database))
+		authorized.GET("/admin/schedule", handlers.AdminScheduleHandler(database))
+		authorized.POST("/admin/schedule/generate", handlers.AdminGenerateScheduleHandler(database))
+		authorized.GET("/admin/projections", handlers.AdminProjectionsHandler(database))
+		authorized.POST("/admin/projections/import", handlers.AdminImportProjectionsHandler(database))
+		authorized.POST("/admin/projections/resolve", handlers.AdminResolveProjectionHandler(database))
+		authorized.GET("/admin/season-rollover", handlers.AdminSeasonRolloverHandler(database))
+		authorized.POST("/admin/season-rollover/apply", handlers.AdminApplySeasonRolloverHandler(database))
+		authorized.GET("/admin/contract-options", handlers.AdminContractOptionsHandler(database))
+		authorized.POST("/admin/contract-options/add", handlers.AdminAddContractOptionHandler(database))
+		authorized.POST("/admin/contract-options/rule", handlers.AdminRulePlayerOptionHandler(database))
+		authorized.POST("/admin/contract-options/apply-rule", handlers.AdminApplyOptionRuleHandler(database))
 		authorized.GET("/admin/fantrax-queue", handlers.AdminFantraxQueueHandler(database))
 		authorized.GET("/admin/waiver-audit", handlers.AdminWaiverAuditHandler(database))
 		authorized.GET("/admin/roles", handlers.AdminRolesHandler(database))
@@ -210,6 +261,16 @@ func main() {
 		authorized.POST("/admin/roles/delete", handlers.AdminDeleteRoleHandler(database))
 		authorized.GET("/admin/balance-editor", handlers.AdminBalanceEditorHandler(database))
 		authorized.POST("/admin/balance-editor/save", handlers.AdminSaveBalanceHandler(database))
+		authorized.POST("/admin/balance-editor/allocate", handlers.AdminAllocateBalanceHandler(database))
+		authorized.GET("/admin/dues", handlers.AdminDuesHandler(database))
+		authorized.POST("/admin/dues/generate", handlers.AdminGenerateDuesHandler(database))
+		authorized.POST("/admin/dues/invoice", handlers.AdminUpdateDuesInvoiceHandler(database))
+		authorized.POST("/admin/dues/fee", handlers.AdminAddDuesFeeHandler(database))
+		authorized.POST("/admin/dues/fee/delete", handlers.AdminDeleteDuesFeeHandler(database))
+		authorized.POST("/admin/dues/payment", handlers.AdminRecordDuesPaymentHandler(database))
+		authorized.POST("/admin/dues/payment/delete", handlers.AdminDeleteDuesPaymentHandler(database))
+		authorized.POST("/admin/dues/remind", handlers.AdminSendDuesReminderHandler(database))
+		authorized.GET("/admin/dues/export", handlers.AdminDuesExportHandler(database))
 		authorized.GET("/admin/team-owners", handlers.AdminTeamOwnersHandler(database))
 		authorized.POST("/admin/team-owners/add", handlers.AdminAddTeamOwnerHandler(database))
 		authorized.POST("/admin/team-owners/remove", handlers.AdminRemoveTeamOwnerHandler(database))
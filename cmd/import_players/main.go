@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/db"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 )
 
 // 1. Define the Structures
@@ -98,7 +99,8 @@ func main() {
 			// ============================================================
 			// 🚀 IMPORT
 			// ============================================================
-			_, err := database.Exec(context.Background(), `
+			var playerID string
+			err := database.QueryRow(context.Background(), `
 				INSERT INTO players (
 					wp_id, 
 					first_name, 
@@ -112,7 +114,8 @@ func main() {
 					mlb_team = EXCLUDED.mlb_team,
 					raw_fantasy_team_id = EXCLUDED.raw_fantasy_team_id,
 					position = EXCLUDED.position,
-                    league_id = EXCLUDED.league_id;
+                    league_id = EXCLUDED.league_id
+				RETURNING id
 			`,
 				wpPlayer.ID,
 				wpPlayer.Title.Rendered,
@@ -121,11 +124,14 @@ func main() {
 				wpPlayer.ACF.MLBTeam,
 				"33333333-3333-3333-3333-333333333333", // <--- NEW UUID FOR AA
 				wpPlayer.ACF.FantasyTeamID,
-			)
+			).Scan(&playerID)
 
 			if err != nil {
 				fmt.Printf("❌ Failed: %s (%v)\n", wpPlayer.Title.Rendered, err)
 			} else {
+				if _, err := store.SyncPlayerCopyToMaster(database, playerID); err != nil {
+					fmt.Printf("❌ Canonical sync failed: %s (%v)\n", wpPlayer.Title.Rendered, err)
+				}
 				totalImported++
 			}
 		}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/db"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 )
 
 type DeadCapPenalty struct {
@@ -203,6 +204,13 @@ func main() {
 				fmt.Printf("❌ Error saving player %s (WP ID %d): %v\n", name, p.ID, err)
 			}
 
+			// Keep the shared canonical record (and the other leagues' copies) in line
+			if playerUUID != "" {
+				if _, err := store.SyncPlayerCopyToMaster(database, playerUUID); err != nil {
+					fmt.Printf("❌ Error syncing canonical record for %s: %v\n", name, err)
+				}
+			}
+
 			if playerUUID != "" && len(p.ACF.DeadCapPenalties) > 0 {
 				database.Exec(context.Background(), "DELETE FROM dead_cap_penalties WHERE player_id = $1", playerUUID)
 				for _, dc := range p.ACF.DeadCapPenalties {
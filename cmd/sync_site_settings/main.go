@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/db"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 )
 
 const bridgeURL = "https://frontofficedynastysports.com/wp-json/fod-bridge/v1/site-settings?key=fod-migrate-2026"
@@ -110,9 +111,7 @@ func main() {
 				continue
 			}
 			bal := parseNumber(tb.Balance)
-			_, err := database.Exec(context.Background(),
-				"UPDATE teams SET isbp_balance = $1 WHERE id = $2",
-				bal, teamUUID)
+			err := store.SyncTeamBalance(database, teamUUID, "isbp", bal, "ISBP balance synced from site settings")
 			if err != nil {
 				fmt.Printf("  ERROR updating ISBP for %s: %v\n", tb.TeamID, err)
 			} else {
@@ -141,9 +140,7 @@ func main() {
 				continue
 			}
 			bal := parseNumber(tb.Balance)
-			_, err := database.Exec(context.Background(),
-				"UPDATE teams SET milb_balance = $1 WHERE id = $2",
-				bal, teamUUID)
+			err := store.SyncTeamBalance(database, teamUUID, "milb", bal, "MiLB balance synced from site settings")
 			if err != nil {
 				fmt.Printf("  ERROR updating MILB for %s: %v\n", tb.TeamID, err)
 			} else {
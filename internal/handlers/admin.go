@@ -14,6 +14,35 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// isLeagueCommissioner reports whether the user commissions leagueID (site admins manage every league).
+func isLeagueCommissioner(db *pgxpool.Pool, user *store.User, leagueID string) bool {
+	if user.Role == "admin" {
+		return true
+	}
+	adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+	for _, id := range adminLeagues {
+		if id == leagueID {
+			return true
+		}
+	}
+	return false
+}
+
+// filterLeaguesByID keeps the leagues whose IDs are listed, e.g. a commissioner's leagues.
+func filterLeaguesByID(leagues []store.League, ids []string) []store.League {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	var filtered []store.League
+	for _, l := range leagues {
+		if keep[l.ID] {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered
+}
+
 func AdminDashboardHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet("user").(*store.User)
@@ -139,6 +168,7 @@ func AdminSavePlayerHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			OptionYears: optYears,
 			IsIFA:       c.PostForm("is_ifa") == "on",
 			DFAOnly:     c.PostForm("dfa_only") == "on",
+			IsTwoWay:    c.PostForm("is_two_way") == "on",
 			Contracts:   contracts,
 			FaStatus:       c.PostForm("fa_status"),
 			PendingBidAmt:  bidAmt,
@@ -405,12 +435,25 @@ func AdminBalanceEditorHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		leagues, _ := store.GetLeaguesWithTeams(db)
 
+		// Flag any team whose stored balance has drifted from its ledger
+		drifts, err := store.ReconcileBalances(db, leagueID)
+		if err != nil {
+			fmt.Printf("ERROR [AdminBalanceEditor]: reconcile: %v\n", err)
+		}
+		var ledger []store.BalanceEntry
+		if leagueID != "" {
+			ledger, _ = store.GetLeagueBalanceLedger(db, leagueID, "", 100)
+		}
+
 		RenderTemplate(c, "admin_balance_editor.html", gin.H{
 			"User":        user,
 			"Teams":       teams,
 			"Leagues":     leagues,
 			"LeagueID":    leagueID,
+			"Drifts":      drifts,
+			"Ledger":      ledger,
 			"SaveSuccess": c.Query("saved") == "1",
+			"Allocated":   c.Query("allocated"),
 			"IsCommish":   true,
 		})
 	}
@@ -429,7 +472,7 @@ func AdminSaveBalanceHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		isbpBalance, _ := strconv.ParseFloat(c.PostForm("isbp_balance"), 64)
 		milbBalance, _ := strconv.ParseFloat(c.PostForm("milb_balance"), 64)
 
-		err := store.SetTeamBalance(db, teamID, isbpBalance, milbBalance)
+		err := store.SetTeamBalance(db, teamID, isbpBalance, milbBalance, user.Username, c.PostForm("note"))
 		if err != nil {
 			fmt.Printf("ERROR [AdminSaveBalance]: %v\n", err)
 			c.String(http.StatusInternalServerError, "Internal server error")
@@ -445,6 +488,39 @@ func AdminSaveBalanceHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	}
 }
 
+// AdminAllocateBalanceHandler credits every team in a league, e.g. the annual ISBP allocation.
+func AdminAllocateBalanceHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		balanceType := c.PostForm("balance_type")
+		amount, _ := strconv.ParseFloat(c.PostForm("amount"), 64)
+		if leagueID == "" || amount == 0 {
+			c.String(http.StatusBadRequest, "League and a non-zero amount are required")
+			return
+		}
+		description := c.PostForm("description")
+		if description == "" {
+			description = fmt.Sprintf("%d annual allocation", time.Now().Year())
+		}
+
+		count, err := store.AllocateLeagueBalance(db, leagueID, balanceType, amount, description, user.Username)
+		if err != nil {
+			fmt.Printf("ERROR [AdminAllocateBalance]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/balance-editor?league_id=%s&allocated=%d", leagueID, count))
+	}
+}
+
 // --- League Settings (Feature 16) ---
 
 func AdminSettingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
@@ -474,11 +550,24 @@ func AdminSettingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		// Build league settings map: "leagueID_field" -> value
 		settingsMap := make(map[string]int)
+		optionDefaults := make(map[string]string)
 		for _, l := range leagues {
 			s := store.GetLeagueSettings(db, l.ID, year)
 			settingsMap[l.ID+"_roster_26_man_limit"] = s.Roster26ManLimit
 			settingsMap[l.ID+"_roster_40_man_limit"] = s.Roster40ManLimit
 			settingsMap[l.ID+"_sp_26_man_limit"] = s.SP26ManLimit
+			optionDefaults[l.ID] = s.OptionDefaultAction
+			optionDefaults[l.ID+"_player_rule"] = s.PlayerOptionRule
+			optionDefaults[l.ID+"_player_threshold"] = fmt.Sprintf("%.0f", s.PlayerOptionThreshold)
+			optionDefaults[l.ID+"_min_salary"] = fmt.Sprintf("%.0f", s.MinSalary)
+			optionDefaults[l.ID+"_max_variance"] = fmt.Sprintf("%.0f", s.MaxSalaryVariance*100)
+			optionDefaults[l.ID+"_discount_rate"] = fmt.Sprintf("%.1f", s.DiscountRate*100)
+			if s.MiLBScoring {
+				optionDefaults[l.ID+"_milb_scoring"] = "on"
+			}
+			optionDefaults[l.ID+"_two_way_sp_rule"] = s.TwoWaySPRule
+			optionDefaults[l.ID+"_two_way_roster_slots"] = strconv.Itoa(s.TwoWayRosterSlots)
+			optionDefaults[l.ID+"_standings_source"] = s.StandingsSource
 		}
 
 		// Load Slack integration settings
@@ -501,14 +590,15 @@ func AdminSettingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		RenderTemplate(c, "admin_settings.html", gin.H{
-			"User":        user,
-			"Leagues":     leagues,
-			"Year":        year,
-			"DateMap":     dateMap,
-			"SettingsMap": settingsMap,
-			"SlackMap":    slackMap,
-			"SaveSuccess": c.Query("saved") == "1",
-			"IsCommish":   true,
+			"User":           user,
+			"Leagues":        leagues,
+			"Year":           year,
+			"DateMap":        dateMap,
+			"SettingsMap":    settingsMap,
+			"OptionDefaults": optionDefaults,
+			"SlackMap":       slackMap,
+			"SaveSuccess":    c.Query("saved") == "1",
+			"IsCommish":      true,
 		})
 	}
 }
@@ -529,7 +619,9 @@ func AdminSaveSettingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			"trade_deadline", "opening_day", "extension_deadline",
 			"ifa_window_open", "ifa_window_close",
 			"milb_fa_window_open", "milb_fa_window_close",
-			"option_deadline",
+			"option_deadline", "contract_rollover",
+			"arb_filing_deadline", "arb_hearing_deadline",
+			"qo_tender_deadline", "qo_response_deadline",
 			"roster_expansion_start", "roster_expansion_end",
 		}
 
@@ -549,6 +641,25 @@ func AdminSaveSettingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			if limit40 == 0 { limit40 = 40 }
 			if spLimit == 0 { spLimit = 6 }
 			store.UpsertLeagueSettings(db, l.ID, year, limit26, limit40, spLimit)
+			store.SetOptionDefaultAction(db, l.ID, year, c.PostForm("option_default_action_"+l.ID))
+			threshold, _ := strconv.ParseFloat(c.PostForm("player_option_threshold_"+l.ID), 64)
+			store.SetPlayerOptionRule(db, l.ID, year, c.PostForm("player_option_rule_"+l.ID), threshold)
+			minSalary, _ := strconv.ParseFloat(c.PostForm("contract_min_salary_"+l.ID), 64)
+			maxVariance, _ := strconv.ParseFloat(c.PostForm("contract_max_yoy_variance_"+l.ID), 64)
+			discountRate, _ := strconv.ParseFloat(c.PostForm("contract_discount_rate_"+l.ID), 64)
+			store.SetSalaryScheduleRules(db, l.ID, year, minSalary, maxVariance/100, discountRate/100)
+			twoWaySlots, _ := strconv.Atoi(c.PostForm("two_way_roster_slots_" + l.ID))
+			store.SetTwoWayRules(db, l.ID, year, c.PostForm("two_way_sp_rule_"+l.ID), twoWaySlots)
+			store.SetStandingsSource(db, l.ID, year, c.PostForm("standings_source_"+l.ID))
+			// Turning MiLB scoring on or off rescores the season's stored minor-league lines
+			changed, err := store.SetMiLBScoring(db, l.ID, year, c.PostForm("milb_scoring_"+l.ID) == "on")
+			if err != nil {
+				fmt.Printf("ERROR [AdminSaveSettings]: milb scoring for %s: %v\n", l.ID, err)
+			} else if changed {
+				if _, err := store.RecomputeLeagueFantasyPoints(db, l.ID, year); err != nil {
+					fmt.Printf("ERROR [AdminSaveSettings]: rescore %s: %v\n", l.ID, err)
+				}
+			}
 		}
 
 		// Save Slack integration settings
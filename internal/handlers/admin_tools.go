@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/gin-gonic/gin"
@@ -82,14 +83,20 @@ func AdminProcessCSVHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			leagueID := row[headerMap["league_id"]]
 
 			// Simple upsert by name + league
-			_, err := db.Exec(context.Background(), `
+			var playerID string
+			err := db.QueryRow(context.Background(), `
 				INSERT INTO players (first_name, last_name, position, mlb_team, league_id)
 				VALUES ($1, $2, $3, $4, $5)
 				ON CONFLICT (first_name, last_name, league_id) DO UPDATE
 				SET position = EXCLUDED.position, mlb_team = EXCLUDED.mlb_team
-			`, firstName, lastName, pos, mlb, leagueID)
+				RETURNING id
+			`, firstName, lastName, pos, mlb, leagueID).Scan(&playerID)
 
 			if err == nil {
+				// Position and MLB team changes land on the canonical record and every league's copy
+				if _, err := store.SyncPlayerCopyToMaster(db, playerID); err != nil {
+					fmt.Printf("ERROR [AdminProcessCSV]: canonical sync %s %s: %v\n", firstName, lastName, err)
+				}
 				count++
 			}
 		}
@@ -123,7 +130,14 @@ func AdminProcessAssignHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		playerID := c.PostForm("player_id")
 		teamID := c.PostForm("team_id")
 
-		if teamID == "none" {
+		if teamID == "none" && c.PostForm("apply_dead_cap") == "on" {
+			// Release under the DFA dead cap rules (75% this season, 50% after) and clear the contract
+			if _, err := store.ReleasePlayerWithDeadCap(db, playerID, time.Now().Year(), "Commissioner Release"); err != nil {
+				fmt.Printf("ERROR [AdminProcessAssign]: %v\n", err)
+				c.String(http.StatusBadRequest, "Error releasing player: %v", err)
+				return
+			}
+		} else if teamID == "none" {
 			_, err := db.Exec(context.Background(), "UPDATE players SET team_id = NULL WHERE id = $1", playerID)
 			if err != nil {
 				fmt.Printf("ERROR [AdminProcessAssign]: %v\n", err)
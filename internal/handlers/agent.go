@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -47,6 +48,8 @@ Key database tables and columns for run_query:
 - leagues: id (uuid), name (text)
 - league_settings: league_id (uuid), year (int), luxury_tax_limit (numeric), roster_26_man_limit (int), roster_40_man_limit (int), sp_26_man_limit (int)
 - dead_cap_penalties: id (uuid), team_id (uuid), player_id (uuid, nullable), year (int), amount (numeric), note (text)
+- balance_ledger: id (uuid), team_id (uuid), league_id (uuid), balance_type (text — isbp/milb), amount (numeric, signed), balance_after (numeric), entry_type (text — opening/signing/trade/trade_reversal/qo_compensation/commissioner/allocation/sync), reference_type (text), reference_id (text), counterparty_team_id (uuid), description (text), created_by (text), created_at (timestamp) — every ISBP/MiLB balance change with the running balance
+- retained_salaries: id (uuid), trade_id (uuid), player_id (uuid), paying_team_id (uuid), receiving_team_id (uuid), year (int), percentage (numeric), amount (numeric), note (text), status (text — active/reversed/voided) — salary a team kept when trading a player away; counts toward the paying team's payroll
 - trades: id (uuid), proposing_team_id (uuid), receiving_team_id (uuid), league_id (uuid), status (text — PENDING/ACCEPTED/REJECTED/REVERSED), created_at (timestamp), isbp_offered (numeric), isbp_requested (numeric) — holds trade PROPOSALS made through the app
 - trade_players: trade_id (uuid), player_id (uuid), from_team_id (uuid), to_team_id (uuid) — players involved in a trade proposal
 - transactions: id (uuid), team_id (uuid), league_id (uuid), transaction_type (text — ADD/DROP/TRADE/COMMISSIONER/ROSTER/WAIVER), summary (text), created_at (timestamp), fantrax_processed (bool) — the ACTIVITY LOG of all completed actions. For trade history, use get_recent_activity with action_type='TRADE'.
@@ -96,7 +99,8 @@ Multi-step reasoning:
 - To create a new player, use create_player. To remove a team owner, use remove_team_owner.
 - For Fantrax sync queue, use get_fantrax_queue.
 - For transaction history with type filters, use get_transaction_log (supports 'Added Player', 'Dropped Player', 'Roster Move', 'Trade').
-- For team owner contact info, use get_team_owner_emails.`
+- For team owner contact info, use get_team_owner_emails.
+- For a player's trade value or contract surplus, use get_player_valuation. To weigh two sides of a trade, use compare_trade_packages.`
 
 // Tool definitions for Gemini function calling
 func getAgentTools() []*genai.Tool {
@@ -186,6 +190,10 @@ func getAgentTools() []*genai.Tool {
 								Type:        genai.TypeString,
 								Description: "Player UUID",
 							},
+							"apply_dead_cap": {
+								Type:        genai.TypeBoolean,
+								Description: "Set to true to charge dead cap under the DFA rules (75% of this season's salary, 50% of later years) and clear the contract. Omit for a correction with no dead cap. Confirm with the commissioner first.",
+							},
 						},
 						Required: []string{"player_id"},
 					},
@@ -214,7 +222,7 @@ func getAgentTools() []*genai.Tool {
 				},
 				{
 					Name:        "update_team_balance",
-					Description: "Set ISBP and/or MiLB balance for a team.",
+					Description: "Set ISBP and/or MiLB balance for a team. The change is recorded in the balance ledger.",
 					Parameters: &genai.Schema{
 						Type: genai.TypeObject,
 						Properties: map[string]*genai.Schema{
@@ -230,6 +238,10 @@ func getAgentTools() []*genai.Tool {
 								Type:        genai.TypeNumber,
 								Description: "New MiLB balance (use -1 to leave unchanged)",
 							},
+							"reason": {
+								Type:        genai.TypeString,
+								Description: "Why the balance is changing (recorded in the balance ledger)",
+							},
 						},
 						Required: []string{"team_id", "isbp_balance", "milb_balance"},
 					},
@@ -950,6 +962,42 @@ func getAgentTools() []*genai.Tool {
 						Required: []string{"team_id"},
 					},
 				},
+				{
+					Name:        "get_player_valuation",
+					Description: "Value a player: age-adjusted projected and actual points priced above the position's replacement level, compared to each remaining contract year's salary. Returns per-year value, salary and surplus.",
+					Parameters: &genai.Schema{
+						Type: genai.TypeObject,
+						Properties: map[string]*genai.Schema{
+							"player_id": {
+								Type:        genai.TypeString,
+								Description: "Player UUID",
+							},
+						},
+						Required: []string{"player_id"},
+					},
+				},
+				{
+					Name:        "compare_trade_packages",
+					Description: "Compare the total value, salary and contract surplus of two packages of players in a league, e.g. both sides of a proposed trade.",
+					Parameters: &genai.Schema{
+						Type: genai.TypeObject,
+						Properties: map[string]*genai.Schema{
+							"league_id": {
+								Type:        genai.TypeString,
+								Description: "League UUID",
+							},
+							"side_a": {
+								Type:        genai.TypeString,
+								Description: "Comma-separated player UUIDs on one side",
+							},
+							"side_b": {
+								Type:        genai.TypeString,
+								Description: "Comma-separated player UUIDs on the other side",
+							},
+						},
+						Required: []string{"league_id", "side_a", "side_b"},
+					},
+				},
 			},
 		},
 	}
@@ -1306,6 +1354,10 @@ func executeTool(db *pgxpool.Pool, ac *agentCtx, name string, args map[string]in
 		result = toolCheckRosterExpansion(db, ac, args)
 	case "get_team_owner_emails":
 		result = toolGetTeamOwnerEmails(db, ac, args)
+	case "get_player_valuation":
+		result = toolGetPlayerValuation(db, ac, args)
+	case "compare_trade_packages":
+		result = toolCompareTradePackages(db, ac, args)
 	default:
 		result = map[string]interface{}{"error": "Unknown tool: " + name}
 	}
@@ -1567,6 +1619,20 @@ func toolReleasePlayer(db *pgxpool.Pool, args map[string]interface{}) map[string
 		return map[string]interface{}{"error": "player_id is required"}
 	}
 
+	if applyDeadCap, _ := args["apply_dead_cap"].(bool); applyDeadCap {
+		deadCap, err := store.ReleasePlayerWithDeadCap(db, playerID, time.Now().Year(), "Commissioner Release")
+		if err != nil {
+			fmt.Printf("ERROR [AgentTool:release_player]: %v\n", err)
+			return map[string]interface{}{"error": fmt.Sprintf("Failed to release player: %v", err)}
+		}
+		fmt.Printf("AGENT ACTION: Released player %s with $%.0f dead cap\n", playerID, deadCap)
+		return map[string]interface{}{
+			"success":  true,
+			"dead_cap": deadCap,
+			"message":  fmt.Sprintf("Player %s released to free agency; $%.0f dead cap charged", playerID, deadCap),
+		}
+	}
+
 	ctx := context.Background()
 	_, err := db.Exec(ctx,
 		"UPDATE players SET team_id = NULL, status_40_man = FALSE, status_26_man = FALSE WHERE id = $1",
@@ -1635,7 +1701,7 @@ func toolUpdateTeamBalance(db *pgxpool.Pool, args map[string]interface{}) map[st
 		newMiLB = milb
 	}
 
-	err = store.SetTeamBalance(db, teamID, newISBP, newMiLB)
+	err = store.SetTeamBalance(db, teamID, newISBP, newMiLB, "agent", getStringArg(args, "reason"))
 	if err != nil {
 		fmt.Printf("ERROR [AgentTool:update_team_balance]: %v\n", err)
 		return map[string]interface{}{"error": "Failed to update balance"}
@@ -2136,11 +2202,29 @@ func toolGetTeamPayrolls(db *pgxpool.Pool, ac *agentCtx, args map[string]interfa
 		deadCapRows.Close()
 	}
 
-	// Add dead cap to team results and mark over-tax
+	// Get salary retained on players traded away per team for this year
+	retainedRows, err := db.Query(ctx,
+		`SELECT paying_team_id, COALESCE(SUM(amount), 0)
+		 FROM retained_salaries
+		 WHERE year = $1 AND status = 'active' AND paying_team_id IN (SELECT id FROM teams WHERE league_id = $2)
+		 GROUP BY paying_team_id`, year, leagueID)
+	retainedMap := map[string]float64{}
+	if err == nil {
+		for retainedRows.Next() {
+			var tid string
+			var amt float64
+			retainedRows.Scan(&tid, &amt)
+			retainedMap[tid] = amt
+		}
+		retainedRows.Close()
+	}
+
+	// Add dead cap and retained salary to team results and mark over-tax
 	for _, t := range teams {
 		tid := t["team_id"].(string)
-		dc := deadCapMap[tid]
-		t["dead_cap"] = dc
+		dc := deadCapMap[tid] + retainedMap[tid]
+		t["dead_cap"] = deadCapMap[tid]
+		t["retained_salary"] = retainedMap[tid]
 		t["total_with_dead_cap"] = t["total_salary"].(float64) + dc
 		if luxuryTaxLimit > 0 {
 			t["over_luxury_tax"] = (t["total_salary"].(float64) + dc) > luxuryTaxLimit
@@ -3227,6 +3311,10 @@ func toolUpdatePlayerBid(db *pgxpool.Pool, ac *agentCtx, args map[string]interfa
 	if len(sets) == 0 {
 		return map[string]interface{}{"error": "No bid fields provided to update"}
 	}
+	if !isFlatBid {
+		// A manual override replaces any per-year salary schedule with a flat AAV deal
+		sets = append(sets, "pending_bid_schedule = NULL")
+	}
 
 	query := fmt.Sprintf("UPDATE players SET %s WHERE id = $%d", strings.Join(sets, ", "), argN)
 	vals = append(vals, playerID)
@@ -3445,25 +3533,43 @@ func toolGetPlayerGameLog(db *pgxpool.Pool, ac *agentCtx, args map[string]interf
 		limit = 20
 	}
 
-	// Auto-detect stat type from player position if not specified
+	// Auto-detect stat type from player position if not specified; two-way players get both
+	statTypes := []string{statType}
 	if statType == "" {
 		ctx := context.Background()
 		var position string
-		err := db.QueryRow(ctx, "SELECT COALESCE(position, '') FROM players WHERE id = $1", playerID).Scan(&position)
+		var isTwoWay bool
+		err := db.QueryRow(ctx, "SELECT COALESCE(position, ''), is_two_way FROM players WHERE id = $1", playerID).Scan(&position, &isTwoWay)
 		if err != nil {
 			return map[string]interface{}{"error": "Player not found"}
 		}
-		if position == "SP" || position == "RP" || position == "P" {
+		if isTwoWay {
+			statType = "pitching,hitting"
+			statTypes = []string{"pitching", "hitting"}
+		} else if position == "SP" || position == "RP" || position == "P" {
 			statType = "pitching"
+			statTypes = []string{statType}
 		} else {
 			statType = "hitting"
+			statTypes = []string{statType}
 		}
 	}
 
-	games, err := store.GetPlayerGameLog(db, playerID, statType, limit)
-	if err != nil {
-		fmt.Printf("ERROR [AgentTool:get_player_game_log]: %v\n", err)
-		return map[string]interface{}{"error": "Failed to load game log"}
+	var games []store.DailyPlayerStats
+	for _, st := range statTypes {
+		logs, err := store.GetPlayerGameLog(db, playerID, st, limit)
+		if err != nil {
+			fmt.Printf("ERROR [AgentTool:get_player_game_log]: %v\n", err)
+			return map[string]interface{}{"error": "Failed to load game log"}
+		}
+		games = append(games, logs...)
+	}
+	// Two-way logs interleave by date and share the limit
+	if len(statTypes) > 1 {
+		sort.SliceStable(games, func(i, j int) bool { return games[i].GameDate > games[j].GameDate })
+		if len(games) > limit {
+			games = games[:limit]
+		}
 	}
 
 	var results []map[string]interface{}
@@ -3471,6 +3577,7 @@ func toolGetPlayerGameLog(db *pgxpool.Pool, ac *agentCtx, args map[string]interf
 		entry := map[string]interface{}{
 			"game_date":      g.GameDate,
 			"opponent":       g.Opponent,
+			"level":          g.Level,
 			"fantasy_points": g.FantasyPoints,
 			"stat_type":      g.StatType,
 		}
@@ -3979,3 +4086,48 @@ func toolGetTeamOwnerEmails(db *pgxpool.Pool, ac *agentCtx, args map[string]inte
 		"emails":    emails,
 	}
 }
+
+func toolGetPlayerValuation(db *pgxpool.Pool, ac *agentCtx, args map[string]interface{}) map[string]interface{} {
+	playerID := getStringArg(args, "player_id")
+	if playerID == "" {
+		return map[string]interface{}{"error": "player_id is required"}
+	}
+
+	valuation, err := store.GetPlayerValuation(db, playerID)
+	if err != nil {
+		return map[string]interface{}{"error": "Player not found"}
+	}
+	if !ac.canAccessLeague(valuation.LeagueID) {
+		return map[string]interface{}{"error": "Player is not in your managed leagues"}
+	}
+
+	return map[string]interface{}{"valuation": valuation}
+}
+
+func toolCompareTradePackages(db *pgxpool.Pool, ac *agentCtx, args map[string]interface{}) map[string]interface{} {
+	leagueID := getStringArg(args, "league_id")
+	if leagueID == "" {
+		return map[string]interface{}{"error": "league_id is required"}
+	}
+	if !ac.canAccessLeague(leagueID) {
+		return map[string]interface{}{"error": "You don't have access to this league"}
+	}
+	sideA := splitIDList(getStringArg(args, "side_a"))
+	sideB := splitIDList(getStringArg(args, "side_b"))
+	if len(sideA) == 0 && len(sideB) == 0 {
+		return map[string]interface{}{"error": "side_a or side_b is required"}
+	}
+
+	a, b, err := store.ComparePackages(db, leagueID, sideA, sideB)
+	if err != nil {
+		fmt.Printf("ERROR [AgentTool:compare_trade_packages]: %v\n", err)
+		return map[string]interface{}{"error": "Failed to value packages"}
+	}
+
+	return map[string]interface{}{
+		"side_a":              a,
+		"side_b":              b,
+		"net_surplus":         a.TotalSurplus - b.TotalSurplus,
+		"net_surplus_present": a.SurplusPV - b.SurplusPV,
+	}
+}
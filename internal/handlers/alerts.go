@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdminAlertsHandler renders a league's live alert rules and recently posted alerts.
+func AdminAlertsHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		rules, err := store.GetAlertRules(db, leagueID)
+		if err != nil {
+			fmt.Printf("ERROR [AdminAlerts]: %v\n", err)
+		}
+		events, _ := store.GetRecentAlertEvents(db, leagueID, 50)
+		players, _ := store.GetAlertPlayerOptions(db, leagueID)
+		pitchingCats, _ := store.GetScoringCategories(db, "pitching")
+		hittingCats, _ := store.GetScoringCategories(db, "hitting")
+
+		var teams []store.Team
+		teamNames := make(map[string]string)
+		for _, l := range leagues {
+			if l.ID == leagueID {
+				teams = l.Teams
+			}
+			for _, t := range l.Teams {
+				teamNames[t.ID] = t.Name
+			}
+		}
+		playerNames := make(map[string]string)
+		for _, p := range players {
+			playerNames[p.ID] = p.Name
+		}
+
+		eventLabels := make(map[string]string)
+		for _, et := range store.AlertEventTypes {
+			eventLabels[et.Key] = et.Label
+		}
+
+		// Who each rule covers, for the rules table
+		scopes := make(map[string]string)
+		for _, r := range rules {
+			var names []string
+			switch r.Scope {
+			case "teams":
+				for _, id := range r.TeamIDs {
+					names = append(names, teamNames[id])
+				}
+			case "players":
+				for _, id := range r.PlayerIDs {
+					names = append(names, playerNames[id])
+				}
+			}
+			if len(names) == 0 {
+				scopes[r.ID] = "All rostered players"
+			} else {
+				scopes[r.ID] = strings.Join(names, ", ")
+			}
+		}
+
+		RenderTemplate(c, "admin_alerts.html", gin.H{
+			"User":         user,
+			"Leagues":      leagues,
+			"LeagueID":     leagueID,
+			"Rules":        rules,
+			"Scopes":       scopes,
+			"Events":       events,
+			"Teams":        teams,
+			"Players":      players,
+			"PitchingCats": pitchingCats,
+			"HittingCats":  hittingCats,
+			"EventTypes":   store.AlertEventTypes,
+			"EventLabels":  eventLabels,
+			"NotifyTypes":  store.AlertNotifyTypes,
+			"Saved":        c.Query("saved") == "1",
+			"Error":        c.Query("error"),
+			"IsCommish":    true,
+		})
+	}
+}
+
+// AdminCreateAlertRuleHandler adds a live alert rule to a league.
+func AdminCreateAlertRuleHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if leagueID == "" {
+			c.String(http.StatusBadRequest, "Missing league")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		fail := func(msg string) {
+			c.Redirect(http.StatusFound, "/admin/alerts?league_id="+leagueID+"&error="+msg)
+		}
+
+		rule := store.AlertRule{
+			LeagueID:   leagueID,
+			Name:       strings.TrimSpace(c.PostForm("name")),
+			EventType:  c.PostForm("event_type"),
+			Scope:      c.PostForm("scope"),
+			NotifyType: c.PostForm("notify_type"),
+			ChannelID:  strings.TrimSpace(c.PostForm("channel_id")),
+		}
+
+		validEvent := false
+		for _, et := range store.AlertEventTypes {
+			if et.Key == rule.EventType {
+				validEvent = true
+				if rule.Name == "" {
+					rule.Name = et.Label
+				}
+			}
+		}
+		if !validEvent {
+			fail("invalid_event")
+			return
+		}
+
+		validNotify := false
+		for _, nt := range store.AlertNotifyTypes {
+			if nt.Key == rule.NotifyType {
+				validNotify = true
+			}
+		}
+		if !validNotify {
+			rule.NotifyType = "stat_alerts"
+		}
+
+		if t := c.PostForm("threshold"); t != "" {
+			threshold, err := strconv.ParseFloat(t, 64)
+			if err != nil || threshold < 0 {
+				fail("invalid_threshold")
+				return
+			}
+			rule.Threshold = threshold
+		}
+
+		if rule.EventType == "stat_threshold" {
+			// stat_key arrives as "<stat_type>:<key>"
+			parts := strings.SplitN(c.PostForm("stat_key"), ":", 2)
+			if len(parts) != 2 || (parts[0] != "pitching" && parts[0] != "hitting") || rule.Threshold <= 0 {
+				fail("invalid_threshold")
+				return
+			}
+			rule.StatType, rule.StatKey = parts[0], parts[1]
+		}
+
+		switch rule.Scope {
+		case "teams":
+			rule.TeamIDs = c.PostFormArray("team_ids")
+			if len(rule.TeamIDs) == 0 {
+				fail("no_teams")
+				return
+			}
+		case "players":
+			rule.PlayerIDs = c.PostFormArray("player_ids")
+			if len(rule.PlayerIDs) == 0 {
+				fail("no_players")
+				return
+			}
+		default:
+			rule.Scope = "rostered"
+		}
+
+		if err := store.CreateAlertRule(db, &rule); err != nil {
+			fmt.Printf("ERROR [AdminCreateAlertRule]: %v\n", err)
+			fail("save_failed")
+			return
+		}
+		c.Redirect(http.StatusFound, "/admin/alerts?league_id="+leagueID+"&saved=1")
+	}
+}
+
+// AdminToggleAlertRuleHandler pauses or resumes a rule.
+func AdminToggleAlertRuleHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		ruleLeagueID, err := store.GetAlertRuleLeagueID(db, c.Param("id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Rule not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, ruleLeagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		leagueID, err := store.ToggleAlertRule(db, c.Param("id"))
+		if err != nil {
+			fmt.Printf("ERROR [AdminToggleAlertRule]: %v\n", err)
+			c.String(http.StatusNotFound, "Rule not found")
+			return
+		}
+		c.Redirect(http.StatusFound, "/admin/alerts?league_id="+leagueID)
+	}
+}
+
+// AdminDeleteAlertRuleHandler removes a rule.
+func AdminDeleteAlertRuleHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		ruleLeagueID, err := store.GetAlertRuleLeagueID(db, c.Param("id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Rule not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, ruleLeagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		leagueID, err := store.DeleteAlertRule(db, c.Param("id"))
+		if err != nil {
+			fmt.Printf("ERROR [AdminDeleteAlertRule]: %v\n", err)
+			c.String(http.StatusNotFound, "Rule not found")
+			return
+		}
+		c.Redirect(http.StatusFound, "/admin/alerts?league_id="+leagueID)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -39,34 +40,74 @@ func ArbitrationHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		var players []store.ArbitrationPlayer
+		var decided []store.ArbitrationHearing
+		filingDeadline := ""
 		if selectedTeam.ID != "" {
+			// Hearings are opened by the seasonal worker
 			players, _ = store.GetArbitrationEligiblePlayers(db, selectedTeam.ID, targetYear)
+
+			// All decided hearings league-wide are public
+			decided, _ = store.GetArbitrationHearings(db, selectedTeam.LeagueID, targetYear, "decided")
+			if d, err := store.GetLeagueDateValue(db, selectedTeam.LeagueID, targetYear, "arb_filing_deadline"); err == nil {
+				filingDeadline = d.Format("January 2, 2006")
+			}
 		}
 
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
 		RenderTemplate(c, "arbitration.html", gin.H{
-			"User":         user,
-			"MyTeams":      myTeams,
-			"SelectedTeam": selectedTeam,
-			"TargetYear":   targetYear,
-			"Players":      players,
-			"IsCommish":    len(adminLeagues) > 0,
+			"User":           user,
+			"MyTeams":        myTeams,
+			"SelectedTeam":   selectedTeam,
+			"TargetYear":     targetYear,
+			"Players":        players,
+			"Decided":        decided,
+			"FilingDeadline": filingDeadline,
+			"IsCommish":      len(adminLeagues) > 0,
 		})
 	}
 }
 
 func SubmitArbitrationHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
 		playerID := c.PostForm("player_id")
 		teamID := c.PostForm("team_id")
-		leagueID := c.PostForm("league_id")
 		year, _ := strconv.Atoi(c.PostForm("year"))
 		
 		decline := c.PostForm("decline") == "true"
 		amount, _ := strconv.ParseFloat(c.PostForm("amount"), 64)
 
-		err := store.SubmitArbitrationDecision(db, playerID, teamID, leagueID, year, amount, decline)
+		isOwner, _ := store.IsTeamOwner(db, teamID, user.ID)
+		if !isOwner && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Unauthorized")
+			return
+		}
+		leagueID, err := store.GetTeamLeagueID(db, teamID)
+		if err != nil {
+			c.String(http.StatusNotFound, "Team not found")
+			return
+		}
+		if !decline && amount <= 0 {
+			c.String(http.StatusBadRequest, "Please enter the team's arbitration figure")
+			return
+		}
+		if decline && c.PostForm("confirm_dead_cap") != "1" {
+			c.String(http.StatusBadRequest, "Please review the dead cap before declining arbitration")
+			return
+		}
+
+		// Filing deadline enforcement (unfiled hearings are awarded the player's figure); nothing
+		// can be filed once the hearing deadline has passed either
+		for _, key := range []string{"arb_filing_deadline", "arb_hearing_deadline"} {
+			deadline, deadlineErr := store.GetLeagueDateValue(db, leagueID, year, key)
+			if deadlineErr == nil && time.Now().After(deadline) {
+				c.String(http.StatusForbidden, "The arbitration filing deadline has passed (%s).", deadline.Format("January 2, 2006"))
+				return
+			}
+		}
+
+		err = store.SubmitArbitrationDecision(db, playerID, teamID, leagueID, year, amount, decline)
 		if err != nil {
 			fmt.Printf("ERROR [SubmitArbitration]: %v\n", err)
 			c.String(http.StatusInternalServerError, "Internal server error")
@@ -102,3 +143,120 @@ func SubmitArbExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		c.Redirect(http.StatusFound, "/arbitration?team_id="+teamID)
 	}
 }
+
+// --- Arbitration Hearings (Commissioner) ---
+
+func AdminArbitrationHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		year, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			year = time.Now().Year()
+		}
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		hearings, err := store.GetArbitrationHearings(db, leagueID, year, "")
+		if err != nil {
+			fmt.Printf("ERROR [AdminArbitration]: %v\n", err)
+		}
+
+		hearingDeadline := ""
+		if d, err := store.GetLeagueDateValue(db, leagueID, year, "arb_hearing_deadline"); err == nil {
+			hearingDeadline = d.Format("January 2, 2006")
+		}
+
+		RenderTemplate(c, "admin_arbitration.html", gin.H{
+			"User":            user,
+			"Leagues":         leagues,
+			"LeagueID":        leagueID,
+			"Year":            year,
+			"Hearings":        hearings,
+			"Formula":         store.GetArbitrationFormula(db, leagueID, year),
+			"HearingDeadline": hearingDeadline,
+			"SaveSuccess":     c.Query("saved") == "1",
+			"IsCommish":       true,
+		})
+	}
+}
+
+func AdminRuleArbitrationHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		hearingID := c.PostForm("hearing_id")
+		year := c.PostForm("year")
+		leagueID, err := store.GetArbitrationHearingLeagueID(db, hearingID)
+		if err != nil {
+			c.String(http.StatusNotFound, "Hearing not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		summary, err := store.RuleArbitrationHearing(db, hearingID, c.PostForm("ruling"), user.ID)
+		if err != nil {
+			fmt.Printf("ERROR [AdminRuleArbitration]: %v\n", err)
+			c.String(http.StatusBadRequest, "Ruling failed: %v", err)
+			return
+		}
+		notification.SendSlackNotification(db, leagueID, "transactions", summary)
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/arbitration?league_id=%s&year=%s&saved=1", leagueID, year))
+	}
+}
+
+func AdminSaveArbitrationFormulaHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		year, _ := strconv.Atoi(c.PostForm("year"))
+		var f store.ArbitrationFormula
+		f.BaseSalary, _ = strconv.ParseFloat(c.PostForm("base_salary"), 64)
+		f.DollarsPerPoint, _ = strconv.ParseFloat(c.PostForm("dollars_per_point"), 64)
+		f.Class1, _ = strconv.ParseFloat(c.PostForm("class1_multiplier"), 64)
+		f.Class2, _ = strconv.ParseFloat(c.PostForm("class2_multiplier"), 64)
+		f.Class3, _ = strconv.ParseFloat(c.PostForm("class3_multiplier"), 64)
+
+		if err := store.UpsertArbitrationFormula(db, leagueID, year, f); err != nil {
+			fmt.Printf("ERROR [AdminSaveArbitrationFormula]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/arbitration?league_id=%s&year=%d&saved=1", leagueID, year))
+	}
+}
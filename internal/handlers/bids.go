@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -139,7 +140,8 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 					pending_bid_manager_id = $3,
 					bid_start_time = NOW(),
 					bid_end_time = $4,
-					bid_type = 'milb'
+					bid_type = 'milb',
+					pending_bid_schedule = NULL
 				WHERE id = $5
 			`, signingAmount, teamID, user.ID, endTime, playerID)
 
@@ -149,7 +151,7 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 				return
 			}
 
-			store.AppendBidHistory(db, playerID, teamID, signingAmount, 1, signingAmount)
+			store.AppendBidHistory(db, playerID, teamID, signingAmount, 1, signingAmount, nil)
 			c.Redirect(http.StatusFound, "/player/"+playerID)
 			return
 		}
@@ -220,7 +222,8 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 					pending_bid_manager_id = $3,
 					bid_start_time = NOW(),
 					bid_end_time = $4,
-					bid_type = 'ifa'
+					bid_type = 'ifa',
+					pending_bid_schedule = NULL
 				WHERE id = $5
 			`, signingBonus, teamID, user.ID, endTime, playerID)
 
@@ -230,7 +233,7 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 				return
 			}
 
-			store.AppendBidHistory(db, playerID, teamID, signingBonus, 1, signingBonus)
+			store.AppendBidHistory(db, playerID, teamID, signingBonus, 1, signingBonus, nil)
 			c.Redirect(http.StatusFound, "/player/"+playerID)
 			return
 		}
@@ -252,16 +255,20 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
-		// AAV minimum: $760,000
-		if aav < 760000 {
-			c.String(http.StatusBadRequest, "Minimum AAV is $760,000.")
+		// Per-year salary schedule (flat, front/back-loaded or custom) checked against the
+		// league's minimum salary and max year-over-year variance
+		settings := store.GetLeagueSettings(db, leagueID, time.Now().Year())
+		schedule, err := parseSalarySchedule(c, years, aav, settings)
+		if err != nil {
+			c.String(http.StatusBadRequest, "Invalid salary schedule: %v", err)
 			return
 		}
+		totalValue := store.ScheduleTotal(schedule)
+		aav = totalValue / float64(years)
+		presentValue := store.SchedulePresentValue(schedule, settings.DiscountRate)
 
-		// Calculate Bid Points
-		multipliers := map[int]float64{1: 2.0, 2: 1.8, 3: 1.6, 4: 1.4, 5: 1.2}
-		multiplier := multipliers[years]
-		bidPoints := (float64(years) * aav * multiplier) / 1000000
+		// Calculate Bid Points from total and present value
+		bidPoints := store.ScheduleBidPoints(schedule, settings.DiscountRate)
 
 		// Minimum bid points: 1.0
 		if bidPoints < 1.0 {
@@ -282,21 +289,35 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		// Check Current Bid
-		var currentPoints float64
+		var currentPoints, currentYears, currentAAV float64
 		var currentStatus, currentBidType, playerName string
+		var currentScheduleJSON []byte
 		err = db.QueryRow(context.Background(),
-			"SELECT COALESCE(pending_bid_amount, 0), fa_status, COALESCE(bid_type, 'standard'), first_name || ' ' || last_name FROM players WHERE id = $1",
-			playerID).Scan(&currentPoints, &currentStatus, &currentBidType, &playerName)
+			`SELECT COALESCE(pending_bid_amount, 0), fa_status, COALESCE(bid_type, 'standard'), first_name || ' ' || last_name,
+			        COALESCE(pending_bid_years, 0), COALESCE(pending_bid_aav, 0), pending_bid_schedule
+			 FROM players WHERE id = $1`,
+			playerID).Scan(&currentPoints, &currentStatus, &currentBidType, &playerName, &currentYears, &currentAAV, &currentScheduleJSON)
 
 		// MLB standard bid always overrides MiLB/IFA bids (different currency);
 		// only compare points when the existing bid is also a standard bid
 		if currentStatus == "pending_bid" && currentBidType == "standard" && bidPoints < currentPoints+1 {
-			c.String(http.StatusBadRequest, "Bid too low. Must beat current bid by at least 1 point.")
+			var currentSchedule []float64
+			json.Unmarshal(currentScheduleJSON, &currentSchedule)
+			if len(currentSchedule) == 0 {
+				for i := 0; i < int(currentYears); i++ {
+					currentSchedule = append(currentSchedule, currentAAV)
+				}
+			}
+			c.String(http.StatusBadRequest, fmt.Sprintf(
+				"Bid too low. Must beat current bid by at least 1 point. Current bid: %.2f points ($%.0f total, $%.0f present value). Yours: %.2f points ($%.0f total, $%.0f present value).",
+				currentPoints, store.ScheduleTotal(currentSchedule), store.SchedulePresentValue(currentSchedule, settings.DiscountRate),
+				bidPoints, totalValue, presentValue))
 			return
 		}
 
 		// Update Player with New Bid
 		endTime := time.Now().Add(bidDuration())
+		scheduleJSON, _ := json.Marshal(schedule)
 
 		_, err = db.Exec(context.Background(), `
 			UPDATE players SET
@@ -308,9 +329,10 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 				pending_bid_manager_id = $5,
 				bid_start_time = NOW(),
 				bid_end_time = $6,
-				bid_type = 'standard'
+				bid_type = 'standard',
+				pending_bid_schedule = $8
 			WHERE id = $7
-		`, bidPoints, years, aav, teamID, user.ID, endTime, playerID)
+		`, bidPoints, years, aav, teamID, user.ID, endTime, playerID, scheduleJSON)
 
 		if err != nil {
 			fmt.Printf("ERROR [SubmitBid]: %v\n", err)
@@ -319,7 +341,7 @@ func SubmitBidHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		// Append to bid_history JSONB
-		store.AppendBidHistory(db, playerID, teamID, bidPoints, years, aav)
+		store.AppendBidHistory(db, playerID, teamID, bidPoints, years, aav, schedule)
 
 		// --- SLACK NOTIFICATION ---
 		// msg := fmt.Sprintf("⚾ *New Bid!* %s has bid %.2f points on *%s* (%d years @ $%s AAV). Auction ends in 24 hours.", 
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,27 +19,63 @@ func TeamOptionsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet("user").(*store.User)
 		
-		players2026, _ := store.GetPlayersWithOptions(db, "", 2026)
-		players2027, _ := store.GetPlayersWithOptions(db, "", 2027)
-		allPlayers := append(players2026, players2027...)
+		year := time.Now().Year()
+		playersThisYear, _ := store.GetPlayersWithOptions(db, "", year)
+		playersNextYear, _ := store.GetPlayersWithOptions(db, "", year+1)
+		allPlayers := append(playersThisYear, playersNextYear...)
 
 		var myOptions []store.OptionPlayer
 		myTeams, _ := store.GetManagedTeams(db, user.ID)
 		isAdmin := user.Role == "admin"
-		
+
+		// Decision deadline and default action per league (set in league settings)
+		deadlines := make(map[string]string)
+		defaults := make(map[string]string)
+
 		for _, p := range allPlayers {
 			isOwner := false
 			for _, mt := range myTeams {
 				if mt.ID == p.TeamID { isOwner = true; break }
 			}
 			if isOwner || isAdmin {
+				if _, ok := defaults[p.LeagueID]; !ok {
+					defaults[p.LeagueID] = store.GetLeagueSettings(db, p.LeagueID, year).OptionDefaultAction
+					if deadline, err := store.GetLeagueDateValue(db, p.LeagueID, year, "option_deadline"); err == nil {
+						deadlines[p.LeagueID] = deadline.Format("Jan 2, 2006")
+					}
+				}
+				p.Deadline = deadlines[p.LeagueID]
+				p.DefaultAction = defaults[p.LeagueID]
 				myOptions = append(myOptions, p)
 			}
 		}
 
+		// Player-side clauses (player/mutual options, opt-outs) on my teams
+		var playerSide []store.ContractOption
+		seenLeagues := make(map[string]bool)
+		for _, mt := range myTeams {
+			if seenLeagues[mt.LeagueID] {
+				continue
+			}
+			seenLeagues[mt.LeagueID] = true
+			leagueOptions, _ := store.GetContractOptions(db, mt.LeagueID, "pending")
+			for _, o := range leagueOptions {
+				if o.OptionType == "team" {
+					continue
+				}
+				for _, t := range myTeams {
+					if t.ID == o.TeamID {
+						playerSide = append(playerSide, o)
+						break
+					}
+				}
+			}
+		}
+
 		RenderTemplate(c, "team_options.html", gin.H{
-			"User":    user,
-			"Options": myOptions,
+			"User":       user,
+			"Options":    myOptions,
+			"PlayerSide": playerSide,
 		})
 	}
 }
@@ -60,7 +97,7 @@ func ProcessOptionDecisionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		// Team option deadline enforcement
 		now := time.Now()
-		deadline, deadlineErr := store.GetLeagueDateValue(db, player.LeagueID, now.Year(), "option_deadline")
+		deadline, deadlineErr := store.GetOptionDeadline(db, playerID, player.LeagueID, year)
 		if deadlineErr == nil && now.After(deadline) {
 			c.String(http.StatusForbidden, "The team option deadline has passed (%s). Option decisions are no longer accepted.", deadline.Format("January 2, 2006"))
 			return
@@ -86,7 +123,7 @@ func SubmitExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		optionYears, _ := strconv.Atoi(c.PostForm("option_years"))
 		war := c.PostForm("war")
 
-		if years < 1 || years > 8 || aav <= 0 {
+		if years < 1 || years > 8 || (aav <= 0 && c.PostForm("salary_shape") != "custom") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid extension parameters"})
 			return
 		}
@@ -150,10 +187,20 @@ func SubmitExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
-		// Build year-by-year contract map (guaranteed + option years all at same AAV)
+		// Guaranteed years follow the requested salary schedule; option years pay the AAV
+		schedule, err := parseSalarySchedule(c, years, aav, store.GetLeagueSettings(db, player.LeagueID, now.Year()))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		aav = store.ScheduleTotal(schedule) / float64(years)
 		salaries := make(map[string]float64)
 		for i := 0; i < totalYears; i++ {
-			salaries[fmt.Sprintf("%d", startYear+i)] = aav
+			if i < years {
+				salaries[fmt.Sprintf("%d", startYear+i)] = schedule[i]
+			} else {
+				salaries[fmt.Sprintf("%d", startYear+i)] = aav
+			}
 		}
 
 		// Track which years are team options
@@ -163,20 +210,20 @@ func SubmitExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		// Build contract data with option year metadata
-		type extensionData struct {
-			Salaries    map[string]float64 `json:"salaries"`
-			OptionYears []int              `json:"option_years"`
+		extData := store.ExtensionContract{Salaries: salaries, OptionYears: optionYearsList}
+		if err := parseOptionTerms(c, &extData, startYear, years); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		extData := extensionData{Salaries: salaries, OptionYears: optionYearsList}
 		contractData, _ := json.Marshal(extData)
 
 		endYear := startYear + years - 1
 		summary := fmt.Sprintf("Extension request for %s %s: %d years at $%s AAV (years %d-%d)",
 			player.FirstName, player.LastName, years, formatDollar(aav), startYear, endYear)
-		if optionYears > 0 {
-			optEnd := startYear + totalYears - 1
-			summary += fmt.Sprintf(" + %d team option year(s) (%d-%d)", optionYears, endYear+1, optEnd)
+		if !store.IsFlatSchedule(schedule) {
+			summary += fmt.Sprintf(" [%s]", store.DescribeSchedule(schedule))
 		}
+		summary += describeOptionTerms(extData, optionYears, endYear)
 		if war != "" {
 			summary += fmt.Sprintf(" | 3-Year WAR: %s", war)
 		}
@@ -196,6 +243,58 @@ func SubmitExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	}
 }
 
+// parseSalarySchedule reads the salary shape ('flat', 'front', 'back' or 'custom' with
+// salary_1..salary_N) from a bid or extension form and builds the per-year schedule.
+func parseSalarySchedule(c *gin.Context, years int, aav float64, settings store.LeagueSettings) ([]float64, error) {
+	shape := c.DefaultPostForm("salary_shape", "flat")
+	var custom []float64
+	if shape == "custom" {
+		for i := 1; i <= years; i++ {
+			salary, err := strconv.ParseFloat(c.PostForm(fmt.Sprintf("salary_%d", i)), 64)
+			if err != nil {
+				return nil, fmt.Errorf("enter a salary for year %d", i)
+			}
+			custom = append(custom, salary)
+		}
+	}
+	return store.BuildSalarySchedule(shape, years, aav, custom, settings)
+}
+
+// parseOptionTerms reads who holds the option years, the option buyout and an optional
+// opt-out year from an extension form.
+func parseOptionTerms(c *gin.Context, ext *store.ExtensionContract, startYear, guaranteedYears int) error {
+	ext.OptionType = c.DefaultPostForm("option_type", "team")
+	if ext.OptionType != "team" && ext.OptionType != "player" && ext.OptionType != "mutual" {
+		return fmt.Errorf("Option type must be team, player or mutual")
+	}
+	ext.OptionBuyout, _ = strconv.ParseFloat(c.PostForm("option_buyout"), 64)
+	if ext.OptionBuyout < 0 {
+		return fmt.Errorf("Option buyout cannot be negative")
+	}
+	if val := c.PostForm("opt_out_after"); val != "" && val != "0" {
+		ext.OptOutAfter, _ = strconv.Atoi(val)
+		endYear := startYear + guaranteedYears - 1
+		if ext.OptOutAfter < startYear || ext.OptOutAfter >= endYear {
+			return fmt.Errorf("An opt-out must follow a guaranteed year before the final year (%d-%d)", startYear, endYear-1)
+		}
+	}
+	return nil
+}
+
+func describeOptionTerms(ext store.ExtensionContract, optionYears, endYear int) string {
+	var s string
+	if optionYears > 0 {
+		s += fmt.Sprintf(" + %d %s option year(s) (%d-%d)", optionYears, ext.OptionType, endYear+1, endYear+optionYears)
+		if ext.OptionBuyout > 0 {
+			s += fmt.Sprintf(", $%s buyout", formatDollar(ext.OptionBuyout))
+		}
+	}
+	if ext.OptOutAfter > 0 {
+		s += fmt.Sprintf(" | Player opt-out after %d", ext.OptOutAfter)
+	}
+	return s
+}
+
 func formatDollar(amount float64) string {
 	s := fmt.Sprintf("%.0f", amount)
 	if len(s) <= 3 {
@@ -289,7 +388,7 @@ func SubmitRealLifeExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		optionYears, _ := strconv.Atoi(c.PostForm("option_years"))
 		notes := c.PostForm("notes")
 
-		if years < 1 || years > 10 || aav <= 0 {
+		if years < 1 || years > 10 || (aav <= 0 && c.PostForm("salary_shape") != "custom") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid extension parameters. Years must be 1-10 and AAV must be positive."})
 			return
 		}
@@ -351,10 +450,20 @@ func SubmitRealLifeExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
-		// Build contract data
+		// Build contract data (guaranteed years follow the salary schedule, option years the AAV)
+		schedule, err := parseSalarySchedule(c, years, aav, store.GetLeagueSettings(db, player.LeagueID, now.Year()))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		aav = store.ScheduleTotal(schedule) / float64(years)
 		salaries := make(map[string]float64)
 		for i := 0; i < totalYears; i++ {
-			salaries[fmt.Sprintf("%d", startYear+i)] = aav
+			if i < years {
+				salaries[fmt.Sprintf("%d", startYear+i)] = schedule[i]
+			} else {
+				salaries[fmt.Sprintf("%d", startYear+i)] = aav
+			}
 		}
 
 		var optionYearsList []int
@@ -362,20 +471,20 @@ func SubmitRealLifeExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			optionYearsList = append(optionYearsList, startYear+i)
 		}
 
-		type extensionData struct {
-			Salaries    map[string]float64 `json:"salaries"`
-			OptionYears []int              `json:"option_years"`
+		extData := store.ExtensionContract{Salaries: salaries, OptionYears: optionYearsList}
+		if err := parseOptionTerms(c, &extData, startYear, years); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		extData := extensionData{Salaries: salaries, OptionYears: optionYearsList}
 		contractData, _ := json.Marshal(extData)
 
 		endYear := startYear + years - 1
 		summary := fmt.Sprintf("Real-life extension for %s %s: %d years at $%s AAV (years %d-%d)",
 			player.FirstName, player.LastName, years, formatDollar(aav), startYear, endYear)
-		if optionYears > 0 {
-			optEnd := startYear + totalYears - 1
-			summary += fmt.Sprintf(" + %d team option year(s) (%d-%d)", optionYears, endYear+1, optEnd)
+		if !store.IsFlatSchedule(schedule) {
+			summary += fmt.Sprintf(" [%s]", store.DescribeSchedule(schedule))
 		}
+		summary += describeOptionTerms(extData, optionYears, endYear)
 		if notes != "" {
 			summary += fmt.Sprintf(" | Notes: %s", notes)
 		}
@@ -392,4 +501,166 @@ func SubmitRealLifeExtensionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		c.JSON(http.StatusOK, gin.H{"message": "Real-life extension request submitted for commissioner approval."})
 	}
-}
\ No newline at end of file
+}
+
+// --- Player Options & Opt-Outs (Commissioner) ---
+
+func AdminContractOptionsHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		season := time.Now().Year()
+
+		options, err := store.GetContractOptions(db, leagueID, "")
+		if err != nil {
+			fmt.Printf("ERROR [AdminContractOptions]: %v\n", err)
+		}
+		var pending, resolved []store.ContractOption
+		for _, o := range options {
+			if o.Status == "pending" {
+				pending = append(pending, o)
+			} else {
+				resolved = append(resolved, o)
+			}
+		}
+
+		RenderTemplate(c, "admin_contract_options.html", gin.H{
+			"User":        user,
+			"Leagues":     leagues,
+			"LeagueID":    leagueID,
+			"Season":      season,
+			"Settings":    store.GetLeagueSettings(db, leagueID, season),
+			"Pending":     pending,
+			"Resolved":    resolved,
+			"SaveSuccess": c.Query("saved") == "1",
+			"IsCommish":   true,
+		})
+	}
+}
+
+// AdminAddContractOptionHandler attaches an option or opt-out clause to an existing contract.
+func AdminAddContractOptionHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		playerID := c.PostForm("player_id")
+		year, _ := strconv.Atoi(c.PostForm("year"))
+		optionType := c.PostForm("option_type")
+		buyout, _ := strconv.ParseFloat(c.PostForm("buyout"), 64)
+
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		player, err := store.GetPlayerByID(db, playerID)
+		if err != nil || player.LeagueID != leagueID {
+			c.String(http.StatusBadRequest, "Player is not in this league")
+			return
+		}
+		if year < 2026 || year > 2040 {
+			c.String(http.StatusBadRequest, "Invalid option year")
+			return
+		}
+		switch optionType {
+		case "team", "player", "mutual", "opt_out":
+		default:
+			c.String(http.StatusBadRequest, "Invalid option type")
+			return
+		}
+
+		if err := store.AddContractOption(db, playerID, year, optionType, buyout, c.PostForm("deadline")); err != nil {
+			fmt.Printf("ERROR [AdminAddContractOption]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/admin/contract-options?saved=1&league_id="+leagueID)
+	}
+}
+
+// AdminRulePlayerOptionHandler records a commissioner ruling on a player-side decision.
+func AdminRulePlayerOptionHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		optionID := c.PostForm("option_id")
+		leagueID, err := store.GetContractOptionLeagueID(db, optionID)
+		if err != nil {
+			c.String(http.StatusNotFound, "Option not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		decision := c.PostForm("decision")
+		if decision != "in" && decision != "out" {
+			c.String(http.StatusBadRequest, "Invalid decision")
+			return
+		}
+
+		summary, err := store.RecordPlayerDecision(db, optionID, decision, "commissioner")
+		if err != nil {
+			fmt.Printf("ERROR [AdminRulePlayerOption]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		notification.SendSlackNotification(db, leagueID, "transactions", summary)
+
+		c.Redirect(http.StatusFound, "/admin/contract-options?saved=1&league_id="+leagueID)
+	}
+}
+
+// AdminApplyOptionRuleHandler decides all due player-side clauses using the league rule.
+func AdminApplyOptionRuleHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		season, _ := strconv.Atoi(c.PostForm("season"))
+		if _, err := store.ApplyPlayerOptionRule(db, leagueID, season); err != nil {
+			fmt.Printf("ERROR [AdminApplyOptionRule]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/admin/contract-options?saved=1&league_id="+leagueID)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DeadCapPreviewHandler returns the dead-cap schedule and post-release payroll for a release-type
+// move, so the owner can confirm it first. action: dfa, dfa_minors, waive, arb_decline, release.
+// year is the release season (defaults to the current year; arb_decline uses the arbitration year).
+func DeadCapPreviewHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		playerID := c.Query("player_id")
+		action := c.DefaultQuery("action", "dfa")
+
+		year, err := strconv.Atoi(c.Query("year"))
+		if err != nil || year < 2026 || year > 2040 {
+			year = time.Now().Year()
+		}
+
+		preview, err := store.PreviewReleaseDeadCap(db, playerID, action, year)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		isOwner, _ := store.IsTeamOwner(db, preview.TeamID, user.ID)
+		if !isOwner {
+			leagueID, _ := store.GetTeamLeagueID(db, preview.TeamID)
+			if !isLeagueCommissioner(db, user, leagueID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, preview)
+	}
+}
+
+// deadCapConfirmation responds 409 with the preview when a release-type move arrives unconfirmed.
+func deadCapConfirmation(c *gin.Context, db *pgxpool.Pool, playerID, action string, year int) {
+	preview, err := store.PreviewReleaseDeadCap(db, playerID, action, year)
+	if err != nil {
+		fmt.Printf("ERROR [DeadCapPreview]: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"error":                 "Please review and confirm the dead cap for this move",
+		"requires_confirmation": true,
+		"preview":               preview,
+	})
+}
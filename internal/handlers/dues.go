@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/dwes123/fantasy-baseball-go/internal/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- IRL Dues ---
+
+// duesYear reads the season the same way the IRL financials page does (billing rates exist for 2026-2027).
+func duesYear(s string) int {
+	year, err := strconv.Atoi(s)
+	if err != nil || year < 2026 || year > 2027 {
+		return 2026
+	}
+	return year
+}
+
+func duesRedirect(c *gin.Context, leagueID string, year int) {
+	c.Redirect(http.StatusFound, fmt.Sprintf("/admin/dues?league_id=%s&year=%d&saved=1", leagueID, year))
+}
+
+func AdminDuesHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		year := duesYear(c.Query("year"))
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		invoices, summary, err := store.GetLeagueDuesInvoices(db, leagueID, year)
+		if err != nil {
+			fmt.Printf("ERROR [AdminDues]: %v\n", err)
+		}
+
+		RenderTemplate(c, "admin_dues.html", gin.H{
+			"User":        user,
+			"Leagues":     leagues,
+			"LeagueID":    leagueID,
+			"Year":        year,
+			"Invoices":    invoices,
+			"Summary":     summary,
+			"Message":     c.Query("msg"),
+			"SaveSuccess": c.Query("saved") == "1",
+			"IsCommish":   true,
+		})
+	}
+}
+
+// AdminGenerateDuesHandler creates or refreshes every team's invoice from the current IRL
+// buy-in and luxury-tax figures. Rerunning updates the generated line items only.
+func AdminGenerateDuesHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		year := duesYear(c.PostForm("year"))
+		dueDate := c.PostForm("due_date")
+
+		results, _, err := computeIRLFinancials(db, leagueID, year)
+		if err != nil {
+			fmt.Printf("ERROR [AdminGenerateDues]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		for _, r := range results {
+			if _, err := store.SyncDuesInvoice(db, leagueID, r.TeamID, r.OwnerName, year, r.BuyIn, r.LuxuryTaxPen, dueDate, user.Username); err != nil {
+				fmt.Printf("ERROR [AdminGenerateDues] %s: %v\n", r.TeamName, err)
+			}
+		}
+		duesRedirect(c, leagueID, year)
+	}
+}
+
+func AdminUpdateDuesInvoiceHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID, err := store.GetDuesInvoiceLeagueID(db, c.PostForm("invoice_id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Invoice not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		if err := store.UpdateDuesInvoice(db, c.PostForm("invoice_id"), c.PostForm("due_date"), c.PostForm("notes")); err != nil {
+			fmt.Printf("ERROR [AdminUpdateDuesInvoice]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		duesRedirect(c, leagueID, duesYear(c.PostForm("year")))
+	}
+}
+
+func AdminAddDuesFeeHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID, err := store.GetDuesInvoiceLeagueID(db, c.PostForm("invoice_id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Invoice not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		amount, err := strconv.ParseFloat(c.PostForm("amount"), 64)
+		if err != nil {
+			c.String(http.StatusBadRequest, "Invalid amount")
+			return
+		}
+		description := c.PostForm("description")
+		if description == "" {
+			description = "Manual fee"
+		}
+		if err := store.AddDuesFee(db, c.PostForm("invoice_id"), description, amount, user.Username); err != nil {
+			fmt.Printf("ERROR [AdminAddDuesFee]: %v\n", err)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		duesRedirect(c, leagueID, duesYear(c.PostForm("year")))
+	}
+}
+
+func AdminDeleteDuesFeeHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID, err := store.GetDuesLineItemLeagueID(db, c.PostForm("item_id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Fee not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		if err := store.DeleteDuesFee(db, c.PostForm("item_id")); err != nil {
+			fmt.Printf("ERROR [AdminDeleteDuesFee]: %v\n", err)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		duesRedirect(c, leagueID, duesYear(c.PostForm("year")))
+	}
+}
+
+func AdminRecordDuesPaymentHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID, err := store.GetDuesInvoiceLeagueID(db, c.PostForm("invoice_id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Invoice not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		amount, err := strconv.ParseFloat(c.PostForm("amount"), 64)
+		if err != nil {
+			c.String(http.StatusBadRequest, "Invalid amount")
+			return
+		}
+		err = store.RecordDuesPayment(db, c.PostForm("invoice_id"), amount, c.PostForm("method"),
+			c.PostForm("note"), c.PostForm("paid_on"), user.Username)
+		if err != nil {
+			fmt.Printf("ERROR [AdminRecordDuesPayment]: %v\n", err)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		duesRedirect(c, leagueID, duesYear(c.PostForm("year")))
+	}
+}
+
+func AdminDeleteDuesPaymentHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID, err := store.GetDuesPaymentLeagueID(db, c.PostForm("payment_id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Payment not found")
+			return
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		if err := store.DeleteDuesPayment(db, c.PostForm("payment_id")); err != nil {
+			fmt.Printf("ERROR [AdminDeleteDuesPayment]: %v\n", err)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		duesRedirect(c, leagueID, duesYear(c.PostForm("year")))
+	}
+}
+
+// AdminSendDuesReminderHandler emails a single team's owners now rather than waiting for the worker.
+func AdminSendDuesReminderHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		year := duesYear(c.PostForm("year"))
+		inv, err := store.GetDuesInvoice(db, c.PostForm("invoice_id"))
+		if err != nil {
+			c.String(http.StatusNotFound, "Invoice not found")
+			return
+		}
+		leagueID := inv.LeagueID
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		msg := "Reminder sent to " + inv.TeamName
+		if inv.Outstanding <= 0 {
+			msg = inv.TeamName + " has no outstanding balance"
+		} else if !worker.SendDuesReminder(db, inv) {
+			msg = "No owner email on file for " + inv.TeamName
+		}
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/dues?league_id=%s&year=%d&msg=%s", leagueID, year, url.QueryEscape(msg)))
+	}
+}
+
+// AdminDuesExportHandler downloads the league's dues ledger (charges and payments with a
+// running balance per team) as CSV for the treasurer.
+func AdminDuesExportHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.Query("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		year := duesYear(c.Query("year"))
+		invoices, _, err := store.GetLeagueDuesInvoices(db, leagueID, year)
+		if err != nil {
+			fmt.Printf("ERROR [AdminDuesExport]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=dues_ledger_%d.csv", year))
+
+		writer := csv.NewWriter(c.Writer)
+		writer.Write([]string{"Team", "Owner", "Season", "Date", "Entry", "Description", "Charge", "Payment", "Balance", "Recorded By", "Status"})
+		for _, inv := range invoices {
+			balance := 0.0
+			for _, li := range inv.LineItems {
+				balance += li.Amount
+				writer.Write([]string{
+					inv.TeamName, inv.OwnerName, strconv.Itoa(inv.Year),
+					li.CreatedAt.Format("2006-01-02"), li.ItemType, li.Description,
+					fmt.Sprintf("%.2f", li.Amount), "", fmt.Sprintf("%.2f", balance),
+					li.CreatedBy, inv.Status,
+				})
+			}
+			for _, p := range inv.Payments {
+				balance -= p.Amount
+				description := p.Method
+				if p.Note != "" {
+					description += " - " + p.Note
+				}
+				writer.Write([]string{
+					inv.TeamName, inv.OwnerName, strconv.Itoa(inv.Year),
+					p.PaidOn.Format("2006-01-02"), "payment", description,
+					"", fmt.Sprintf("%.2f", p.Amount), fmt.Sprintf("%.2f", balance),
+					p.RecordedBy, inv.Status,
+				})
+			}
+		}
+		writer.Flush()
+	}
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/gin-gonic/gin"
@@ -19,12 +21,46 @@ func TeamFinancialsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
+		// Split the retained-salary ledger into what this team pays and what others pay for it
+		var paying, receiving []store.RetainedSalary
+		retained, _ := store.GetTeamRetainedSalaries(db, teamID)
+		for _, r := range retained {
+			if r.PayingTeamID == teamID {
+				paying = append(paying, r)
+			} else {
+				receiving = append(receiving, r)
+			}
+		}
+
+		ledger, _ := store.GetBalanceLedger(db, teamID, "", 200)
+
+		// Payroll trend for one contract year from the nightly snapshots
+		chartYear, err := strconv.Atoi(c.Query("chart_year"))
+		if err != nil || chartYear < 2026 || chartYear > 2040 {
+			chartYear = time.Now().Year()
+		}
+		history, _ := store.GetTeamPayrollHistory(db, teamID, chartYear, "", "")
+		if history == nil {
+			history = []store.PayrollSnapshot{}
+		}
+		var tradeDeadline string
+		if d, err := store.GetLeagueDateValue(db, team.LeagueID, chartYear, "trade_deadline"); err == nil {
+			tradeDeadline = d.Format("2006-01-02")
+		}
+
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
 		RenderTemplate(c, "team_financials.html", gin.H{
-			"User":      user,
-			"Team":      team,
-			"IsCommish": len(adminLeagues) > 0 || user.Role == "admin",
+			"User":              user,
+			"Team":              team,
+			"RetainedPaying":    paying,
+			"RetainedReceiving": receiving,
+			"Ledger":            ledger,
+			"PayrollHistory":    history,
+			"ChartYear":         chartYear,
+			"TradeDeadline":     tradeDeadline,
+			"IsCommish":         len(adminLeagues) > 0 || user.Role == "admin",
+			"CanPlan":           canPlanPayroll(db, teamID, user),
 		})
 	}
 }
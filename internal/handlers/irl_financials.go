@@ -68,56 +68,13 @@ func IRLFinancialsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		var leagueName string
 		db.QueryRow(context.Background(), "SELECT name FROM leagues WHERE id = $1", leagueID).Scan(&leagueName)
 
-		// Get luxury tax limit from league_settings
-		var luxuryTaxLimit float64
-		db.QueryRow(context.Background(), "SELECT COALESCE(luxury_tax_limit, 0) FROM league_settings WHERE league_id = $1 AND year = $2", leagueID, year).Scan(&luxuryTaxLimit)
-
-		// Fetch teams
-		rows, err := db.Query(context.Background(), "SELECT id, name, COALESCE(owner_name, '') FROM teams WHERE league_id = $1 ORDER BY name", leagueID)
+		results, luxuryTaxLimit, err := computeIRLFinancials(db, leagueID, year)
 		if err != nil {
 			fmt.Printf("ERROR [IRLFinancials]: %v\n", err)
 			c.String(http.StatusInternalServerError, "Internal server error")
 			return
 		}
 
-		type teamBasic struct {
-			ID, Name, Owner string
-		}
-		var teams []teamBasic
-		for rows.Next() {
-			var t teamBasic
-			if err := rows.Scan(&t.ID, &t.Name, &t.Owner); err == nil {
-				teams = append(teams, t)
-			}
-		}
-		rows.Close()
-
-		var results []IRLTeamFinancial
-		for _, t := range teams {
-			summary := store.CalculateYearlySummary(db, t.ID, leagueID, year)
-			salary := summary.TotalPayroll
-
-			buyIn := calculateBuyIn(leagueID, salary, year)
-			amountOver := salary - luxuryTaxLimit
-			taxPen := calculateLuxuryTaxPenalty(leagueID, amountOver, year)
-
-			results = append(results, IRLTeamFinancial{
-				TeamID:        t.ID,
-				TeamName:      t.Name,
-				OwnerName:     t.Owner,
-				TotalSalary:   salary,
-				BuyIn:         buyIn,
-				LuxuryTaxPen:  taxPen,
-				TotalIRL:      buyIn + taxPen,
-				IsOverTax:     amountOver > 0,
-				AmountOverTax: math.Max(amountOver, 0),
-			})
-		}
-
-		sort.Slice(results, func(i, j int) bool {
-			return results[i].TotalSalary > results[j].TotalSalary
-		})
-
 		// Calculate league totals
 		var totalBuyIn, totalTax, totalIRL float64
 		for _, r := range results {
@@ -126,6 +83,13 @@ func IRLFinancialsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			totalIRL += r.TotalIRL
 		}
 
+		// Invoice status per team, once the commissioner has generated dues
+		invoices, duesSummary, _ := store.GetLeagueDuesInvoices(db, leagueID, year)
+		invoiceByTeam := make(map[string]store.DuesInvoice)
+		for _, inv := range invoices {
+			invoiceByTeam[inv.TeamID] = inv
+		}
+
 		leagues, _ := store.GetLeaguesWithTeams(db)
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
@@ -140,11 +104,66 @@ func IRLFinancialsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			"TotalBuyIn":     totalBuyIn,
 			"TotalTax":       totalTax,
 			"TotalIRL":       totalIRL,
+			"Invoices":       invoiceByTeam,
+			"DuesSummary":    duesSummary,
 			"IsCommish":      len(adminLeagues) > 0 || user.Role == "admin",
 		})
 	}
 }
 
+// computeIRLFinancials calculates each team's buy-in and luxury-tax penalty for the season,
+// sorted by payroll (highest first). Also returns the league's luxury tax limit.
+func computeIRLFinancials(db *pgxpool.Pool, leagueID string, year int) ([]IRLTeamFinancial, float64, error) {
+	// Get luxury tax limit from league_settings
+	var luxuryTaxLimit float64
+	db.QueryRow(context.Background(), "SELECT COALESCE(luxury_tax_limit, 0) FROM league_settings WHERE league_id = $1 AND year = $2", leagueID, year).Scan(&luxuryTaxLimit)
+
+	// Fetch teams
+	rows, err := db.Query(context.Background(), "SELECT id, name, COALESCE(owner_name, '') FROM teams WHERE league_id = $1 ORDER BY name", leagueID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	type teamBasic struct {
+		ID, Name, Owner string
+	}
+	var teams []teamBasic
+	for rows.Next() {
+		var t teamBasic
+		if err := rows.Scan(&t.ID, &t.Name, &t.Owner); err == nil {
+			teams = append(teams, t)
+		}
+	}
+	rows.Close()
+
+	var results []IRLTeamFinancial
+	for _, t := range teams {
+		summary := store.CalculateYearlySummary(db, t.ID, leagueID, year)
+		salary := summary.TotalPayroll
+
+		buyIn := calculateBuyIn(leagueID, salary, year)
+		amountOver := salary - luxuryTaxLimit
+		taxPen := calculateLuxuryTaxPenalty(leagueID, amountOver, year)
+
+		results = append(results, IRLTeamFinancial{
+			TeamID:        t.ID,
+			TeamName:      t.Name,
+			OwnerName:     t.Owner,
+			TotalSalary:   salary,
+			BuyIn:         buyIn,
+			LuxuryTaxPen:  taxPen,
+			TotalIRL:      buyIn + taxPen,
+			IsOverTax:     amountOver > 0,
+			AmountOverTax: math.Max(amountOver, 0),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].TotalSalary > results[j].TotalSalary
+	})
+	return results, luxuryTaxLimit, nil
+}
+
 func calculateBuyIn(leagueID string, salary float64, year int) float64 {
 	if salary <= 0 {
 		return 0
@@ -63,17 +63,31 @@ func LeagueFinancialsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			teamRows[i].Summary = store.CalculateYearlySummary(db, teamRows[i].TeamID, leagueID, year)
 		}
 
+		ledger, _ := store.GetLeagueBalanceLedger(db, leagueID, "", 50)
+
+		history, _ := store.GetLeaguePayrollHistory(db, leagueID, year, "", "")
+		if history == nil {
+			history = []store.PayrollSnapshot{}
+		}
+		var tradeDeadline string
+		if d, err := store.GetLeagueDateValue(db, leagueID, year, "trade_deadline"); err == nil {
+			tradeDeadline = d.Format("2006-01-02")
+		}
+
 		leagues, _ := store.GetLeaguesWithTeams(db)
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
 		RenderTemplate(c, "league_financials.html", gin.H{
-			"User":       user,
-			"TeamRows":   teamRows,
-			"Leagues":    leagues,
-			"LeagueID":   leagueID,
-			"LeagueName": leagueName,
-			"Year":       year,
-			"IsCommish":  len(adminLeagues) > 0 || user.Role == "admin",
+			"User":           user,
+			"TeamRows":       teamRows,
+			"Leagues":        leagues,
+			"LeagueID":       leagueID,
+			"LeagueName":     leagueName,
+			"Year":           year,
+			"Ledger":         ledger,
+			"PayrollHistory": history,
+			"TradeDeadline":  tradeDeadline,
+			"IsCommish":      len(adminLeagues) > 0 || user.Role == "admin",
 		})
 	}
 }
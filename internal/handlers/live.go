@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LiveScoringHandler renders the live scoreboard: per-team fantasy points for a date, with
+// in-progress games marked provisional until nightly processing finalizes them.
+func LiveScoringHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		leagues, _ := store.GetLeaguesWithTeams(db)
+
+		leagueID := c.Query("league")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		teamID := c.Query("team")
+		date := c.Query("date")
+		if date == "" {
+			date = liveScoringDate()
+		}
+
+		board, err := store.GetLiveScoreboard(db, leagueID, teamID, date)
+		if err != nil {
+			fmt.Printf("ERROR [LiveScoringHandler]: %v\n", err)
+			board = &store.LiveScoreboard{LeagueID: leagueID, Date: date}
+		}
+
+		var teams []store.Team
+		for _, l := range leagues {
+			if l.ID == leagueID {
+				teams = l.Teams
+			}
+		}
+
+		var lastUpdated string
+		if board.LastUpdated != nil {
+			lastUpdated = board.LastUpdated.In(liveScoringLocation()).Format("3:04 PM")
+		}
+
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+
+		RenderTemplate(c, "live.html", gin.H{
+			"User":           user,
+			"Board":          board,
+			"Leagues":        leagues,
+			"Teams":          teams,
+			"SelectedLeague": leagueID,
+			"SelectedTeam":   teamID,
+			"Date":           date,
+			"LastUpdated":    lastUpdated,
+			"IsToday":        date == liveScoringDate(),
+			"IsCommish":      len(adminLeagues) > 0,
+		})
+	}
+}
+
+// LiveScoringAPIHandler returns the live scoreboard as JSON. Params: league (required), team, date.
+func LiveScoringAPIHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		leagueID := c.Query("league")
+		if leagueID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "league is required"})
+			return
+		}
+		date := c.Query("date")
+		if date == "" {
+			date = liveScoringDate()
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+			return
+		}
+
+		board, err := store.GetLiveScoreboard(db, leagueID, c.Query("team"), date)
+		if err != nil {
+			fmt.Printf("ERROR [LiveScoringAPIHandler]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load live scores"})
+			return
+		}
+		c.JSON(http.StatusOK, board)
+	}
+}
+
+// liveScoringDate is the slate currently being scored: today ET, or yesterday before 4 AM ET
+// while late games finish.
+func liveScoringDate() string {
+	et := time.Now().In(liveScoringLocation())
+	if et.Hour() < 4 {
+		et = et.AddDate(0, 0, -1)
+	}
+	return et.Format("2006-01-02")
+}
+
+func liveScoringLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/notification"
@@ -136,9 +137,14 @@ func PromoteTo26ManHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		limit26 := settings.Roster26ManLimit
 		limit40 := settings.Roster40ManLimit
 
+		// Position and two-way designation decide the slots taken and the SP limit check
+		var playerPos string
+		var isTwoWay bool
+		db.QueryRow(context.Background(), "SELECT position, is_two_way FROM players WHERE id = $1", req.PlayerID).Scan(&playerPos, &isTwoWay)
+
 		count26, count40, err := store.GetTeamRosterCounts(db, req.TeamID)
 		if err == nil {
-			if count26 >= limit26 {
+			if count26+settings.RosterSlots(isTwoWay) > limit26 {
 				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("26-Man Roster is full (%d/%d). You must option a player to the minors first.", count26, limit26)})
 				return
 			}
@@ -152,9 +158,7 @@ func PromoteTo26ManHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		// SP limit check
-		var playerPos string
-		db.QueryRow(context.Background(), "SELECT position FROM players WHERE id = $1", req.PlayerID).Scan(&playerPos)
-		if playerPos == "SP" {
+		if settings.CountsTowardSPLimit(playerPos, isTwoWay) {
 			spCount, _ := store.GetTeam26ManSPCount(db, req.TeamID)
 			if spCount >= settings.SP26ManLimit {
 				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("SP limit reached (%d/%d on 26-man). You must option an SP first.", spCount, settings.SP26ManLimit)})
@@ -272,23 +276,23 @@ func MoveToILHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		statusIL := req.Duration + "-Day IL"
 
-		// Validate IL duration based on position
+		// Validate IL duration based on position; two-way players may use either
 		var position string
-		var on26 bool
+		var on26, isTwoWay bool
 		err := db.QueryRow(context.Background(),
-			`SELECT position, status_26_man FROM players WHERE id = $1 AND team_id = $2`,
-			req.PlayerID, req.TeamID).Scan(&position, &on26)
+			`SELECT position, status_26_man, is_two_way FROM players WHERE id = $1 AND team_id = $2`,
+			req.PlayerID, req.TeamID).Scan(&position, &on26, &isTwoWay)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
 			return
 		}
 
 		isPitcher := position == "SP" || position == "RP" || position == "P"
-		if req.Duration == "10" && isPitcher {
+		if req.Duration == "10" && isPitcher && !isTwoWay {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Pitchers must use the 15-Day or 60-Day IL"})
 			return
 		}
-		if req.Duration == "15" && !isPitcher {
+		if req.Duration == "15" && !isPitcher && !isTwoWay {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Position players must use the 10-Day or 60-Day IL"})
 			return
 		}
@@ -419,7 +423,8 @@ func MoveToSixtyDayILHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 type DFARequest struct {
 	MoveRequest
-	ClearAction string `json:"dfa_clear_action"` // "release" or "minors"
+	ClearAction    string `json:"dfa_clear_action"` // "release" or "minors"
+	ConfirmDeadCap bool   `json:"confirm_dead_cap"` // owner has seen the dead-cap preview
 }
 
 func DFAPlayerHandler(db *pgxpool.Pool) gin.HandlerFunc {
@@ -440,6 +445,10 @@ func DFAPlayerHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		if req.ClearAction == "" {
 			req.ClearAction = "release"
 		}
+		if req.ClearAction == "release" && !req.ConfirmDeadCap {
+			deadCapConfirmation(c, db, req.PlayerID, "dfa", time.Now().Year())
+			return
+		}
 
 		waiverEnd := time.Now().Add(48 * time.Hour)
 
@@ -529,27 +538,35 @@ func SwapPitcherPositionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		// Get current position
 		var position string
-		err := db.QueryRow(ctx, "SELECT position FROM players WHERE id = $1 AND team_id = $2", req.PlayerID, req.TeamID).Scan(&position)
+		var isTwoWay bool
+		err := db.QueryRow(ctx, "SELECT position, is_two_way FROM players WHERE id = $1 AND team_id = $2", req.PlayerID, req.TeamID).Scan(&position, &isTwoWay)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Player not found on this team"})
 			return
 		}
 
-		if position != "SP" && position != "RP" && position != "P" && position != "SP,RP" && position != "RP,SP" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Only pitchers (SP, RP, P, SP/RP) can use position swap"})
+		if !isTwoWay && position != "SP" && position != "RP" && position != "P" && position != "SP,RP" && position != "RP,SP" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Only pitchers (SP, RP, P, SP/RP) and two-way players can use position swap"})
 			return
 		}
 
 		isDualEligible := position == "P" || position == "SP,RP" || position == "RP,SP"
 
 		var newPosition string
-		if isDualEligible {
-			// Dual-eligible players must specify target via target_position
+		if isDualEligible || isTwoWay {
+			// Dual-eligible and two-way players must specify target via target_position
 			if req.TargetPosition != "SP" && req.TargetPosition != "RP" {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Must specify SP or RP as target position"})
 				return
 			}
 			newPosition = req.TargetPosition
+			if isTwoWay {
+				newPosition = withPitchingRole(position, req.TargetPosition)
+			}
+			if newPosition == position {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Player is already assigned " + req.TargetPosition})
+				return
+			}
 		} else {
 			// SP↔RP toggle; ignore target_position
 			newPosition = "RP"
@@ -575,13 +592,13 @@ func SwapPitcherPositionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			}
 		}
 
-		// If targeting SP on 26-man, check SP limit
-		if newPosition == "SP" {
+		// If the new position starts counting toward the SP limit on the 26-man, check it
+		leagueID, _ := store.GetTeamLeagueID(db, req.TeamID)
+		settings := store.GetLeagueSettings(db, leagueID, time.Now().Year())
+		if settings.CountsTowardSPLimit(newPosition, isTwoWay) && !settings.CountsTowardSPLimit(position, isTwoWay) {
 			var is26Man bool
 			db.QueryRow(ctx, "SELECT status_26_man FROM players WHERE id = $1", req.PlayerID).Scan(&is26Man)
 			if is26Man {
-				leagueID, _ := store.GetTeamLeagueID(db, req.TeamID)
-				settings := store.GetLeagueSettings(db, leagueID, time.Now().Year())
 				spCount, _ := store.GetTeam26ManSPCount(db, req.TeamID)
 				if spCount >= settings.SP26ManLimit {
 					c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("SP limit reached (%d/%d on 26-man). Cannot assign as SP.", spCount, settings.SP26ManLimit)})
@@ -608,6 +625,20 @@ func SwapPitcherPositionHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	}
 }
 
+// withPitchingRole sets a two-way player's pitching role in a position list like "DH,SP",
+// replacing any existing SP/RP/P entry and keeping the hitting positions.
+func withPitchingRole(position, role string) string {
+	var parts []string
+	for _, pos := range strings.Split(position, ",") {
+		pos = strings.TrimSpace(pos)
+		if pos == "" || pos == "SP" || pos == "RP" || pos == "P" {
+			continue
+		}
+		parts = append(parts, pos)
+	}
+	return strings.Join(append(parts, role), ",")
+}
+
 func ToggleTradeBlockHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req TradeBlockRequest
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PayrollHistoryHandler returns daily payroll snapshots for a team (team_id) or every team in a
+// league (league_id) for one contract year, optionally bounded by from/to (YYYY-MM-DD).
+func PayrollHistoryHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Query("team_id")
+		leagueID := c.Query("league_id")
+		if teamID == "" && leagueID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team_id or league_id is required"})
+			return
+		}
+		year, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			year = time.Now().Year()
+		}
+		from, to := c.Query("from"), c.Query("to")
+		for _, d := range []string{from, to} {
+			if d == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", d); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+				return
+			}
+		}
+
+		var snaps []store.PayrollSnapshot
+		if teamID != "" {
+			snaps, err = store.GetTeamPayrollHistory(db, teamID, year, from, to)
+		} else {
+			snaps, err = store.GetLeaguePayrollHistory(db, leagueID, year, from, to)
+		}
+		if err != nil {
+			fmt.Printf("ERROR [PayrollHistory]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load payroll history"})
+			return
+		}
+		if snaps == nil {
+			snaps = []store.PayrollSnapshot{}
+		}
+		c.JSON(http.StatusOK, gin.H{"year": year, "snapshots": snaps})
+	}
+}
+
+// PayrollAsOfHandler returns each team's payroll for a contract year as it stood on a past date,
+// using the latest snapshot taken on or before it. Scoped to team_id or league_id.
+func PayrollAsOfHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Query("team_id")
+		leagueID := c.Query("league_id")
+		if teamID == "" && leagueID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team_id or league_id is required"})
+			return
+		}
+		date := c.Query("date")
+		asOf, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date is required (YYYY-MM-DD)"})
+			return
+		}
+		year, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			year = asOf.Year()
+		}
+
+		snaps, err := store.GetPayrollAsOf(db, leagueID, teamID, year, date)
+		if err != nil {
+			fmt.Printf("ERROR [PayrollAsOf]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load payroll"})
+			return
+		}
+		if snaps == nil {
+			snaps = []store.PayrollSnapshot{}
+		}
+		c.JSON(http.StatusOK, gin.H{"date": date, "year": year, "teams": snaps})
+	}
+}
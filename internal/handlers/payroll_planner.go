@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// canPlanPayroll reports whether the user may build scenarios for a team: its owners and site admins.
+func canPlanPayroll(db *pgxpool.Pool, teamID string, user *store.User) bool {
+	if user.Role == "admin" {
+		return true
+	}
+	isOwner, _ := store.IsTeamOwner(db, teamID, user.ID)
+	return isOwner
+}
+
+// PayrollPlannerHandler renders the multi-year payroll planner for a team. ?scenario= loads a
+// saved scenario the user created or a co-owner shared.
+func PayrollPlannerHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Param("id")
+		user := c.MustGet("user").(*store.User)
+
+		if !canPlanPayroll(db, teamID, user) {
+			c.String(http.StatusForbidden, "Unauthorized")
+			return
+		}
+
+		team, err := store.GetTeamWithRoster(db, teamID)
+		if err != nil {
+			c.String(http.StatusNotFound, "Team not found")
+			return
+		}
+
+		roster, err := store.GetContractLines(db, teamID)
+		if err != nil {
+			fmt.Printf("ERROR [PayrollPlanner]: %v\n", err)
+		}
+		if roster == nil {
+			roster = []store.ContractLine{}
+		}
+
+		// Pending option clauses on this roster, for the exercise/decline picker
+		options := []store.ContractOption{}
+		pending, _ := store.GetContractOptions(db, team.LeagueID, "pending")
+		for _, o := range pending {
+			if o.TeamID == teamID {
+				options = append(options, o)
+			}
+		}
+
+		// Trade partners for acquiring players
+		var partners []store.Team
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		for _, l := range leagues {
+			if l.ID != team.LeagueID {
+				continue
+			}
+			for _, t := range l.Teams {
+				if t.ID != teamID {
+					partners = append(partners, t)
+				}
+			}
+		}
+
+		scenarios, _ := store.GetPayrollScenarios(db, teamID, user.ID)
+
+		current := store.PayrollScenario{StartYear: time.Now().Year(), Moves: []store.ScenarioMove{}}
+		if id := c.Query("scenario"); id != "" {
+			s, err := store.GetPayrollScenario(db, id, user.ID)
+			if err != nil || s.TeamID != teamID {
+				c.String(http.StatusNotFound, "Scenario not found")
+				return
+			}
+			current = *s
+			if current.Moves == nil {
+				current.Moves = []store.ScenarioMove{}
+			}
+		}
+
+		RenderTemplate(c, "payroll_planner.html", gin.H{
+			"User":        user,
+			"Team":        team,
+			"Roster":      roster,
+			"Options":     options,
+			"Partners":    partners,
+			"Scenarios":   scenarios,
+			"Scenario":    current,
+			"CanEdit":     current.ID == "" || current.CreatedBy == user.ID,
+			"SaveSuccess": c.Query("saved") == "1",
+		})
+	}
+}
+
+// SavePayrollScenarioHandler creates or updates a scenario. Moves arrive as JSON in moves_json.
+func SavePayrollScenarioHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Param("id")
+		user := c.MustGet("user").(*store.User)
+
+		if !canPlanPayroll(db, teamID, user) {
+			c.String(http.StatusForbidden, "Unauthorized")
+			return
+		}
+
+		name := strings.TrimSpace(c.PostForm("name"))
+		if name == "" {
+			c.String(http.StatusBadRequest, "Scenario name is required")
+			return
+		}
+		startYear, err := strconv.Atoi(c.PostForm("start_year"))
+		if err != nil || startYear < 2026 || startYear > 2040 {
+			c.String(http.StatusBadRequest, "Invalid start year")
+			return
+		}
+		var moves []store.ScenarioMove
+		if raw := c.PostForm("moves_json"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &moves); err != nil {
+				c.String(http.StatusBadRequest, "Invalid moves")
+				return
+			}
+		}
+
+		id, err := store.SavePayrollScenario(db, store.PayrollScenario{
+			ID:        c.PostForm("scenario_id"),
+			TeamID:    teamID,
+			CreatedBy: user.ID,
+			Name:      name,
+			Notes:     strings.TrimSpace(c.PostForm("notes")),
+			StartYear: startYear,
+			Moves:     moves,
+			Shared:    c.PostForm("shared") == "on",
+		})
+		if err != nil {
+			fmt.Printf("ERROR [SavePayrollScenario]: %v\n", err)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/team/planner/%s?scenario=%s&saved=1", teamID, id))
+	}
+}
+
+// DeletePayrollScenarioHandler deletes a scenario the user created.
+func DeletePayrollScenarioHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Param("id")
+		user := c.MustGet("user").(*store.User)
+
+		if err := store.DeletePayrollScenario(db, c.PostForm("scenario_id"), user.ID); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/team/planner/"+teamID)
+	}
+}
+
+// EvaluatePayrollScenarioHandler runs a set of moves against the team's contracts and returns
+// payroll and tax space per year, with and without the scenario.
+func EvaluatePayrollScenarioHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Param("id")
+		user := c.MustGet("user").(*store.User)
+
+		if !canPlanPayroll(db, teamID, user) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		var req struct {
+			StartYear int                  `json:"start_year"`
+			Moves     []store.ScenarioMove `json:"moves"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		result, err := store.EvaluatePayrollScenario(db, teamID, req.StartYear, req.Moves)
+		if err != nil {
+			fmt.Printf("ERROR [EvaluatePayrollScenario]: %v\n", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// PlannerTeamPlayersHandler returns another team's contracts for the planner's trade picker.
+// The caller must be able to plan for plan_team_id, and team_id must be in the same league.
+func PlannerTeamPlayersHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		planTeamID, teamID := c.Query("plan_team_id"), c.Query("team_id")
+		if !canPlanPayroll(db, planTeamID, user) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+			return
+		}
+		planLeagueID, err := store.GetTeamLeagueID(db, planTeamID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+		if leagueID, err := store.GetTeamLeagueID(db, teamID); err != nil || leagueID != planLeagueID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Team is not in this league"})
+			return
+		}
+
+		lines, err := store.GetContractLines(db, teamID)
+		if err != nil {
+			fmt.Printf("ERROR [PlannerTeamPlayers]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load players"})
+			return
+		}
+		if lines == nil {
+			lines = []store.ContractLine{}
+		}
+		c.JSON(http.StatusOK, lines)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/gin-gonic/gin"
@@ -47,6 +48,12 @@ func FreeAgentHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
+		// Projected points with this league's scoring
+		projSeason := store.GetProjectionSeason(db, leagueID, time.Now().Year())
+		if err := store.ApplyProjectedPoints(db, leagueID, projSeason, players); err != nil {
+			fmt.Printf("ERROR [FreeAgents projections]: %v\n", err)
+		}
+
 		totalCount, err := store.CountFreeAgents(db, filter)
 		if err != nil {
 			fmt.Printf("ERROR [FreeAgents count]: %v\n", err)
@@ -73,6 +80,7 @@ func FreeAgentHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			"CurrentPage": page,
 			"TotalPages":  totalPages,
 			"TotalCount":  totalCount,
+			"ProjSeason":  projSeason,
 		})
 	}
 }
@@ -128,6 +136,14 @@ func PlayerProfileHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			}
 		}
 
+		// Salary schedule constraints for the bid and extension forms
+		salaryRules := store.GetLeagueSettings(db, player.LeagueID, time.Now().Year())
+
+		valuation, err := store.GetPlayerValuation(db, player.ID)
+		if err != nil {
+			fmt.Printf("ERROR [PlayerProfile valuation]: %v\n", err)
+		}
+
 		RenderTemplate(c, "player_profile.html", gin.H{
 			"Player":          player,
 			"User":            user,
@@ -140,6 +156,9 @@ func PlayerProfileHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			"DeadCap":         playerDeadCap,
 			"IsbpBalance":     userIsbpBalance,
 			"MilbBalance":     userMilbBalance,
+			"SalaryRules":     salaryRules,
+			"MaxVariancePct":  salaryRules.MaxSalaryVariance * 100,
+			"Valuation":       valuation,
 		})
 	}
 }
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// projectionStatColumns maps projection file headers (lowercased) to our stat keys per stat type.
+// Headers follow the common Steamer/ZiPS/FanGraphs exports; our own keys are accepted as-is.
+var projectionStatColumns = map[string]map[string]string{
+	"hitting": {
+		"h": "h", "hr": "hr", "rbi": "rbi", "r": "r", "bb": "bb", "sb": "sb", "cs": "cs",
+		"k": "k", "so": "k",
+	},
+	"pitching": {
+		"ip": "ip", "k": "k", "so": "k", "er": "er", "bb": "bb", "hra": "hra", "hr": "hra",
+		"hb": "hb", "hbp": "hb", "wp": "wp", "bk": "bk", "gs": "gs", "sv": "sv", "hld": "hld",
+		"holds": "hld", "bs": "bs", "cg": "cg", "sho": "sho", "qs": "qs", "pko": "pko",
+	},
+}
+
+var (
+	projectionIDColumns   = []string{"mlb_id", "mlbid", "mlbamid", "xmlbamid", "mlbam_id", "key_mlbam"}
+	projectionNameColumns = []string{"name", "player_name", "playername", "player"}
+	projectionTeamColumns = []string{"team", "mlb_team", "tm"}
+)
+
+// parseProjectionRecord turns one header→value record into a projection row.
+func parseProjectionRecord(rec map[string]string, statType string) store.ProjectionRow {
+	row := store.ProjectionRow{Stats: make(map[string]float64)}
+	for _, col := range projectionIDColumns {
+		if id, err := strconv.Atoi(strings.TrimSpace(rec[col])); err == nil && id > 0 {
+			row.MlbID = id
+			break
+		}
+	}
+	for _, col := range projectionNameColumns {
+		if v := strings.TrimSpace(rec[col]); v != "" {
+			row.PlayerName = v
+			break
+		}
+	}
+	if row.PlayerName == "" {
+		row.PlayerName = strings.TrimSpace(strings.TrimSpace(rec["first_name"]) + " " + strings.TrimSpace(rec["last_name"]))
+	}
+	for _, col := range projectionTeamColumns {
+		if v := strings.TrimSpace(rec[col]); v != "" {
+			row.MLBTeam = v
+			break
+		}
+	}
+	for col, key := range projectionStatColumns[statType] {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(rec[col]), 64); err == nil {
+			row.Stats[key] = v
+		}
+	}
+	return row
+}
+
+// parseProjectionFile reads a CSV (header row) or JSON (array of objects) projection file.
+func parseProjectionFile(r io.Reader, filename, statType string) ([]store.ProjectionRow, error) {
+	var records []map[string]string
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		var objects []map[string]interface{}
+		if err := json.NewDecoder(r).Decode(&objects); err != nil {
+			return nil, fmt.Errorf("invalid JSON: expected an array of objects")
+		}
+		for _, obj := range objects {
+			rec := make(map[string]string)
+			for k, v := range obj {
+				switch val := v.(type) {
+				case string:
+					rec[strings.ToLower(strings.TrimSpace(k))] = val
+				case float64:
+					rec[strings.ToLower(strings.TrimSpace(k))] = strconv.FormatFloat(val, 'f', -1, 64)
+				}
+			}
+			records = append(records, rec)
+		}
+	} else {
+		// Skip the UTF-8 BOM some spreadsheet exports start with
+		br := bufio.NewReader(r)
+		if bom, _ := br.Peek(3); string(bom) == "\xef\xbb\xbf" {
+			br.Discard(3)
+		}
+		reader := csv.NewReader(br)
+		reader.FieldsPerRecord = -1
+		headers, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV headers")
+		}
+		for i, h := range headers {
+			headers[i] = strings.ToLower(strings.TrimSpace(h))
+		}
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV data")
+		}
+		for _, row := range rows {
+			rec := make(map[string]string)
+			for i, v := range row {
+				if i < len(headers) {
+					rec[headers[i]] = v
+				}
+			}
+			records = append(records, rec)
+		}
+	}
+
+	var out []store.ProjectionRow
+	for _, rec := range records {
+		row := parseProjectionRecord(rec, statType)
+		if len(row.Stats) == 0 || (row.MlbID == 0 && row.PlayerName == "") {
+			continue
+		}
+		out = append(out, row)
+	}
+	return out, nil
+}
+
+// AdminProjectionsHandler shows loaded projections and the review queue. Projections are shared
+// by every league, so only global admins get the import form and can work the queue.
+func AdminProjectionsHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		summaries, err := store.GetProjectionSummaries(db)
+		if err != nil {
+			fmt.Printf("ERROR [AdminProjections]: summaries: %v\n", err)
+		}
+		queue, err := store.GetProjectionQueue(db)
+		if err != nil {
+			fmt.Printf("ERROR [AdminProjections]: queue: %v\n", err)
+		}
+
+		RenderTemplate(c, "admin_projections.html", gin.H{
+			"User":        user,
+			"Summaries":   summaries,
+			"Queue":       queue,
+			"DefaultYear": time.Now().Year(),
+			"Message":     c.Query("msg"),
+			"Error":       c.Query("error"),
+			"IsCommish":   true,
+			"CanEdit":     user.Role == "admin",
+		})
+	}
+}
+
+// AdminImportProjectionsHandler imports an uploaded projection file for a season and stat type.
+// Global admins only.
+func AdminImportProjectionsHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		if user.Role != "admin" {
+			c.String(http.StatusForbidden, "Global Admin Only")
+			return
+		}
+		fail := func(msg string) {
+			c.Redirect(http.StatusFound, "/admin/projections?error="+url.QueryEscape(msg))
+		}
+
+		// Limit upload to 10 MB
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 10<<20)
+
+		season, _ := strconv.Atoi(c.PostForm("season"))
+		if season < 2000 || season > 2100 {
+			fail("Enter a valid season.")
+			return
+		}
+		statType := c.PostForm("stat_type")
+		if statType != "hitting" && statType != "pitching" {
+			fail("Choose hitting or pitching.")
+			return
+		}
+		source := strings.TrimSpace(c.PostForm("source"))
+
+		file, header, err := c.Request.FormFile("projection_file")
+		if err != nil {
+			fail("Error uploading file.")
+			return
+		}
+		defer file.Close()
+
+		rows, err := parseProjectionFile(file, header.Filename, statType)
+		if err != nil {
+			fail(err.Error())
+			return
+		}
+		if len(rows) == 0 {
+			fail("No projection rows found. Check the headers match the expected columns.")
+			return
+		}
+
+		result, err := store.ImportProjections(db, season, statType, source, rows)
+		if err != nil {
+			fmt.Printf("ERROR [AdminImportProjections]: %v\n", err)
+			fail("Import failed: " + err.Error())
+			return
+		}
+
+		fmt.Printf("Projections: %s imported %d %d %s rows (%d from earlier reviews, %d queued for review) from %s\n",
+			user.Username, result.Imported, season, statType, result.Rematched, result.Queued, header.Filename)
+		msg := fmt.Sprintf("Imported %d %d %s projections (%d matched from earlier reviews); %d row(s) need review.",
+			result.Imported, season, statType, result.Rematched, result.Queued)
+		c.Redirect(http.StatusFound, "/admin/projections?msg="+url.QueryEscape(msg))
+	}
+}
+
+// AdminResolveProjectionHandler matches a queued row to an MLB ID, or dismisses it. Global admins only.
+func AdminResolveProjectionHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		if user.Role != "admin" {
+			c.String(http.StatusForbidden, "Global Admin Only")
+			return
+		}
+
+		id := c.PostForm("id")
+		if c.PostForm("action") == "dismiss" {
+			if err := store.DismissProjectionQueueItem(db, id, user.ID); err != nil {
+				fmt.Printf("ERROR [AdminResolveProjection]: dismiss: %v\n", err)
+				c.Redirect(http.StatusFound, "/admin/projections?error="+url.QueryEscape("Could not dismiss row."))
+				return
+			}
+			c.Redirect(http.StatusFound, "/admin/projections?msg="+url.QueryEscape("Row dismissed."))
+			return
+		}
+
+		mlbID, err := strconv.Atoi(strings.TrimSpace(c.PostForm("mlb_id")))
+		if err != nil || mlbID <= 0 {
+			c.Redirect(http.StatusFound, "/admin/projections?error="+url.QueryEscape("Enter an MLB ID."))
+			return
+		}
+		if err := store.ResolveProjectionQueueItem(db, id, mlbID, user.ID); err != nil {
+			c.Redirect(http.StatusFound, "/admin/projections?error="+url.QueryEscape(err.Error()))
+			return
+		}
+		c.Redirect(http.StatusFound, "/admin/projections?msg="+url.QueryEscape(fmt.Sprintf("Matched to MLB ID %d.", mlbID)))
+	}
+}
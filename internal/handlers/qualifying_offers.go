@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Qualifying Offers ---
+
+// QualifyingOffersHandler lists a team's expiring contracts with the league's QO amount.
+func QualifyingOffersHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		season := time.Now().Year()
+
+		myTeams, _ := store.GetManagedTeams(db, user.ID)
+		selectedTeamID := c.Query("team_id")
+		var selectedTeam store.TeamDetail
+		for _, t := range myTeams {
+			if selectedTeamID == "" || t.ID == selectedTeamID {
+				selectedTeam = t
+				break
+			}
+		}
+
+		var candidates []store.QOCandidate
+		var offers []store.QualifyingOffer
+		var rules store.QualifyingOfferRules
+		var amount float64
+		tenderDeadline := ""
+		if selectedTeam.ID != "" {
+			rules = store.GetQualifyingOfferRules(db, selectedTeam.LeagueID, season)
+			amount, _ = store.ComputeQualifyingOfferAmount(db, selectedTeam.LeagueID, season)
+			candidates, _ = store.GetQOCandidates(db, selectedTeam.ID, season)
+			offers, _ = store.GetQualifyingOffers(db, selectedTeam.LeagueID, season, "")
+			if d, err := store.GetLeagueDateValue(db, selectedTeam.LeagueID, season, "qo_tender_deadline"); err == nil {
+				tenderDeadline = d.Format("January 2, 2006")
+			}
+		}
+
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+
+		RenderTemplate(c, "qualifying_offers.html", gin.H{
+			"User":           user,
+			"MyTeams":        myTeams,
+			"SelectedTeam":   selectedTeam,
+			"Season":         season,
+			"Rules":          rules,
+			"Amount":         amount,
+			"Candidates":     candidates,
+			"Offers":         offers,
+			"TenderDeadline": tenderDeadline,
+			"Success":        c.Query("saved") == "1",
+			"IsCommish":      len(adminLeagues) > 0,
+		})
+	}
+}
+
+func TenderQualifyingOfferHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		teamID := c.PostForm("team_id")
+		playerID := c.PostForm("player_id")
+		season, _ := strconv.Atoi(c.PostForm("season"))
+
+		isOwner, _ := store.IsTeamOwner(db, teamID, user.ID)
+		if !isOwner && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Unauthorized")
+			return
+		}
+		leagueID, err := store.GetTeamLeagueID(db, teamID)
+		if err != nil {
+			c.String(http.StatusNotFound, "Team not found")
+			return
+		}
+
+		deadline, err := store.GetLeagueDateValue(db, leagueID, season, "qo_tender_deadline")
+		if err == nil && time.Now().After(deadline) {
+			c.String(http.StatusForbidden, "The qualifying offer deadline has passed (%s).", deadline.Format("January 2, 2006"))
+			return
+		}
+
+		amount, err := store.TenderQualifyingOffer(db, teamID, playerID, season)
+		if err != nil {
+			c.String(http.StatusBadRequest, "Qualifying offer failed: %v", err)
+			return
+		}
+
+		player, _ := store.GetPlayerByID(db, playerID)
+		var teamName string
+		db.QueryRow(context.Background(), `SELECT name FROM teams WHERE id = $1`, teamID).Scan(&teamName)
+		if player != nil {
+			msg := fmt.Sprintf("📝 *Qualifying Offer*\n%s tendered %s %s a 1yr/$%.0f qualifying offer for %d",
+				teamName, player.FirstName, player.LastName, amount, season+1)
+			notification.SendSlackNotification(db, leagueID, "transactions", msg)
+		}
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/qualifying-offers?team_id=%s&saved=1", teamID))
+	}
+}
+
+// AdminQualifyingOffersHandler shows a league's offers, the QO rules and manual rulings.
+func AdminQualifyingOffersHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		season, err := strconv.Atoi(c.Query("season"))
+		if err != nil {
+			season = time.Now().Year()
+		}
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		offers, err := store.GetQualifyingOffers(db, leagueID, season, "")
+		if err != nil {
+			fmt.Printf("ERROR [AdminQualifyingOffers]: %v\n", err)
+		}
+		amount, _ := store.ComputeQualifyingOfferAmount(db, leagueID, season)
+
+		RenderTemplate(c, "admin_qualifying_offers.html", gin.H{
+			"User":        user,
+			"Leagues":     leagues,
+			"LeagueID":    leagueID,
+			"Season":      season,
+			"Rules":       store.GetQualifyingOfferRules(db, leagueID, season),
+			"Amount":      amount,
+			"Offers":      offers,
+			"SaveSuccess": c.Query("saved") == "1",
+			"IsCommish":   true,
+		})
+	}
+}
+
+func AdminSaveQualifyingOfferRulesHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		season, _ := strconv.Atoi(c.PostForm("season"))
+		var r store.QualifyingOfferRules
+		r.Enabled = c.PostForm("enabled") == "on"
+		r.TopN, _ = strconv.Atoi(c.PostForm("top_n"))
+		r.AcceptRule = c.PostForm("accept_rule")
+		r.CompensationType = c.PostForm("compensation_type")
+		r.CompensationAmount, _ = strconv.ParseFloat(c.PostForm("compensation_amount"), 64)
+		r.SigningTeamPays = c.PostForm("signing_team_pays") == "on"
+
+		if err := store.UpsertQualifyingOfferRules(db, leagueID, season, r); err != nil {
+			fmt.Printf("ERROR [AdminSaveQualifyingOfferRules]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/qualifying-offers?league_id=%s&season=%d&saved=1", leagueID, season))
+	}
+}
+
+// AdminResolveQualifyingOfferHandler records the player's answer on the commissioner's ruling,
+// or applies the league rule to every outstanding offer.
+func AdminResolveQualifyingOfferHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		season, _ := strconv.Atoi(c.PostForm("season"))
+		offerID := c.PostForm("offer_id")
+		if offerID != "" {
+			if id, err := store.GetQualifyingOfferLeagueID(db, offerID); err == nil {
+				leagueID = id
+			}
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		var summaries []string
+		if offerID != "" {
+			summary, err := store.ResolveQualifyingOffer(db, offerID, c.PostForm("decision") == "accept", "commissioner")
+			if err != nil {
+				c.String(http.StatusBadRequest, "Ruling failed: %v", err)
+				return
+			}
+			summaries = append(summaries, summary)
+		} else {
+			var err error
+			summaries, err = store.ApplyQualifyingOfferRule(db, leagueID, season)
+			if err != nil {
+				fmt.Printf("ERROR [AdminResolveQualifyingOffer]: %v\n", err)
+				c.String(http.StatusInternalServerError, "Internal server error")
+				return
+			}
+		}
+
+		for _, summary := range summaries {
+			notification.SendSlackNotification(db, leagueID, "transactions", summary)
+		}
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/qualifying-offers?league_id=%s&season=%d&saved=1", leagueID, season))
+	}
+}
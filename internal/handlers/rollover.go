@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- End-of-Season Contract Rollover ---
+
+// AdminRolloverHandler shows a dry-run preview of a league's contract rollover, or the
+// commissioner report once it has been applied.
+func AdminRolloverHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		season, err := strconv.Atoi(c.Query("season"))
+		if err != nil {
+			season = time.Now().Year()
+		}
+
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		complete := store.IsRolloverComplete(db, leagueID, season)
+		var preview []store.RolloverChange
+		if !complete {
+			preview, err = store.PreviewContractRollover(db, leagueID, season)
+			if err != nil {
+				fmt.Printf("ERROR [AdminRollover]: %v\n", err)
+			}
+		}
+		report, _ := store.GetRolloverLog(db, leagueID, season)
+
+		rolloverDate := ""
+		if d, err := store.GetLeagueDateValue(db, leagueID, season, "contract_rollover"); err == nil {
+			rolloverDate = d.Format("January 2, 2006")
+		}
+
+		RenderTemplate(c, "admin_rollover.html", gin.H{
+			"User":         user,
+			"Leagues":      leagues,
+			"LeagueID":     leagueID,
+			"Season":       season,
+			"Complete":     complete,
+			"Preview":      preview,
+			"Report":       report,
+			"RolloverDate": rolloverDate,
+			"SaveSuccess":  c.Query("saved") == "1",
+			"IsCommish":    true,
+		})
+	}
+}
+
+// AdminRunRolloverHandler applies a league's rollover immediately instead of waiting for the worker.
+func AdminRunRolloverHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		season, _ := strconv.Atoi(c.PostForm("season"))
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		changes, err := store.ApplyContractRollover(db, leagueID, season)
+		if err != nil {
+			fmt.Printf("ERROR [AdminRunRollover]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Rollover failed: %v", err)
+			return
+		}
+
+		fmt.Printf("Rollover: %s applied %d contract rollover for league %s (%d changes)\n", user.Username, season, leagueID, len(changes))
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/rollover?league_id=%s&season=%d&saved=1", leagueID, season))
+	}
+}
+
+// --- Season Rollover Wizard ---
+
+// seasonRolloverOptions reads the wizard's choices from the query string or form.
+func seasonRolloverOptions(c *gin.Context, db *pgxpool.Pool, leagueID string, fromYear int) store.SeasonRolloverOptions {
+	o := store.SeasonRolloverOptions{LeagueID: leagueID, FromYear: fromYear}
+	o.IsbpAllocation, o.MilbAllocation, o.AllocationMode = store.GetSeasonAllocationDefaults(db, leagueID, fromYear)
+	if v, ok := c.GetPostForm("isbp_allocation"); ok {
+		o.IsbpAllocation, _ = strconv.ParseFloat(v, 64)
+	} else if v := c.Query("isbp_allocation"); v != "" {
+		o.IsbpAllocation, _ = strconv.ParseFloat(v, 64)
+	}
+	if v, ok := c.GetPostForm("milb_allocation"); ok {
+		o.MilbAllocation, _ = strconv.ParseFloat(v, 64)
+	} else if v := c.Query("milb_allocation"); v != "" {
+		o.MilbAllocation, _ = strconv.ParseFloat(v, 64)
+	}
+	if mode := c.DefaultPostForm("allocation_mode", c.Query("allocation_mode")); mode != "" {
+		o.AllocationMode = mode
+	}
+	if o.AllocationMode != "reset" {
+		o.AllocationMode = "add"
+	}
+	o.Overwrite = c.PostForm("overwrite") == "on" || c.Query("overwrite") == "on"
+	return o
+}
+
+// AdminSeasonRolloverHandler shows the full diff of rolling a league into the next season:
+// cloned settings, shifted key dates, balance allocations and counter resets.
+func AdminSeasonRolloverHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		fromYear, err := strconv.Atoi(c.Query("from_year"))
+		if err != nil {
+			fromYear = time.Now().Year()
+		}
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+
+		opts := seasonRolloverOptions(c, db, leagueID, fromYear)
+		plan, err := store.PreviewSeasonRollover(db, opts)
+		if err != nil {
+			fmt.Printf("ERROR [AdminSeasonRollover]: %v\n", err)
+			plan = &store.SeasonRolloverPlan{Options: opts, ToYear: fromYear + 1}
+		}
+
+		RenderTemplate(c, "admin_season_rollover.html", gin.H{
+			"User":        user,
+			"Leagues":     leagues,
+			"LeagueID":    leagueID,
+			"Plan":        plan,
+			"SaveSuccess": c.Query("saved") == "1",
+			"IsCommish":   true,
+		})
+	}
+}
+
+func AdminApplySeasonRolloverHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		fromYear, _ := strconv.Atoi(c.PostForm("from_year"))
+		opts := seasonRolloverOptions(c, db, leagueID, fromYear)
+
+		plan, err := store.ApplySeasonRollover(db, opts, user.Username)
+		if err != nil {
+			fmt.Printf("ERROR [AdminApplySeasonRollover]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Season rollover failed: %v", err)
+			return
+		}
+
+		fmt.Printf("Season Rollover: %s rolled league %s into %d (%d allocations)\n", user.Username, leagueID, plan.ToYear, len(plan.Allocations))
+
+		c.Redirect(http.StatusFound, fmt.Sprintf("/admin/season-rollover?league_id=%s&from_year=%d&saved=1", leagueID, fromYear))
+	}
+}
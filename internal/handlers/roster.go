@@ -48,6 +48,11 @@ func RosterHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		posOrder := []string{"C", "1B", "2B", "SS", "3B", "OF", "SP", "RP"}
 
+		projSeason := store.GetProjectionSeason(db, team.LeagueID, time.Now().Year())
+		if err := store.ApplyProjectedPoints(db, team.LeagueID, projSeason, team.Players); err != nil {
+			fmt.Printf("ERROR [Roster projections]: %v\n", err)
+		}
+
 		// Categorize players — normalize position before bucketing
 		roster26 := make(map[string][]store.RosterPlayer)
 		roster40 := make(map[string][]store.RosterPlayer)
@@ -64,12 +69,22 @@ func RosterHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			}
 		}
 
-		// Compute roster counts (60-Day IL players don't count against limits)
+		currentYear := time.Now().Year()
+		settings := store.GetLeagueSettings(db, team.LeagueID, currentYear)
+
+		// Compute roster counts (60-Day IL players don't count against limits;
+		// two-way players take the league's configured number of 26-man slots)
 		count26 := 0
+		players26 := 0
+		spCount := 0
 		for _, players := range roster26 {
 			for _, p := range players {
 				if p.StatusIL != "60-Day IL" {
-					count26++
+					players26++
+					count26 += settings.RosterSlots(p.IsTwoWay)
+					if settings.CountsTowardSPLimit(p.Position, p.IsTwoWay) {
+						spCount++
+					}
 				}
 			}
 		}
@@ -85,16 +100,7 @@ func RosterHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		for _, players := range minors {
 			countMinors += len(players)
 		}
-		count40 := count26 + count40only
-		spCount := 0
-		for _, p := range roster26["SP"] {
-			if p.StatusIL != "60-Day IL" {
-				spCount++
-			}
-		}
-
-		currentYear := time.Now().Year()
-		settings := store.GetLeagueSettings(db, team.LeagueID, currentYear)
+		count40 := players26 + count40only
 
 		// Query restructure and extension player names (PENDING + APPROVED) for current year
 		ctx := context.Background()
@@ -159,6 +165,7 @@ func RosterHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			"ILPlayers":           ilPlayers,
 			"DeadCap":             deadCapEntries,
 			"PointsMap":           pointsMap,
+			"ProjSeason":          projSeason,
 		}
 
 		RenderTemplate(c, "roster.html", data)
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// canViewRosterHistory allows a team's owners, its league's commissioners and admins.
+func canViewRosterHistory(db *pgxpool.Pool, teamID, leagueID string, user *store.User) bool {
+	if user.Role == "admin" {
+		return true
+	}
+	if isOwner, _ := store.IsTeamOwner(db, teamID, user.ID); isOwner {
+		return true
+	}
+	adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+	for _, id := range adminLeagues {
+		if id == leagueID {
+			return true
+		}
+	}
+	return false
+}
+
+// RosterHistoryHandler shows a team's roster as locked at first pitch on ?date= (default: yesterday).
+func RosterHistoryHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID := c.Param("id")
+		user := c.MustGet("user").(*store.User)
+
+		today := time.Now().In(liveScoringLocation())
+		date := c.Query("date")
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			date = today.AddDate(0, 0, -1).Format("2006-01-02")
+		}
+
+		snap, err := store.GetRosterSnapshot(db, teamID, date)
+		if err != nil {
+			c.String(http.StatusNotFound, "Team not found")
+			return
+		}
+		if !canViewRosterHistory(db, teamID, snap.LeagueID, user) {
+			c.String(http.StatusForbidden, "Unauthorized")
+			return
+		}
+
+		// Group by membership for display
+		groups := map[string][]store.RosterSnapshotEntry{}
+		for _, p := range snap.Players {
+			groups[p.RosterStatus] = append(groups[p.RosterStatus], p)
+		}
+
+		d, _ := time.Parse("2006-01-02", date)
+		seasonStart := fmt.Sprintf("%d-01-01", d.Year())
+		seasonPoints, err := store.GetTeamSnapshotPoints(db, teamID, seasonStart, date)
+		if err != nil {
+			fmt.Printf("ERROR [RosterHistory]: season points: %v\n", err)
+		}
+
+		firstPitch := ""
+		if snap.FirstPitch != nil {
+			firstPitch = snap.FirstPitch.In(liveScoringLocation()).Format("3:04 PM") + " ET"
+		}
+
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		RenderTemplate(c, "roster_history.html", gin.H{
+			"User":         user,
+			"Snapshot":     snap,
+			"Date":         date,
+			"PrevDate":     d.AddDate(0, 0, -1).Format("2006-01-02"),
+			"NextDate":     d.AddDate(0, 0, 1).Format("2006-01-02"),
+			"HasNext":      d.AddDate(0, 0, 1).Format("2006-01-02") <= today.Format("2006-01-02"),
+			"Active":       groups["26"],
+			"IL":           groups["IL"],
+			"FortyMan":     groups["40"],
+			"Minors":       groups["minors"],
+			"SeasonPoints": seasonPoints,
+			"FirstPitch":   firstPitch,
+			"IsCommish":    len(adminLeagues) > 0 || user.Role == "admin",
+		})
+	}
+}
+
+// RosterSnapshotAPIHandler returns a team's locked roster for ?team_id= on ?date= as JSON.
+func RosterSnapshotAPIHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		teamID := c.Query("team_id")
+		date := c.Query("date")
+		if teamID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team_id is required"})
+			return
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date is required (YYYY-MM-DD)"})
+			return
+		}
+
+		snap, err := store.GetRosterSnapshot(db, teamID, date)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+		if !canViewRosterHistory(db, teamID, snap.LeagueID, user) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+			return
+		}
+		if snap.Players == nil {
+			snap.Players = []store.RosterSnapshotEntry{}
+		}
+		c.JSON(http.StatusOK, snap)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"sort"
 	"strconv"
@@ -12,6 +11,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -756,12 +756,12 @@ func AutoFillRotationHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		var leagueID string
 		db.QueryRow(context.Background(), "SELECT league_id::TEXT FROM teams WHERE id = $1", teamID).Scan(&leagueID)
 
-		// Get team's pitchers (SP, RP, P) on 26-man with mlb_id
+		// Get team's pitchers (SP, RP, P) and two-way players on 26-man with mlb_id
 		rows, err := db.Query(context.Background(), `
 			SELECT id, first_name, last_name, position, COALESCE(mlb_id, 0)
 			FROM players
 			WHERE team_id = $1 AND status_26_man = TRUE
-			  AND position IN ('SP', 'RP', 'P', 'SP,RP', 'RP,SP')
+			  AND (position IN ('SP', 'RP', 'P', 'SP,RP', 'RP,SP') OR is_two_way)
 			  AND COALESCE(mlb_id, 0) > 0
 			ORDER BY position, last_name
 		`, teamID)
@@ -807,39 +807,14 @@ func AutoFillRotationHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		endDate := sunday.Format("2006-01-02")
 
 		// Fetch MLB schedule with probable pitchers
-		schedURL := fmt.Sprintf("https://statsapi.mlb.com/api/v1/schedule?sportId=1&startDate=%s&endDate=%s&hydrate=probablePitcher", startDate, endDate)
-		client := &http.Client{Timeout: 15 * time.Second}
-		resp, err := client.Get(schedURL)
+		schedule, err := mlbapi.Default().Schedule(c.Request.Context(), mlbapi.ScheduleQuery{
+			StartDate: startDate, EndDate: endDate, Hydrate: "probablePitcher",
+		})
 		if err != nil {
 			fmt.Printf("ERROR [AutoFillRotation]: MLB API fetch: %v\n", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch MLB schedule"})
 			return
 		}
-		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-
-		var schedule struct {
-			Dates []struct {
-				Date  string `json:"date"`
-				Games []struct {
-					Teams struct {
-						Away struct {
-							ProbablePitcher struct {
-								ID       int    `json:"id"`
-								FullName string `json:"fullName"`
-							} `json:"probablePitcher"`
-						} `json:"away"`
-						Home struct {
-							ProbablePitcher struct {
-								ID       int    `json:"id"`
-								FullName string `json:"fullName"`
-							} `json:"probablePitcher"`
-						} `json:"home"`
-					} `json:"teams"`
-				} `json:"games"`
-			} `json:"dates"`
-		}
-		json.Unmarshal(body, &schedule)
 
 		// Build: date -> []rosterPitcher (pitchers from our roster who are probable that day)
 		type dayAssignment struct {
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdminScheduleHandler shows a league's head-to-head schedule for the season and the generator.
+func AdminScheduleHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		year := time.Now().In(liveScoringLocation()).Year()
+
+		matchups, err := store.GetMatchups(db, leagueID, year, 0)
+		if err != nil {
+			fmt.Printf("ERROR [AdminSchedule]: %v\n", err)
+		}
+
+		// Default the start to opening day
+		startDate := ""
+		if d, err := store.GetLeagueDateValue(db, leagueID, year, "opening_day"); err == nil {
+			startDate = d.Format("2006-01-02")
+		}
+
+		RenderTemplate(c, "admin_schedule.html", gin.H{
+			"User":      user,
+			"Leagues":   leagues,
+			"LeagueID":  leagueID,
+			"Year":      year,
+			"Matchups":  matchups,
+			"StartDate": startDate,
+			"Saved":     c.Query("saved"),
+			"Error":     c.Query("error"),
+			"IsCommish": true,
+		})
+	}
+}
+
+// AdminGenerateScheduleHandler builds a round-robin weekly schedule for a league's season.
+func AdminGenerateScheduleHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		fail := func(msg string) {
+			c.Redirect(http.StatusFound, "/admin/schedule?league_id="+leagueID+"&error="+url.QueryEscape(msg))
+		}
+
+		start, err := time.Parse("2006-01-02", c.PostForm("start_date"))
+		if err != nil {
+			fail("Pick a start date.")
+			return
+		}
+		weeks, _ := strconv.Atoi(c.PostForm("weeks"))
+		if weeks < 1 || weeks > 30 {
+			fail("Weeks must be between 1 and 30.")
+			return
+		}
+
+		today := time.Now().In(liveScoringLocation())
+		created, err := store.GenerateSchedule(db, leagueID, today.Year(), start, weeks, today.Format("2006-01-02"))
+		if err != nil {
+			fmt.Printf("ERROR [AdminGenerateSchedule]: %v\n", err)
+			fail("Could not generate schedule: " + err.Error())
+			return
+		}
+
+		fmt.Printf("Schedule: generated %d weeks (%d matchups) for league %s by %s\n", weeks, created, leagueID, user.Username)
+		c.Redirect(http.StatusFound, "/admin/schedule?league_id="+leagueID+"&saved="+strconv.Itoa(created))
+	}
+}
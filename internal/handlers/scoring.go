@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// scoringRedirect returns to the scoring editor for a league and season with a status flag.
+func scoringRedirect(c *gin.Context, leagueID string, season int, status string) {
+	c.Redirect(http.StatusFound, fmt.Sprintf("/admin/scoring?league_id=%s&season=%d&%s", leagueID, season, status))
+}
+
+// AdminScoringHandler renders the per-league scoring editor.
+func AdminScoringHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagues, _ := store.GetLeaguesWithTeams(db)
+		if user.Role != "admin" {
+			leagues = filterLeaguesByID(leagues, adminLeagues)
+		}
+		leagueID := c.Query("league_id")
+		if leagueID == "" && len(leagues) > 0 {
+			leagueID = leagues[0].ID
+		}
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		season, err := strconv.Atoi(c.Query("season"))
+		if err != nil || season < 2026 || season > 2040 {
+			season = time.Now().Year()
+		}
+
+		pitching, err := store.GetLeagueScoringCategories(db, leagueID, season, "pitching")
+		if err != nil {
+			fmt.Printf("ERROR [AdminScoring]: %v\n", err)
+		}
+		hitting, _ := store.GetLeagueScoringCategories(db, leagueID, season, "hitting")
+
+		RenderTemplate(c, "admin_scoring.html", gin.H{
+			"User":        user,
+			"Leagues":     leagues,
+			"LeagueID":    leagueID,
+			"Season":      season,
+			"Pitching":    pitching,
+			"Hitting":     hitting,
+			"SaveSuccess": c.Query("saved") == "1",
+			"Reset":       c.Query("reset") == "1",
+			"Recomputed":  c.Query("recomputed"),
+			"IsCommish":   true,
+		})
+	}
+}
+
+// AdminSaveScoringHandler saves one stat type's scoring for a league and season, then rescores
+// that league's stored stats for the season.
+func AdminSaveScoringHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		statType := c.PostForm("stat_type")
+		season, err := strconv.Atoi(c.PostForm("season"))
+		if err != nil || leagueID == "" || (statType != "pitching" && statType != "hitting") {
+			c.String(http.StatusBadRequest, "Invalid scoring request")
+			return
+		}
+
+		defaults, _ := store.GetScoringCategories(db, statType)
+		var rules []store.ScoringRuleInput
+		for _, d := range defaults {
+			pts, err := strconv.ParseFloat(c.PostForm("points_"+d.StatKey), 64)
+			if err != nil {
+				c.String(http.StatusBadRequest, "Invalid points for %s", d.DisplayName)
+				return
+			}
+			rules = append(rules, store.ScoringRuleInput{
+				StatKey:  d.StatKey,
+				Points:   pts,
+				IsActive: c.PostForm("active_"+d.StatKey) == "on",
+			})
+		}
+
+		if err := store.SaveLeagueScoringRules(db, leagueID, season, statType, rules, user.ID); err != nil {
+			fmt.Printf("ERROR [AdminSaveScoring]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Error saving scoring: %v", err)
+			return
+		}
+
+		changed, err := store.RecomputeLeagueFantasyPoints(db, leagueID, season)
+		if err != nil {
+			fmt.Printf("ERROR [AdminSaveScoring]: recompute: %v\n", err)
+		}
+		scoringRedirect(c, leagueID, season, fmt.Sprintf("saved=1&recomputed=%d", changed))
+	}
+}
+
+// AdminResetScoringHandler returns a league to the default scoring for a season and rescores it.
+func AdminResetScoringHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		season, _ := strconv.Atoi(c.PostForm("season"))
+
+		if err := store.ResetLeagueScoringRules(db, leagueID, season); err != nil {
+			fmt.Printf("ERROR [AdminResetScoring]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		changed, err := store.RecomputeLeagueFantasyPoints(db, leagueID, season)
+		if err != nil {
+			fmt.Printf("ERROR [AdminResetScoring]: recompute: %v\n", err)
+		}
+		scoringRedirect(c, leagueID, season, fmt.Sprintf("reset=1&recomputed=%d", changed))
+	}
+}
+
+// AdminRecomputeScoringHandler rescores a league's stored stats for a season without changing rules.
+func AdminRecomputeScoringHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.String(http.StatusForbidden, "Commissioner Only")
+			return
+		}
+
+		leagueID := c.PostForm("league_id")
+		if !isLeagueCommissioner(db, user, leagueID) {
+			c.String(http.StatusForbidden, "Unauthorized for this league")
+			return
+		}
+		season, _ := strconv.Atoi(c.PostForm("season"))
+
+		changed, err := store.RecomputeLeagueFantasyPoints(db, leagueID, season)
+		if err != nil {
+			fmt.Printf("ERROR [AdminRecomputeScoring]: %v\n", err)
+			c.String(http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		scoringRedirect(c, leagueID, season, fmt.Sprintf("recomputed=%d", changed))
+	}
+}
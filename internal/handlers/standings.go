@@ -3,6 +3,8 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/fantrax"
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
@@ -10,6 +12,18 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// standingsRow is one team's line on the standings page, from Fantrax or native matchups.
+type standingsRow struct {
+	Rank           int
+	TeamName       string
+	Record         string
+	WinPercentage  float64
+	GamesBack      float64
+	TotalPointsFor float64
+	NativeRecord   string // in-house record, shown beside Fantrax's for cross-checking
+	Mismatch       bool
+}
+
 func StandingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet("user").(*store.User)
@@ -24,36 +38,115 @@ func StandingsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		var url string
 		var leagueName string
-		db.QueryRow(context.Background(), "SELECT name, fantrax_url FROM leagues WHERE id = $1", leagueID).Scan(&leagueName, &url)
-
-		var standings []fantrax.Standing
-		var errMsg string
-		switch {
-		case url == "":
-			errMsg = "This league is not linked to Fantrax yet. Ask a commissioner to configure it."
-		default:
-			s, err := fantrax.Fetch(url)
+		db.QueryRow(context.Background(), "SELECT name, COALESCE(fantrax_url, '') FROM leagues WHERE id = $1", leagueID).Scan(&leagueName, &url)
+
+		today := time.Now().In(liveScoringLocation())
+		year := today.Year()
+		settings := store.GetLeagueSettings(db, leagueID, year)
+
+		// In-house standings, whenever the league has a schedule
+		var native []store.StandingRow
+		hasSchedule := store.HasSchedule(db, leagueID, year)
+		if hasSchedule {
+			var err error
+			native, err = store.GetNativeStandings(db, leagueID, year, today.Format("2006-01-02"))
+			if err != nil {
+				fmt.Printf("ERROR [StandingsHandler]: native standings: %v\n", err)
+			}
+		}
+
+		var standings []standingsRow
+		var errMsg, notice string
+		source := "fantrax"
+		if settings.StandingsSource == "native" {
+			source = "native"
+		} else {
+			switch {
+			case url == "":
+				errMsg = "This league is not linked to Fantrax yet. Ask a commissioner to configure it."
+			default:
+				s, err := fantrax.Fetch(url)
+				if err != nil {
+					fmt.Printf("ERROR [StandingsHandler]: %v\n", err)
+					errMsg = "Couldn't reach Fantrax right now. Try again in a few minutes."
+				} else if len(s) == 0 {
+					errMsg = "No standings data available yet for this league."
+				} else {
+					nativeByFantrax := make(map[string]store.StandingRow)
+					for _, r := range native {
+						if r.FantraxTeamID != "" {
+							nativeByFantrax[r.FantraxTeamID] = r
+						}
+					}
+					for _, st := range s {
+						row := standingsRow{
+							Rank: st.Rank, TeamName: st.TeamName, Record: st.Record,
+							WinPercentage: st.WinPercentage, GamesBack: st.GamesBack, TotalPointsFor: st.TotalPointsFor,
+						}
+						if n, ok := nativeByFantrax[st.TeamID]; ok {
+							row.NativeRecord = n.Record()
+							row.Mismatch = row.NativeRecord != st.Record
+						}
+						standings = append(standings, row)
+					}
+				}
+			}
+			// Fall back to in-house standings when Fantrax can't supply them
+			if errMsg != "" && hasSchedule {
+				source = "native"
+				notice = errMsg + " Showing in-house standings instead."
+				errMsg = ""
+			}
+		}
+
+		if source == "native" {
+			if !hasSchedule {
+				errMsg = "No head-to-head schedule for this league yet. Ask a commissioner to generate one."
+			}
+			for _, r := range native {
+				standings = append(standings, standingsRow{
+					Rank: r.Rank, TeamName: r.TeamName, Record: r.Record(),
+					WinPercentage: r.WinPct, GamesBack: r.GamesBack, TotalPointsFor: r.PointsFor,
+				})
+			}
+		}
+
+		// Matchups for the selected (default: current) week
+		var matchups []store.Matchup
+		week, _ := strconv.Atoi(c.Query("week"))
+		currentWeek := 0
+		var weeks []int
+		if hasSchedule {
+			currentWeek = store.GetCurrentWeek(db, leagueID, year, today.Format("2006-01-02"))
+			if week <= 0 {
+				week = currentWeek
+			}
+			var err error
+			matchups, err = store.GetMatchups(db, leagueID, year, week)
 			if err != nil {
-				fmt.Printf("ERROR [StandingsHandler]: %v\n", err)
-				errMsg = "Couldn't reach Fantrax right now. Try again in a few minutes."
-			} else if len(s) == 0 {
-				errMsg = "No standings data available yet for this league."
-			} else {
-				standings = s
+				fmt.Printf("ERROR [StandingsHandler]: matchups: %v\n", err)
 			}
+			weeks = store.GetScheduleWeeks(db, leagueID, year)
 		}
 
 		leagues, _ := store.GetLeaguesWithTeams(db)
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
 		RenderTemplate(c, "standings.html", gin.H{
-			"User":       user,
-			"Standings":  standings,
-			"Leagues":    leagues,
-			"LeagueID":   leagueID,
-			"LeagueName": leagueName,
-			"IsCommish":  len(adminLeagues) > 0 || user.Role == "admin",
-			"Error":      errMsg,
+			"User":        user,
+			"Standings":   standings,
+			"Source":      source,
+			"CrossCheck":  source == "fantrax" && len(native) > 0,
+			"Matchups":    matchups,
+			"Week":        week,
+			"CurrentWeek": currentWeek,
+			"Weeks":       weeks,
+			"Leagues":     leagues,
+			"LeagueID":    leagueID,
+			"LeagueName":  leagueName,
+			"IsCommish":   len(adminLeagues) > 0 || user.Role == "admin",
+			"Error":       errMsg,
+			"Notice":      notice,
 		})
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
@@ -38,7 +39,12 @@ func StatsLeaderboardHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		leagues, _ := store.GetLeaguesWithTeams(db)
 
-		categories, _ := store.GetScoringCategories(db, "pitching")
+		// The selected league's scoring for the season being viewed (global defaults for all leagues)
+		season := now.Year()
+		if d, err := time.Parse("2006-01-02", startDate); err == nil {
+			season = d.Year()
+		}
+		categories, _ := store.GetLeagueScoringCategories(db, leagueID, season, "pitching")
 
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
@@ -80,7 +86,12 @@ func HittingLeaderboardHandler(db *pgxpool.Pool) gin.HandlerFunc {
 
 		leagues, _ := store.GetLeaguesWithTeams(db)
 
-		categories, _ := store.GetScoringCategories(db, "hitting")
+		// The selected league's scoring for the season being viewed (global defaults for all leagues)
+		season := now.Year()
+		if d, err := time.Parse("2006-01-02", startDate); err == nil {
+			season = d.Year()
+		}
+		categories, _ := store.GetLeagueScoringCategories(db, leagueID, season, "hitting")
 
 		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
 
@@ -139,9 +150,83 @@ func AdminBackfillStatsHandler(db *pgxpool.Pool) gin.HandlerFunc {
 		}
 
 		// Run in background goroutine with detached context (request context cancels on response)
-		go worker.ProcessDateStats(context.Background(), db, date)
+		go func() {
+			worker.ProcessDateStats(context.Background(), db, date)
+			worker.ProcessMiLBDateStats(context.Background(), db, date)
+		}()
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Backfill started for %s (MLB + MiLB, pitching + hitting)", date)})
+	}
+}
+
+// AdminBackfillStatCopiesHandler copies stored stat lines to every league's copy of each player (admin only).
+func AdminBackfillStatCopiesHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		if user.Role != "admin" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin only"})
+			return
+		}
+
+		season, _ := strconv.Atoi(c.PostForm("season")) // 0 = every season
+		created, err := store.BackfillStatLineCopies(db, season)
+		if err != nil {
+			fmt.Printf("ERROR [AdminBackfillStatCopies]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Backfill failed"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Created %d stat lines for other leagues' player copies", created), "created": created})
+	}
+}
+
+// AdminStatCorrectionsHandler re-checks recent processed dates for MLB scoring changes (admin only).
+func AdminStatCorrectionsHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		if user.Role != "admin" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin only"})
+			return
+		}
+
+		days := 7
+		if d, err := strconv.Atoi(c.PostForm("days")); err == nil && d > 0 && d <= 30 {
+			days = d
+		}
+
+		// Run in background goroutine with detached context (request context cancels on response)
+		go func() {
+			if _, err := worker.ProcessStatCorrections(context.Background(), db, days); err != nil {
+				fmt.Printf("ERROR [AdminStatCorrections]: %v\n", err)
+			}
+		}()
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Stat correction pass started for the last %d days", days)})
+	}
+}
 
-		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Backfill started for %s (pitching + hitting)", date)})
+// GetStatCorrectionsHandler returns the stat correction log as JSON.
+func GetStatCorrectionsHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		adminLeagues, _ := store.GetAdminLeagues(db, user.ID)
+		if len(adminLeagues) == 0 && user.Role != "admin" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Commissioner Only"})
+			return
+		}
+
+		limit := 100
+		if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 500 {
+			limit = l
+		}
+
+		corrections, err := store.GetStatCorrections(db, c.Query("league_id"), limit)
+		if err != nil {
+			fmt.Printf("ERROR [GetStatCorrections]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load stat corrections"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"corrections": corrections})
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/notification"
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
@@ -67,6 +68,21 @@ func NewTradeHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
+		// Projected points and contract surplus with the league's rules, on both sides
+		projSeason := store.GetProjectionSeason(db, targetTeam.LeagueID, time.Now().Year())
+		store.ApplyProjectedPoints(db, targetTeam.LeagueID, projSeason, targetTeam.Players)
+		for i := range filteredTeams {
+			store.ApplyProjectedPoints(db, targetTeam.LeagueID, projSeason, filteredTeams[i].Players)
+		}
+		if model, err := store.NewValuationModel(db, targetTeam.LeagueID); err != nil {
+			fmt.Printf("ERROR [NewTrade valuation]: %v\n", err)
+		} else {
+			store.ApplyValuations(model, targetTeam.Players)
+			for i := range filteredTeams {
+				store.ApplyValuations(model, filteredTeams[i].Players)
+			}
+		}
+
 		myTeamsJSON, _ := json.Marshal(filteredTeams)
 
 		RenderTemplate(c, "trade_new.html", gin.H{
@@ -79,6 +95,28 @@ func NewTradeHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	}
 }
 
+// parseTradeRetention reads each retained player's percentage (retain_pct_<id>, default 50, max 50)
+// and last retained year (retain_through_<id>, default the current season).
+func parseTradeRetention(c *gin.Context, retainedPlayers []string) map[string]store.TradeRetention {
+	currentYear := time.Now().Year()
+	retention := make(map[string]store.TradeRetention)
+	for _, pID := range retainedPlayers {
+		pct, err := strconv.Atoi(c.PostForm("retain_pct_" + pID))
+		if err != nil || pct > 50 {
+			pct = 50
+		}
+		if pct <= 0 {
+			continue
+		}
+		through, _ := strconv.Atoi(c.PostForm("retain_through_" + pID))
+		if through < currentYear || through > 2040 {
+			through = currentYear
+		}
+		retention[pID] = store.TradeRetention{Pct: pct, ThroughYear: through}
+	}
+	return retention
+}
+
 func SubmitTradeHandler(db *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := c.MustGet("user").(*store.User)
@@ -107,8 +145,8 @@ func SubmitTradeHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
-		allRetained := append(retained, retainedRequested...)
-		err := store.CreateTradeProposal(db, proposerID, receiverID, offered, requested, allRetained, isbpOffered, isbpRequested, "")
+		retention := parseTradeRetention(c, append(retained, retainedRequested...))
+		err := store.CreateTradeProposal(db, proposerID, receiverID, offered, requested, retention, isbpOffered, isbpRequested, "")
 		if err != nil {
 			fmt.Printf("ERROR [SubmitTrade]: %v\n", err)
 			c.String(http.StatusInternalServerError, "Internal server error")
@@ -244,14 +282,20 @@ func CounterTradeHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			RetainedRequested  []string `json:"retained_requested"`
 			IsbpOffered        int      `json:"isbp_offered"`
 			IsbpRequested      int      `json:"isbp_requested"`
+
+			Retention map[string]store.TradeRetention `json:"retention"`
 		}
 
 		pre := PreSelected{
 			IsbpOffered:   original.IsbpRequested, // flip
 			IsbpRequested: original.IsbpOffered,    // flip
+			Retention:     make(map[string]store.TradeRetention),
 		}
 
 		for _, item := range original.Items {
+			if item.RetainSalary {
+				pre.Retention[item.PlayerID] = store.TradeRetention{Pct: item.RetainPct, ThroughYear: item.RetainThroughYear}
+			}
 			if item.SenderTeamID == original.ProposingTeamID {
 				// Was offered by proposer -> now requested from target
 				pre.RequestedPlayerIDs = append(pre.RequestedPlayerIDs, item.PlayerID)
@@ -267,6 +311,16 @@ func CounterTradeHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			}
 		}
 
+		projSeason := store.GetProjectionSeason(db, targetTeam.LeagueID, time.Now().Year())
+		store.ApplyProjectedPoints(db, targetTeam.LeagueID, projSeason, targetTeam.Players)
+		store.ApplyProjectedPoints(db, counterProposerTeam.LeagueID, projSeason, counterProposerTeam.Players)
+		if model, err := store.NewValuationModel(db, targetTeam.LeagueID); err != nil {
+			fmt.Printf("ERROR [CounterTrade valuation]: %v\n", err)
+		} else {
+			store.ApplyValuations(model, targetTeam.Players)
+			store.ApplyValuations(model, counterProposerTeam.Players)
+		}
+
 		preJSON, _ := json.Marshal(pre)
 		myTeamsJSON, _ := json.Marshal([]store.TeamDetail{*counterProposerTeam})
 
@@ -328,8 +382,8 @@ func SubmitCounterHandler(db *pgxpool.Pool) gin.HandlerFunc {
 			return
 		}
 
-		allRetained := append(retained, retainedRequested...)
-		err = store.CreateTradeProposal(db, proposerID, receiverID, offered, requested, allRetained, isbpOffered, isbpRequested, parentTradeID)
+		retention := parseTradeRetention(c, append(retained, retainedRequested...))
+		err = store.CreateTradeProposal(db, proposerID, receiverID, offered, requested, retention, isbpOffered, isbpRequested, parentTradeID)
 		if err != nil {
 			fmt.Printf("ERROR [SubmitCounter]: %v\n", err)
 			c.String(http.StatusInternalServerError, "Internal server error")
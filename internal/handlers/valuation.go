@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// splitIDList parses a comma-separated list of player IDs, dropping blanks.
+func splitIDList(s string) []string {
+	var ids []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// canViewLeague reports whether the user owns a team in the league or commissions it.
+func canViewLeague(db *pgxpool.Pool, user *store.User, leagueID string) bool {
+	if isLeagueCommissioner(db, user, leagueID) {
+		return true
+	}
+	teams, _ := store.GetManagedTeams(db, user.ID)
+	for _, t := range teams {
+		if t.LeagueID == leagueID {
+			return true
+		}
+	}
+	return false
+}
+
+// PlayerValuationAPIHandler returns a player's valuation and contract surplus for ?player_id=.
+// Limited to members of the player's league.
+func PlayerValuationAPIHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		playerID := c.Query("player_id")
+		if playerID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "player_id is required"})
+			return
+		}
+		valuation, err := store.GetPlayerValuation(db, playerID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		if !canViewLeague(db, user, valuation.LeagueID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized for this league"})
+			return
+		}
+		c.JSON(http.StatusOK, valuation)
+	}
+}
+
+// CompareValuationAPIHandler values two packages of players from ?league_id= (?side_a= and
+// ?side_b= as comma-separated player IDs) so owners can weigh a trade. Limited to members of
+// the league.
+func CompareValuationAPIHandler(db *pgxpool.Pool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*store.User)
+		leagueID := c.Query("league_id")
+		sideA := splitIDList(c.Query("side_a"))
+		sideB := splitIDList(c.Query("side_b"))
+		if leagueID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "league_id is required"})
+			return
+		}
+		if len(sideA) == 0 && len(sideB) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "side_a or side_b is required"})
+			return
+		}
+		if !canViewLeague(db, user, leagueID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized for this league"})
+			return
+		}
+
+		a, b, err := store.ComparePackages(db, leagueID, sideA, sideB)
+		if err != nil {
+			fmt.Printf("ERROR [CompareValuation]: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to value packages"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"side_a":              a,
+			"side_b":              b,
+			"net_surplus":         a.TotalSurplus - b.TotalSurplus,
+			"net_surplus_present": a.SurplusPV - b.SurplusPV,
+		})
+	}
+}
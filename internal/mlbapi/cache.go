@@ -0,0 +1,82 @@
+package mlbapi
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fileName maps a request key to a stable file name: a readable prefix plus a hash so
+// long hydrate queries stay unique.
+func fileName(key string) string {
+	sum := sha1.Sum([]byte(key))
+	readable := unsafeFileChars.ReplaceAllString(key, "_")
+	if len(readable) > 80 {
+		readable = readable[:80]
+	}
+	return fmt.Sprintf("%s_%s.json", readable, hex.EncodeToString(sum[:6]))
+}
+
+// readCache returns a cached body younger than ttl.
+func (c *Client) readCache(key string, ttl time.Duration) ([]byte, bool) {
+	if c.cfg.CacheDir == "" || ttl <= 0 {
+		return nil, false
+	}
+	path := filepath.Join(c.cfg.CacheDir, fileName(key))
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil, false
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// writeCache stores a body in the cache. Failures only cost a refetch, so they are ignored.
+func (c *Client) writeCache(key string, body []byte) {
+	if c.cfg.CacheDir == "" {
+		return
+	}
+	writeFileAtomic(filepath.Join(c.cfg.CacheDir, fileName(key)), body)
+}
+
+func (c *Client) readFixture(key string) ([]byte, error) {
+	body, err := os.ReadFile(filepath.Join(c.cfg.FixtureDir, fileName(key)))
+	if err != nil {
+		return nil, fmt.Errorf("mlbapi: no fixture for %s in %s: %w", key, c.cfg.FixtureDir, err)
+	}
+	return body, nil
+}
+
+func (c *Client) writeFixture(key string, body []byte) error {
+	return writeFileAtomic(filepath.Join(c.cfg.FixtureDir, fileName(key)), body)
+}
+
+// writeFileAtomic writes via a temp file and rename so readers never see a partial body.
+func writeFileAtomic(path string, body []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,229 @@
+// Package mlbapi is the shared client for the MLB Stats API (statsapi.mlb.com).
+//
+// Every request goes through one token-bucket limiter, is retried with backoff on network
+// errors, 429s and 5xxs, and can be served from an on-disk cache. In record mode responses
+// are also written to a fixture directory; in replay mode they are read back from it and the
+// network is never touched, so the stats pipeline can run against saved games offline.
+package mlbapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Mode controls whether the client uses the network, fixtures, or both.
+type Mode string
+
+const (
+	ModeLive   Mode = "live"   // network only (default)
+	ModeRecord Mode = "record" // network, saving every response as a fixture
+	ModeReplay Mode = "replay" // fixtures only; missing fixtures are errors
+)
+
+// Config configures a Client. Zero values fall back to the defaults below.
+type Config struct {
+	BaseURL       string        // default https://statsapi.mlb.com/api
+	Timeout       time.Duration // per attempt, default 15s
+	RatePerSecond float64       // sustained request rate, default 2
+	Burst         int           // bucket size, default 5
+	MaxRetries    int           // retries after the first attempt, default 3; negative disables
+	CacheDir      string        // on-disk response cache; empty disables caching
+	Mode          Mode
+	FixtureDir    string // record/replay directory, default testdata/mlbapi
+}
+
+// Client is an MLB Stats API client. Safe for concurrent use.
+type Client struct {
+	cfg     Config
+	http    *http.Client
+	limiter *limiter
+}
+
+// New builds a client from cfg, filling in defaults.
+func New(cfg Config) *Client {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://statsapi.mlb.com/api"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	if cfg.RatePerSecond <= 0 {
+		cfg.RatePerSecond = 2
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = 5
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = ModeLive
+	}
+	if cfg.FixtureDir == "" {
+		cfg.FixtureDir = filepath.Join("testdata", "mlbapi")
+	}
+	return &Client{
+		cfg:     cfg,
+		http:    &http.Client{Timeout: cfg.Timeout},
+		limiter: newLimiter(cfg.RatePerSecond, cfg.Burst),
+	}
+}
+
+// ConfigFromEnv reads MLB_API_BASE_URL, MLB_API_RATE_PER_SEC, MLB_API_CACHE_DIR,
+// MLB_API_MODE and MLB_API_FIXTURE_DIR. The cache defaults to a directory under os.TempDir();
+// set MLB_API_CACHE_DIR=off to disable it.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		BaseURL:    os.Getenv("MLB_API_BASE_URL"),
+		CacheDir:   os.Getenv("MLB_API_CACHE_DIR"),
+		Mode:       Mode(os.Getenv("MLB_API_MODE")),
+		FixtureDir: os.Getenv("MLB_API_FIXTURE_DIR"),
+	}
+	if r, err := strconv.ParseFloat(os.Getenv("MLB_API_RATE_PER_SEC"), 64); err == nil {
+		cfg.RatePerSecond = r
+	}
+	switch cfg.CacheDir {
+	case "":
+		cfg.CacheDir = filepath.Join(os.TempDir(), "fods-mlbapi-cache")
+	case "off":
+		cfg.CacheDir = ""
+	}
+	return cfg
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultClient *Client
+)
+
+// Init sets up the shared client from the environment. Safe to call more than once;
+// Default calls it lazily for tools that don't.
+func Init() {
+	defaultOnce.Do(func() {
+		defaultClient = New(ConfigFromEnv())
+		cfg := defaultClient.cfg
+		fmt.Printf("MLB API client: %s (mode=%s, %.1f req/s, cache=%q)\n", cfg.BaseURL, cfg.Mode, cfg.RatePerSecond, cfg.CacheDir)
+	})
+}
+
+// Default returns the shared client used by workers and handlers.
+func Default() *Client {
+	Init()
+	return defaultClient
+}
+
+// StatusError is returned for a non-retryable HTTP error status.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("mlbapi: %s returned status %d", e.URL, e.StatusCode)
+}
+
+// getJSON fetches path?query and decodes it into out. Responses younger than ttl are served
+// from the disk cache outside record mode; a ttl of 0 always goes to the network (or fixtures
+// in replay mode).
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, ttl time.Duration, out interface{}) error {
+	key := path
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+
+	if c.cfg.Mode == ModeReplay {
+		body, err := c.readFixture(key)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, out)
+	}
+
+	// Record mode skips the cache so every call reaches the network and leaves a fixture behind
+	if c.cfg.Mode != ModeRecord {
+		if body, ok := c.readCache(key, ttl); ok {
+			if err := json.Unmarshal(body, out); err == nil {
+				return nil
+			}
+		}
+	}
+
+	body, err := c.fetch(ctx, c.cfg.BaseURL+key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("mlbapi: decode %s: %w", path, err)
+	}
+
+	if ttl > 0 {
+		c.writeCache(key, body)
+	}
+	if c.cfg.Mode == ModeRecord {
+		if err := c.writeFixture(key, body); err != nil {
+			fmt.Printf("ERROR [mlbapi]: record fixture %s: %v\n", key, err)
+		}
+	}
+	return nil
+}
+
+// fetch performs a rate-limited GET with retries and exponential backoff.
+func (c *Client) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	backoff := 500 * time.Millisecond
+	var lastErr error
+
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			lastErr = &StatusError{URL: rawURL, StatusCode: resp.StatusCode}
+			if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+				backoff = time.Duration(secs) * time.Second
+			}
+			continue
+		case resp.StatusCode >= 400:
+			return nil, &StatusError{URL: rawURL, StatusCode: resp.StatusCode}
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("mlbapi: %s failed after %d attempts: %w", rawURL, c.cfg.MaxRetries+1, lastErr)
+}
@@ -0,0 +1,286 @@
+package mlbapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Cache lifetimes. Box scores are short-lived so the daily stat-correction pass sees
+// MLB's revisions; live feeds are never cached.
+const (
+	pastScheduleTTL = time.Hour
+	boxScoreTTL     = 10 * time.Minute
+)
+
+// --- Schedule ---
+
+// Sport IDs for MLB and the minor-league levels we ingest.
+const (
+	SportMLB     = 1
+	SportAAA     = 11
+	SportAA      = 12
+	SportHighA   = 13
+	SportSingleA = 14
+)
+
+// Level is a minor-league level: its sport ID and the label stored on stat lines.
+type Level struct {
+	SportID int
+	Name    string
+}
+
+var MiLBLevels = []Level{
+	{SportAAA, "AAA"},
+	{SportAA, "AA"},
+	{SportHighA, "A+"},
+	{SportSingleA, "A"},
+}
+
+// ScheduleQuery selects games by a single date or a date range.
+type ScheduleQuery struct {
+	SportID   int    // default SportMLB
+	Date      string // YYYY-MM-DD
+	StartDate string
+	EndDate   string
+	Hydrate   string // e.g. "probablePitcher"
+}
+
+type Schedule struct {
+	Dates []ScheduleDate `json:"dates"`
+}
+
+type ScheduleDate struct {
+	Date  string         `json:"date"`
+	Games []ScheduleGame `json:"games"`
+}
+
+type ScheduleGame struct {
+	GamePk   int        `json:"gamePk"`
+	GameDate string     `json:"gameDate"` // UTC start time
+	Status   GameStatus `json:"status"`
+	Teams    struct {
+		Away ScheduleTeam `json:"away"`
+		Home ScheduleTeam `json:"home"`
+	} `json:"teams"`
+}
+
+type GameStatus struct {
+	AbstractGameState string `json:"abstractGameState"` // Preview, Live, Final
+	DetailedState     string `json:"detailedState"`
+}
+
+type ScheduleTeam struct {
+	Team            TeamRef   `json:"team"`
+	Score           int       `json:"score"`
+	ProbablePitcher PersonRef `json:"probablePitcher"`
+}
+
+type TeamRef struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation"`
+}
+
+type PersonRef struct {
+	ID       int    `json:"id"`
+	FullName string `json:"fullName"`
+}
+
+// Games returns every game in the schedule, across dates.
+func (s *Schedule) Games() []ScheduleGame {
+	var games []ScheduleGame
+	for _, d := range s.Dates {
+		games = append(games, d.Games...)
+	}
+	return games
+}
+
+// Schedule fetches the schedule. Ranges ending before yesterday are cached for an hour; yesterday
+// stays uncached since late games (and UTC servers) run past midnight ET.
+func (c *Client) Schedule(ctx context.Context, q ScheduleQuery) (*Schedule, error) {
+	if q.SportID == 0 {
+		q.SportID = SportMLB
+	}
+	params := url.Values{}
+	params.Set("sportId", strconv.Itoa(q.SportID))
+	last := q.Date
+	if q.Date != "" {
+		params.Set("date", q.Date)
+	} else {
+		params.Set("startDate", q.StartDate)
+		params.Set("endDate", q.EndDate)
+		last = q.EndDate
+	}
+	if q.Hydrate != "" {
+		params.Set("hydrate", q.Hydrate)
+	}
+
+	var ttl time.Duration
+	if last != "" && last < time.Now().AddDate(0, 0, -1).Format("2006-01-02") {
+		ttl = pastScheduleTTL
+	}
+
+	var s Schedule
+	if err := c.getJSON(ctx, "/v1/schedule", params, ttl, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// --- Box Score ---
+
+type BoxScore struct {
+	Teams struct {
+		Away BoxScoreSide `json:"away"`
+		Home BoxScoreSide `json:"home"`
+	} `json:"teams"`
+}
+
+type BoxScoreSide struct {
+	Team    TeamRef                   `json:"team"`
+	Players map[string]BoxScorePlayer `json:"players"`
+}
+
+type BoxScorePlayer struct {
+	Person   PersonRef `json:"person"`
+	Position struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"position"`
+	Stats struct {
+		Pitching PitchingStats `json:"pitching"`
+		Batting  BattingStats  `json:"batting"`
+	} `json:"stats"`
+}
+
+type PitchingStats struct {
+	InningsPitched         string      `json:"inningsPitched"`
+	Hits                   json.Number `json:"hits"`
+	EarnedRuns             json.Number `json:"earnedRuns"`
+	BaseOnBalls            json.Number `json:"baseOnBalls"`
+	StrikeOuts             json.Number `json:"strikeOuts"`
+	HomeRuns               json.Number `json:"homeRuns"`
+	HitByPitch             json.Number `json:"hitByPitch"`
+	Balks                  json.Number `json:"balks"`
+	WildPitches            json.Number `json:"wildPitches"`
+	Pickoffs               json.Number `json:"pickoffs"`
+	GamesStarted           json.Number `json:"gamesStarted"`
+	CompleteGames          json.Number `json:"completeGames"`
+	Shutouts               json.Number `json:"shutouts"`
+	Saves                  json.Number `json:"saves"`
+	Holds                  json.Number `json:"holds"`
+	BlownSaves             json.Number `json:"blownSaves"`
+	InheritedRunners       json.Number `json:"inheritedRunners"`
+	InheritedRunnersScored json.Number `json:"inheritedRunnersScored"`
+	NumberOfPitches        json.Number `json:"numberOfPitches"`
+}
+
+type BattingStats struct {
+	AtBats           json.Number `json:"atBats"`
+	Runs             json.Number `json:"runs"`
+	Hits             json.Number `json:"hits"`
+	Doubles          json.Number `json:"doubles"`
+	Triples          json.Number `json:"triples"`
+	HomeRuns         json.Number `json:"homeRuns"`
+	RBI              json.Number `json:"rbi"`
+	BaseOnBalls      json.Number `json:"baseOnBalls"`
+	StrikeOuts       json.Number `json:"strikeOuts"`
+	StolenBases      json.Number `json:"stolenBases"`
+	CaughtStealing   json.Number `json:"caughtStealing"`
+	HitByPitch       json.Number `json:"hitByPitch"`
+	PlateAppearances json.Number `json:"plateAppearances"`
+}
+
+// BoxScore fetches a game's box score.
+func (c *Client) BoxScore(ctx context.Context, gamePk int) (*BoxScore, error) {
+	var b BoxScore
+	if err := c.getJSON(ctx, fmt.Sprintf("/v1/game/%d/boxscore", gamePk), nil, boxScoreTTL, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// --- Live Feed ---
+
+type LiveFeed struct {
+	GameData struct {
+		Status GameStatus `json:"status"`
+	} `json:"gameData"`
+	LiveData struct {
+		Plays struct {
+			AllPlays []Play `json:"allPlays"`
+		} `json:"plays"`
+		Linescore Linescore `json:"linescore"`
+		Boxscore  BoxScore  `json:"boxscore"`
+	} `json:"liveData"`
+}
+
+type Play struct {
+	Result struct {
+		Event       string `json:"event"`
+		EventType   string `json:"eventType"`
+		Description string `json:"description"`
+	} `json:"result"`
+	About struct {
+		AtBatIndex    int    `json:"atBatIndex"`
+		HalfInning    string `json:"halfInning"`
+		Inning        int    `json:"inning"`
+		IsComplete    bool   `json:"isComplete"`
+		IsScoringPlay bool   `json:"isScoringPlay"`
+	} `json:"about"`
+	MatchUp struct {
+		Batter  PersonRef `json:"batter"`
+		Pitcher PersonRef `json:"pitcher"`
+	} `json:"matchup"`
+	PlayEvents []PlayEvent `json:"playEvents"`
+	Runners    []Runner    `json:"runners"`
+}
+
+// PlayEvent is a pitch, pickoff, substitution, delay etc. within a plate appearance.
+type PlayEvent struct {
+	Details struct {
+		Event       string `json:"event"`
+		EventType   string `json:"eventType"` // e.g. injury, pitching_substitution
+		Description string `json:"description"`
+	} `json:"details"`
+	Index          int       `json:"index"`
+	IsSubstitution bool      `json:"isSubstitution"`
+	Player         PersonRef `json:"player"`
+	ReplacedPlayer PersonRef `json:"replacedPlayer"`
+}
+
+// Runner is one runner's movement during a play; stolen bases appear here.
+type Runner struct {
+	Details struct {
+		Event     string    `json:"event"`
+		EventType string    `json:"eventType"` // e.g. stolen_base_2b
+		Runner    PersonRef `json:"runner"`
+		PlayIndex int       `json:"playIndex"`
+	} `json:"details"`
+}
+
+type Linescore struct {
+	CurrentInning        int    `json:"currentInning"`
+	CurrentInningOrdinal string `json:"currentInningOrdinal"`
+	InningState          string `json:"inningState"`
+	Teams                struct {
+		Away struct {
+			Runs int `json:"runs"`
+		} `json:"away"`
+		Home struct {
+			Runs int `json:"runs"`
+		} `json:"home"`
+	} `json:"teams"`
+}
+
+// LiveFeed fetches a game's live feed. Never cached.
+func (c *Client) LiveFeed(ctx context.Context, gamePk int) (*LiveFeed, error) {
+	var f LiveFeed
+	if err := c.getJSON(ctx, fmt.Sprintf("/v1.1/game/%d/feed/live", gamePk), nil, 0, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
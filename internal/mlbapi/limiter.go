@@ -0,0 +1,46 @@
+package mlbapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket shared by every request a Client makes.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	max    float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	return &limiter{rate: rate, max: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.max {
+			l.tokens = l.max
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
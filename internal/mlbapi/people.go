@@ -0,0 +1,72 @@
+package mlbapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// peopleTTL covers player searches and career stats, which change slowly.
+const peopleTTL = 24 * time.Hour
+
+type Person struct {
+	ID              int    `json:"id"`
+	FullName        string `json:"fullName"`
+	FirstName       string `json:"firstName"`
+	LastName        string `json:"lastName"`
+	FullFMLName     string `json:"fullFMLName"`
+	Active          bool   `json:"active"`
+	BirthDate       string `json:"birthDate"` // YYYY-MM-DD
+	PrimaryPosition struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"primaryPosition"`
+	CurrentTeam TeamRef     `json:"currentTeam"`
+	Stats       []StatGroup `json:"stats"`
+}
+
+// StatGroup is one hydrated stats block (e.g. career pitching).
+type StatGroup struct {
+	Group struct {
+		DisplayName string `json:"displayName"` // hitting, pitching
+	} `json:"group"`
+	Splits []struct {
+		Stat json.RawMessage `json:"stat"`
+	} `json:"splits"`
+}
+
+// SearchPeople searches players by full name.
+func (c *Client) SearchPeople(ctx context.Context, name string) ([]Person, error) {
+	params := url.Values{}
+	params.Set("names", name)
+	params.Set("hydrate", "currentTeam")
+
+	var resp struct {
+		People []Person `json:"people"`
+	}
+	if err := c.getJSON(ctx, "/v1/people/search", params, peopleTTL, &resp); err != nil {
+		return nil, err
+	}
+	return resp.People, nil
+}
+
+// CareerStats fetches people with career hitting and pitching stats hydrated.
+func (c *Client) CareerStats(ctx context.Context, mlbIDs []int) ([]Person, error) {
+	ids := make([]string, len(mlbIDs))
+	for i, id := range mlbIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	params := url.Values{}
+	params.Set("personIds", strings.Join(ids, ","))
+	params.Set("hydrate", "stats(group=[hitting,pitching],type=[career])")
+
+	var resp struct {
+		People []Person `json:"people"`
+	}
+	if err := c.getJSON(ctx, "/v1/people", params, peopleTTL, &resp); err != nil {
+		return nil, err
+	}
+	return resp.People, nil
+}
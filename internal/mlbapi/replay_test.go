@@ -0,0 +1,108 @@
+package mlbapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// replayClient serves requests from the fixtures in testdata and never touches the network.
+func replayClient() *Client {
+	return New(Config{Mode: ModeReplay, FixtureDir: "testdata", CacheDir: ""})
+}
+
+func TestScheduleReplay(t *testing.T) {
+	s, err := replayClient().Schedule(context.Background(), ScheduleQuery{Date: "2025-06-01"})
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	games := s.Games()
+	if len(games) != 2 {
+		t.Fatalf("got %d games, want 2", len(games))
+	}
+	g := games[0]
+	if g.GamePk != 777245 || g.GameDate != "2025-06-01T17:35:00Z" {
+		t.Errorf("game = %d at %s, want 777245 at 2025-06-01T17:35:00Z", g.GamePk, g.GameDate)
+	}
+	if g.Status.AbstractGameState != "Final" {
+		t.Errorf("state = %q, want Final", g.Status.AbstractGameState)
+	}
+	if g.Teams.Away.Team.Abbreviation != "NYY" || g.Teams.Home.Team.Abbreviation != "BOS" {
+		t.Errorf("teams = %s @ %s, want NYY @ BOS", g.Teams.Away.Team.Abbreviation, g.Teams.Home.Team.Abbreviation)
+	}
+	if games[1].Status.DetailedState != "Postponed" {
+		t.Errorf("second game state = %q, want Postponed", games[1].Status.DetailedState)
+	}
+}
+
+func TestBoxScoreReplay(t *testing.T) {
+	b, err := replayClient().BoxScore(context.Background(), 777245)
+	if err != nil {
+		t.Fatalf("BoxScore: %v", err)
+	}
+	if b.Teams.Away.Team.Abbreviation != "NYY" || len(b.Teams.Away.Players) != 4 {
+		t.Fatalf("away = %s with %d players, want NYY with 4", b.Teams.Away.Team.Abbreviation, len(b.Teams.Away.Players))
+	}
+	cole := b.Teams.Away.Players["ID543037"]
+	if cole.Person.ID != 543037 || cole.Stats.Pitching.InningsPitched != "7.0" || cole.Stats.Pitching.StrikeOuts.String() != "9" {
+		t.Errorf("Cole = %+v, want 7.0 IP and 9 K", cole.Stats.Pitching)
+	}
+	judge := b.Teams.Away.Players["ID592450"]
+	if judge.Stats.Batting.HomeRuns.String() != "1" || judge.Stats.Batting.RBI.String() != "3" {
+		t.Errorf("Judge = %+v, want 1 HR and 3 RBI", judge.Stats.Batting)
+	}
+}
+
+func TestCareerStatsReplay(t *testing.T) {
+	people, err := replayClient().CareerStats(context.Background(), []int{592450, 660271})
+	if err != nil {
+		t.Fatalf("CareerStats: %v", err)
+	}
+	if len(people) != 2 {
+		t.Fatalf("got %d people, want 2", len(people))
+	}
+	if people[0].FullName != "Aaron Judge" || people[0].BirthDate != "1992-04-26" || len(people[0].Stats) != 1 {
+		t.Errorf("Judge = %s born %s with %d stat groups", people[0].FullName, people[0].BirthDate, len(people[0].Stats))
+	}
+	ohtani := people[1]
+	if len(ohtani.Stats) != 2 || ohtani.Stats[0].Group.DisplayName != "hitting" || ohtani.Stats[1].Group.DisplayName != "pitching" {
+		t.Fatalf("Ohtani stat groups = %+v, want hitting and pitching", ohtani.Stats)
+	}
+	if len(ohtani.Stats[1].Splits) != 1 || len(ohtani.Stats[1].Splits[0].Stat) == 0 {
+		t.Errorf("Ohtani pitching splits missing")
+	}
+}
+
+func TestReplayMissingFixture(t *testing.T) {
+	if _, err := replayClient().BoxScore(context.Background(), 1); err == nil {
+		t.Fatal("BoxScore with no fixture succeeded, want error")
+	}
+}
+
+func TestRecordBypassesCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"teams":{"away":{"team":{"abbreviation":"NYY"}},"home":{"team":{"abbreviation":"BOS"}}}}`))
+	}))
+	defer srv.Close()
+
+	cacheDir, fixtureDir := t.TempDir(), t.TempDir()
+	key := "/v1/game/777245/boxscore"
+	if err := writeFileAtomic(filepath.Join(cacheDir, fileName(key)), []byte(`{"teams":{}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(Config{BaseURL: srv.URL, Mode: ModeRecord, CacheDir: cacheDir, FixtureDir: fixtureDir})
+	b, err := c.BoxScore(context.Background(), 777245)
+	if err != nil {
+		t.Fatalf("BoxScore: %v", err)
+	}
+	if b.Teams.Away.Team.Abbreviation != "NYY" {
+		t.Errorf("served the cached body, want the network response")
+	}
+	if _, err := os.Stat(filepath.Join(fixtureDir, fileName(key))); err != nil {
+		t.Errorf("fixture not recorded: %v", err)
+	}
+}
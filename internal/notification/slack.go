@@ -36,6 +36,21 @@ func SendSlackNotification(db *pgxpool.Pool, leagueID, notifyType, message strin
 		return nil // Not configured, skip
 	}
 
+	return postSlackMessage(token, channelID, message)
+}
+
+// SendSlackToChannel posts to a specific Slack channel using the league's bot token.
+func SendSlackToChannel(db *pgxpool.Pool, leagueID, channelID, message string) error {
+	var token string
+	err := db.QueryRow(context.Background(), `SELECT slack_bot_token FROM league_integrations WHERE league_id = $1`, leagueID).Scan(&token)
+	if err != nil || token == "" || channelID == "" {
+		return nil // Not configured, skip
+	}
+
+	return postSlackMessage(token, channelID, message)
+}
+
+func postSlackMessage(token, channelID, message string) error {
 	payload := SlackPayload{
 		Channel: channelID,
 		Text:    message,
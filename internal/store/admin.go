@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,6 +22,7 @@ type PlayerAdminUpdate struct {
 	OptionYears int    `json:"option_years_used"`
 	IsIFA       bool   `json:"is_international_free_agent"`
 	DFAOnly     bool   `json:"dfa_only"`
+	IsTwoWay    bool   `json:"is_two_way"`
 	Contracts   map[string]string `json:"contracts"`
 	ContractOptionYears []int `json:"contract_option_years"`
 	// Bid & FA fields (commissioner adjustment)
@@ -105,11 +107,14 @@ func AdminUpdatePlayer(db *pgxpool.Pool, u PlayerAdminUpdate) error {
 			contract_2036 = $21, contract_2037 = $22, contract_2038 = $23, contract_2039 = $24, contract_2040 = $25,
 			fa_status = $26,
 			pending_bid_amount = $27, pending_bid_years = $28, pending_bid_aav = $29,
+			pending_bid_schedule = CASE WHEN pending_bid_years IS DISTINCT FROM $28 OR pending_bid_aav IS DISTINCT FROM $29
+				THEN NULL ELSE pending_bid_schedule END,
 			pending_bid_team_id = $30, bid_type = $31,
 			is_international_free_agent = $32,
 			contract_option_years = $33::jsonb,
-			dfa_only = $34
-		WHERE id = $35
+			dfa_only = $34,
+			is_two_way = $35
+		WHERE id = $36
 	`, u.FirstName, u.LastName, u.Position, u.MLBTeam, teamID, u.LeagueID,
 		u.Status40Man, u.Status26Man, u.StatusIL, u.OptionYears,
 		u.Contracts["2026"], u.Contracts["2027"], u.Contracts["2028"], u.Contracts["2029"], u.Contracts["2030"],
@@ -120,9 +125,16 @@ func AdminUpdatePlayer(db *pgxpool.Pool, u PlayerAdminUpdate) error {
 		u.IsIFA,
 		optYearsJSON,
 		u.DFAOnly,
+		u.IsTwoWay,
 		u.ID)
 	if err != nil { return err }
-	return tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil { return err }
+
+	// Bio edits go to the canonical record so every league's copy stays in line
+	if _, err := SyncPlayerCopyToMaster(db, u.ID); err != nil {
+		fmt.Printf("ERROR [AdminUpdatePlayer]: canonical sync %s: %v\n", u.ID, err)
+	}
+	return nil
 }
 
 func AdminCreatePlayer(db *pgxpool.Pool, u PlayerAdminUpdate) (string, error) {
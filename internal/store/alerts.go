@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Live Alert Rules ---
+
+type AlertRule struct {
+	ID         string    `json:"id"`
+	LeagueID   string    `json:"league_id"`
+	Name       string    `json:"name"`
+	EventType  string    `json:"event_type"`
+	StatType   string    `json:"stat_type"`
+	StatKey    string    `json:"stat_key"`
+	Threshold  float64   `json:"threshold"`
+	Scope      string    `json:"scope"` // rostered, teams, players
+	TeamIDs    []string  `json:"team_ids"`
+	PlayerIDs  []string  `json:"player_ids"`
+	NotifyType string    `json:"notify_type"`
+	ChannelID  string    `json:"channel_id"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AlertEventType is one kind of live event a rule can watch for.
+type AlertEventType struct {
+	Key   string
+	Label string
+}
+
+var AlertEventTypes = []AlertEventType{
+	{"home_run", "Home run"},
+	{"stolen_base", "Stolen base"},
+	{"save", "Save"},
+	{"quality_start", "Quality start"},
+	{"no_hitter", "No-hitter in progress"},
+	{"injury_exit", "Pitcher injury exit"},
+	{"stat_threshold", "Stat threshold"},
+}
+
+// AlertNotifyTypes are the league_integrations channels a rule can post to.
+var AlertNotifyTypes = []AlertEventType{
+	{"stat_alerts", "Stat Alerts"},
+	{"transactions", "Transactions"},
+	{"completed_trades", "Completed Trades"},
+	{"trade_block", "Trade Block"},
+}
+
+type AlertEvent struct {
+	RuleName string    `json:"rule_name"`
+	Message  string    `json:"message"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// AlertPlayerOption is a rostered player a rule can be scoped to.
+type AlertPlayerOption struct {
+	ID       string
+	Name     string
+	TeamName string
+}
+
+const alertRuleColumns = `id, league_id, name, event_type, stat_type, stat_key, threshold, scope,
+	team_ids::TEXT[], player_ids::TEXT[], notify_type, channel_id, is_active, created_at`
+
+func queryAlertRules(db *pgxpool.Pool, where string, args ...interface{}) ([]AlertRule, error) {
+	rows, err := db.Query(context.Background(),
+		`SELECT `+alertRuleColumns+` FROM alert_rules `+where+` ORDER BY created_at`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []AlertRule
+	for rows.Next() {
+		var r AlertRule
+		if err := rows.Scan(&r.ID, &r.LeagueID, &r.Name, &r.EventType, &r.StatType, &r.StatKey, &r.Threshold, &r.Scope,
+			&r.TeamIDs, &r.PlayerIDs, &r.NotifyType, &r.ChannelID, &r.IsActive, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+// GetAlertRules returns a league's alert rules.
+func GetAlertRules(db *pgxpool.Pool, leagueID string) ([]AlertRule, error) {
+	return queryAlertRules(db, `WHERE league_id = $1`, leagueID)
+}
+
+// GetActiveAlertRules returns every league's active rules, for the alert monitor.
+func GetActiveAlertRules(db *pgxpool.Pool) ([]AlertRule, error) {
+	return queryAlertRules(db, `WHERE is_active = TRUE`)
+}
+
+func CreateAlertRule(db *pgxpool.Pool, r *AlertRule) error {
+	if r.TeamIDs == nil {
+		r.TeamIDs = []string{}
+	}
+	if r.PlayerIDs == nil {
+		r.PlayerIDs = []string{}
+	}
+	return db.QueryRow(context.Background(), `
+		INSERT INTO alert_rules (league_id, name, event_type, stat_type, stat_key, threshold, scope,
+			team_ids, player_ids, notify_type, channel_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8::UUID[], $9::UUID[], $10, $11)
+		RETURNING id, is_active, created_at
+	`, r.LeagueID, r.Name, r.EventType, r.StatType, r.StatKey, r.Threshold, r.Scope,
+		r.TeamIDs, r.PlayerIDs, r.NotifyType, r.ChannelID).Scan(&r.ID, &r.IsActive, &r.CreatedAt)
+}
+
+// GetAlertRuleLeagueID returns the league a rule belongs to.
+func GetAlertRuleLeagueID(db *pgxpool.Pool, ruleID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `SELECT league_id FROM alert_rules WHERE id = $1`, ruleID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// ToggleAlertRule flips a rule on or off and returns its league.
+func ToggleAlertRule(db *pgxpool.Pool, ruleID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(),
+		`UPDATE alert_rules SET is_active = NOT is_active WHERE id = $1 RETURNING league_id`, ruleID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// DeleteAlertRule removes a rule and its sent-event history, returning its league.
+func DeleteAlertRule(db *pgxpool.Pool, ruleID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(),
+		`DELETE FROM alert_rules WHERE id = $1 RETURNING league_id`, ruleID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// ClaimAlertEvent records that a rule is posting an event. Returns false if it was already posted,
+// so a restarted monitor re-scanning the same plays doesn't post twice.
+func ClaimAlertEvent(db *pgxpool.Pool, ruleID, eventKey string, gamePk, mlbID int, message string) (bool, error) {
+	tag, err := db.Exec(context.Background(), `
+		INSERT INTO alert_events (rule_id, event_key, game_pk, mlb_id, message)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (rule_id, event_key) DO NOTHING
+	`, ruleID, eventKey, gamePk, mlbID, message)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseAlertEvent drops a claim whose post failed so the next pass retries it.
+func ReleaseAlertEvent(db *pgxpool.Pool, ruleID, eventKey string) error {
+	_, err := db.Exec(context.Background(),
+		`DELETE FROM alert_events WHERE rule_id = $1 AND event_key = $2`, ruleID, eventKey)
+	return err
+}
+
+// GetRecentAlertEvents returns a league's most recently posted alerts.
+func GetRecentAlertEvents(db *pgxpool.Pool, leagueID string, limit int) ([]AlertEvent, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT r.name, e.message, e.sent_at
+		FROM alert_events e
+		JOIN alert_rules r ON r.id = e.rule_id
+		WHERE r.league_id = $1
+		ORDER BY e.sent_at DESC
+		LIMIT $2
+	`, leagueID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []AlertEvent
+	for rows.Next() {
+		var e AlertEvent
+		if err := rows.Scan(&e.RuleName, &e.Message, &e.SentAt); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// GetAlertPlayerOptions lists a league's rostered players for scoping rules.
+func GetAlertPlayerOptions(db *pgxpool.Pool, leagueID string) ([]AlertPlayerOption, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT p.id, p.first_name || ' ' || p.last_name, t.name
+		FROM players p
+		JOIN teams t ON t.id = p.team_id
+		WHERE p.league_id = $1
+		ORDER BY t.name, p.last_name, p.first_name
+	`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var opts []AlertPlayerOption
+	for rows.Next() {
+		var o AlertPlayerOption
+		if err := rows.Scan(&o.ID, &o.Name, &o.TeamName); err != nil {
+			continue
+		}
+		opts = append(opts, o)
+	}
+	return opts, nil
+}
+
+// GetRosteredCopy returns a league's rostered copy of an MLB player. ok is false if no team rosters them.
+func GetRosteredCopy(db *pgxpool.Pool, mlbID int, leagueID string) (playerID, teamID, teamName string, ok bool) {
+	err := db.QueryRow(context.Background(), `
+		SELECT p.id, t.id, t.name
+		FROM players p
+		JOIN teams t ON t.id = p.team_id
+		WHERE p.mlb_id = $1 AND p.league_id = $2
+		LIMIT 1
+	`, mlbID, leagueID).Scan(&playerID, &teamID, &teamName)
+	return playerID, teamID, teamName, err == nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -28,6 +29,14 @@ type ArbitrationPlayer struct {
 	LeagueID      string `json:"league_id"`
 	CurrentStatus string `json:"current_status"` // e.g. "ARB 1"
 	PendingStatus string `json:"pending_status"` // 'PENDING' if a request exists
+
+	// Hearing (see arbitration_hearings)
+	HearingID     string  `json:"hearing_id"`
+	HearingStatus string  `json:"hearing_status"` // 'open', 'filed', 'decided', 'declined'
+	PriorPoints   float64 `json:"prior_points"`
+	PlayerFigure  float64 `json:"player_figure"`
+	TeamFigure    float64 `json:"team_figure"`
+	Awarded       float64 `json:"awarded"`
 }
 
 type PendingAction struct {
@@ -49,13 +58,16 @@ func GetArbitrationEligiblePlayers(db *pgxpool.Pool, teamID string, year int) ([
 
 	query := fmt.Sprintf(`
 		SELECT p.id, p.first_name || ' ' || p.last_name, p.team_id, t.name, p.league_id, p.%s,
-		       COALESCE(pa.status, '')
+		       COALESCE(pa.status, ''),
+		       COALESCE(ah.id::TEXT, ''), COALESCE(ah.status, ''), COALESCE(ah.prior_points, 0),
+		       COALESCE(ah.player_figure, 0), COALESCE(ah.team_figure, 0), COALESCE(ah.awarded_amount, 0)
 		FROM players p
 		JOIN teams t ON p.team_id = t.id
 		LEFT JOIN pending_actions pa ON p.id = pa.player_id 
 		     AND pa.action_type = 'ARBITRATION' 
 		     AND pa.target_year = $2 
 		     AND pa.status = 'PENDING'
+		LEFT JOIN arbitration_hearings ah ON ah.player_id = p.id AND ah.year = $2
 		WHERE p.team_id = $1 AND p.%s ILIKE '%%ARB%%'
 		ORDER BY p.last_name
 	`, contractCol, contractCol)
@@ -69,7 +81,8 @@ func GetArbitrationEligiblePlayers(db *pgxpool.Pool, teamID string, year int) ([
 	var players []ArbitrationPlayer
 	for rows.Next() {
 		var p ArbitrationPlayer
-		if err := rows.Scan(&p.ID, &p.Name, &p.TeamID, &p.TeamName, &p.LeagueID, &p.CurrentStatus, &p.PendingStatus); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.TeamID, &p.TeamName, &p.LeagueID, &p.CurrentStatus, &p.PendingStatus,
+			&p.HearingID, &p.HearingStatus, &p.PriorPoints, &p.PlayerFigure, &p.TeamFigure, &p.Awarded); err != nil {
 			continue
 		}
 		players = append(players, p)
@@ -85,11 +98,22 @@ func SubmitArbitrationDecision(db *pgxpool.Pool, playerID, teamID, leagueID stri
 		if err != nil { return err }
 		defer tx.Rollback(ctx)
 
-		_, err = tx.Exec(ctx, `
-			UPDATE players SET team_id = NULL, fa_status = 'available', status_40_man = FALSE, status_26_man = FALSE
-			WHERE id = $1
-		`, playerID)
-		if err != nil { return err }
+		// The player must still be on the declining team with an undecided hearing
+		var rosterTeamID string
+		err = tx.QueryRow(ctx, `SELECT COALESCE(team_id::TEXT, '') FROM players WHERE id = $1 FOR UPDATE`, playerID).Scan(&rosterTeamID)
+		if err != nil { return fmt.Errorf("player not found") }
+		if rosterTeamID != teamID { return fmt.Errorf("player is not on this team") }
+		var hearingID string
+		err = tx.QueryRow(ctx, `
+			SELECT id FROM arbitration_hearings
+			WHERE player_id = $1 AND team_id = $2 AND year = $3 AND status IN ('open', 'filed')
+			FOR UPDATE
+		`, playerID, teamID, year).Scan(&hearingID)
+		if err != nil { return fmt.Errorf("no open arbitration hearing for this player in %d", year) }
+
+		// Same dead cap rules as a DFA release, charged from the arbitration season on
+		if _, err = ApplyReleaseDeadCap(ctx, tx, playerID, teamID, year, "Arbitration Decline"); err != nil { return err }
+		if err = releaseFromYear(ctx, tx, playerID, year); err != nil { return err }
 
 		_, err = tx.Exec(ctx, `
 			INSERT INTO transactions (team_id, player_id, transaction_type, status)
@@ -97,15 +121,22 @@ func SubmitArbitrationDecision(db *pgxpool.Pool, playerID, teamID, leagueID stri
 		`, teamID, playerID)
 		if err != nil { return err }
 
+		_, err = tx.Exec(ctx, `UPDATE arbitration_hearings SET status = 'declined', decided_at = NOW() WHERE id = $1`, hearingID)
+		if err != nil { return err }
+
 		return tx.Commit(ctx)
 	}
 
-	_, err := db.Exec(ctx, `
-		INSERT INTO pending_actions (player_id, team_id, league_id, action_type, target_year, salary_amount, status)
-		VALUES ($1, $2, $3, 'ARBITRATION', $4, $5, 'PENDING')
-	`, playerID, teamID, leagueID, year, amount)
-
-	return err
+	// File the team's figure against the league-generated player figure
+	result, err := db.Exec(ctx, `
+		UPDATE arbitration_hearings SET team_figure = $1, status = 'filed', filed_at = NOW()
+		WHERE player_id = $2 AND team_id = $3 AND year = $4 AND status IN ('open', 'filed')
+	`, amount, playerID, teamID, year)
+	if err != nil { return err }
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("no open arbitration hearing for this player in %d", year)
+	}
+	return nil
 }
 
 func SubmitExtension(db *pgxpool.Pool, playerID, teamID, leagueID string, salaries map[string]float64) error {
@@ -183,10 +214,7 @@ func ProcessAction(db *pgxpool.Pool, actionID, status string) error {
 			if err != nil { return err }
 		} else if (aType == "EXTENSION" || aType == "REAL_LIFE_EXTENSION") && pID != nil {
 			// Try new format with option_years metadata first
-			var extData struct {
-				Salaries    map[string]float64 `json:"salaries"`
-				OptionYears []int              `json:"option_years"`
-			}
+			var extData ExtensionContract
 			var salaries map[string]float64
 			if err := json.Unmarshal(multiYear, &extData); err == nil && extData.Salaries != nil {
 				salaries = extData.Salaries
@@ -198,9 +226,15 @@ func ProcessAction(db *pgxpool.Pool, actionID, status string) error {
 				contractCol := fmt.Sprintf("contract_%s", yr)
 				_, err = tx.Exec(ctx, fmt.Sprintf("UPDATE players SET %s = $1 WHERE id = $2", contractCol), fmt.Sprintf("%.2f", amt), *pID)
 				if err != nil { return err }
+				// The new deal replaces any year still carrying retained salary from an earlier trade
+				if y, convErr := strconv.Atoi(yr); convErr == nil {
+					if err = voidRetainedSalary(ctx, tx, *pID, y); err != nil { return err }
+				}
 			}
+			// Record option / opt-out clauses (buyouts, player & mutual options)
+			if err = applyExtensionOptions(ctx, tx, *pID, extData); err != nil { return err }
 			// Set contract_option_years if any team options were included
-			if len(extData.OptionYears) > 0 {
+			if len(extData.OptionYears) > 0 && (extData.OptionType == "" || extData.OptionType == "team") {
 				// Merge with existing option years
 				var existingRaw []byte
 				tx.QueryRow(ctx, `SELECT COALESCE(contract_option_years, '[]'::jsonb) FROM players WHERE id = $1`, *pID).Scan(&existingRaw)
@@ -249,4 +283,263 @@ func ProcessAction(db *pgxpool.Pool, actionID, status string) error {
 	if err != nil { return err }
 
 	return tx.Commit(ctx)
-}
\ No newline at end of file
+}
+
+// --- Arbitration Hearings ---
+
+// ArbitrationFormula generates the league's "player" figure for a hearing.
+type ArbitrationFormula struct {
+	BaseSalary      float64 `json:"base_salary"`
+	DollarsPerPoint float64 `json:"dollars_per_point"`
+	Class1          float64 `json:"class1_multiplier"`
+	Class2          float64 `json:"class2_multiplier"`
+	Class3          float64 `json:"class3_multiplier"`
+}
+
+type ArbitrationHearing struct {
+	ID            string    `json:"id"`
+	LeagueID      string    `json:"league_id"`
+	PlayerID      string    `json:"player_id"`
+	PlayerName    string    `json:"player_name"`
+	TeamID        string    `json:"team_id"`
+	TeamName      string    `json:"team_name"`
+	Year          int       `json:"year"`
+	ServiceClass  string    `json:"service_class"`
+	PriorPoints   float64   `json:"prior_points"`
+	PlayerFigure  float64   `json:"player_figure"`
+	TeamFigure    float64   `json:"team_figure"`
+	Ruling        string    `json:"ruling"`
+	AwardedAmount float64   `json:"awarded_amount"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+const arbMinimumSalary = 760000
+
+func GetArbitrationFormula(db *pgxpool.Pool, leagueID string, year int) ArbitrationFormula {
+	f := ArbitrationFormula{BaseSalary: arbMinimumSalary, DollarsPerPoint: 10000, Class1: 0.40, Class2: 0.60, Class3: 0.80}
+	db.QueryRow(context.Background(), `
+		SELECT COALESCE(arb_base_salary, 760000), COALESCE(arb_dollars_per_point, 10000),
+		       COALESCE(arb_class1_multiplier, 0.40), COALESCE(arb_class2_multiplier, 0.60), COALESCE(arb_class3_multiplier, 0.80)
+		FROM league_settings WHERE league_id = $1 AND year = $2
+	`, leagueID, year).Scan(&f.BaseSalary, &f.DollarsPerPoint, &f.Class1, &f.Class2, &f.Class3)
+	return f
+}
+
+func UpsertArbitrationFormula(db *pgxpool.Pool, leagueID string, year int, f ArbitrationFormula) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, arb_base_salary, arb_dollars_per_point,
+			arb_class1_multiplier, arb_class2_multiplier, arb_class3_multiplier)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (league_id, year) DO UPDATE SET
+			arb_base_salary = EXCLUDED.arb_base_salary,
+			arb_dollars_per_point = EXCLUDED.arb_dollars_per_point,
+			arb_class1_multiplier = EXCLUDED.arb_class1_multiplier,
+			arb_class2_multiplier = EXCLUDED.arb_class2_multiplier,
+			arb_class3_multiplier = EXCLUDED.arb_class3_multiplier
+	`, leagueID, year, f.BaseSalary, f.DollarsPerPoint, f.Class1, f.Class2, f.Class3)
+	return err
+}
+
+// PlayerFigure applies the formula: base + prior points * $/point * class multiplier, never below the minimum.
+func (f ArbitrationFormula) PlayerFigure(serviceClass string, priorPoints float64) float64 {
+	mult := f.Class1
+	switch strings.ToUpper(strings.TrimSpace(serviceClass)) {
+	case "ARB 2":
+		mult = f.Class2
+	case "ARB 3":
+		mult = f.Class3
+	}
+	figure := f.BaseSalary + priorPoints*f.DollarsPerPoint*mult
+	if figure < arbMinimumSalary {
+		figure = arbMinimumSalary
+	}
+	// Round to the nearest $10K like every other salary in the league
+	return float64(int64(figure/10000+0.5)) * 10000
+}
+
+// EnsureArbitrationHearings opens a hearing (with the league's player figure) for every
+// arbitration-eligible player in a league that doesn't have one for the year yet.
+// Returns the number of hearings opened.
+func EnsureArbitrationHearings(db *pgxpool.Pool, leagueID string, year int) (int, error) {
+	rows, err := db.Query(context.Background(), `SELECT id FROM teams WHERE league_id = $1`, leagueID)
+	if err != nil {
+		return 0, err
+	}
+	var teamIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err == nil {
+			teamIDs = append(teamIDs, id)
+		}
+	}
+	rows.Close()
+
+	opened := 0
+	for _, teamID := range teamIDs {
+		n, err := ensureTeamArbitrationHearings(db, teamID, year)
+		if err != nil {
+			return opened, err
+		}
+		opened += n
+	}
+	return opened, nil
+}
+
+func ensureTeamArbitrationHearings(db *pgxpool.Pool, teamID string, year int) (int, error) {
+	players, err := GetArbitrationEligiblePlayers(db, teamID, year)
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []ArbitrationPlayer
+	var ids []string
+	for _, p := range players {
+		if p.HearingID == "" {
+			missing = append(missing, p)
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	points, _ := GetPlayerPointsSummary(db, ids, fmt.Sprintf("%d-01-01", year-1), fmt.Sprintf("%d-12-31", year-1))
+	formula := GetArbitrationFormula(db, missing[0].LeagueID, year)
+
+	for _, p := range missing {
+		pts := points[p.ID]
+		_, err := db.Exec(context.Background(), `
+			INSERT INTO arbitration_hearings (league_id, player_id, team_id, year, service_class, prior_points, player_figure)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (player_id, year) DO NOTHING
+		`, p.LeagueID, p.ID, p.TeamID, year, strings.ToUpper(strings.TrimSpace(p.CurrentStatus)), pts, formula.PlayerFigure(p.CurrentStatus, pts))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(missing), nil
+}
+
+// GetArbitrationHearingLeagueID returns the league a hearing belongs to.
+func GetArbitrationHearingLeagueID(db *pgxpool.Pool, hearingID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `SELECT league_id FROM arbitration_hearings WHERE id = $1`, hearingID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// GetArbitrationHearings lists a league's hearings for a year, optionally filtered by status.
+func GetArbitrationHearings(db *pgxpool.Pool, leagueID string, year int, status string) ([]ArbitrationHearing, error) {
+	query := `
+		SELECT ah.id, ah.league_id, ah.player_id, p.first_name || ' ' || p.last_name, COALESCE(ah.team_id::TEXT, ''),
+		       COALESCE(t.name, ''), ah.year, ah.service_class, COALESCE(ah.prior_points, 0), ah.player_figure,
+		       COALESCE(ah.team_figure, 0), COALESCE(ah.ruling, ''), COALESCE(ah.awarded_amount, 0), ah.status, ah.created_at
+		FROM arbitration_hearings ah
+		JOIN players p ON ah.player_id = p.id
+		LEFT JOIN teams t ON ah.team_id = t.id
+		WHERE ah.league_id = $1 AND ah.year = $2
+	`
+	args := []interface{}{leagueID, year}
+	if status != "" {
+		query += " AND ah.status = $3"
+		args = append(args, status)
+	}
+	query += " ORDER BY t.name, p.last_name"
+
+	rows, err := db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hearings []ArbitrationHearing
+	for rows.Next() {
+		var h ArbitrationHearing
+		if err := rows.Scan(&h.ID, &h.LeagueID, &h.PlayerID, &h.PlayerName, &h.TeamID, &h.TeamName, &h.Year,
+			&h.ServiceClass, &h.PriorPoints, &h.PlayerFigure, &h.TeamFigure, &h.Ruling, &h.AwardedAmount,
+			&h.Status, &h.CreatedAt); err != nil {
+			continue
+		}
+		hearings = append(hearings, h)
+	}
+	return hearings, nil
+}
+
+// RuleArbitrationHearing settles a hearing for the player's figure, the team's figure, or the
+// midpoint, and writes the award into the player's contract year.
+func RuleArbitrationHearing(db *pgxpool.Pool, hearingID, ruling, userID string) (string, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil { return "", err }
+	defer tx.Rollback(ctx)
+
+	var h ArbitrationHearing
+	err = tx.QueryRow(ctx, `
+		SELECT ah.player_id, p.first_name || ' ' || p.last_name, ah.league_id, COALESCE(ah.team_id::TEXT, ''), COALESCE(t.name, ''),
+		       ah.year, ah.player_figure, COALESCE(ah.team_figure, 0), ah.status
+		FROM arbitration_hearings ah
+		JOIN players p ON ah.player_id = p.id
+		LEFT JOIN teams t ON ah.team_id = t.id
+		WHERE ah.id = $1
+	`, hearingID).Scan(&h.PlayerID, &h.PlayerName, &h.LeagueID, &h.TeamID, &h.TeamName, &h.Year, &h.PlayerFigure, &h.TeamFigure, &h.Status)
+	if err != nil { return "", err }
+	if h.Status != "filed" && h.Status != "open" {
+		return "", fmt.Errorf("hearing is already %s", h.Status)
+	}
+
+	var award float64
+	switch ruling {
+	case "player":
+		award = h.PlayerFigure
+	case "team":
+		if h.Status != "filed" { return "", fmt.Errorf("the team has not filed a figure") }
+		award = h.TeamFigure
+	case "midpoint":
+		if h.Status != "filed" { return "", fmt.Errorf("the team has not filed a figure") }
+		// Split the difference, rounded to the nearest $10K
+		award = float64(int64((h.PlayerFigure+h.TeamFigure)/2/10000+0.5)) * 10000
+	default:
+		return "", fmt.Errorf("invalid ruling %q", ruling)
+	}
+
+	var decidedBy *string
+	if userID != "" { decidedBy = &userID }
+	_, err = tx.Exec(ctx, `
+		UPDATE arbitration_hearings SET ruling = $1, awarded_amount = $2, status = 'decided', decided_by = $3, decided_at = NOW()
+		WHERE id = $4
+	`, ruling, award, decidedBy, hearingID)
+	if err != nil { return "", err }
+
+	contractCol := fmt.Sprintf("contract_%d", h.Year)
+	_, err = tx.Exec(ctx, fmt.Sprintf("UPDATE players SET %s = $1 WHERE id = $2", contractCol), fmt.Sprintf("%.2f", award), h.PlayerID)
+	if err != nil { return "", err }
+
+	sides := map[string]string{"player": "the player's figure", "team": "the team's figure", "midpoint": "the midpoint"}
+	summary := fmt.Sprintf("Arbitration: %s (%s) awarded $%.0f for %d — ruled for %s (player $%.0f / team $%.0f)",
+		h.PlayerName, h.TeamName, award, h.Year, sides[ruling], h.PlayerFigure, h.TeamFigure)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (league_id, team_id, player_id, transaction_type, summary, status)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, 'Roster Move', $4, 'COMPLETED')
+	`, h.LeagueID, h.TeamID, h.PlayerID, summary)
+	if err != nil { return "", err }
+
+	return summary, tx.Commit(ctx)
+}
+
+// AwardUnfiledHearings rules for the player's figure on every hearing where the team
+// missed the filing deadline. Returns the number of hearings decided.
+func AwardUnfiledHearings(db *pgxpool.Pool, leagueID string, year int) (int, error) {
+	hearings, err := GetArbitrationHearings(db, leagueID, year, "open")
+	if err != nil {
+		return 0, err
+	}
+	decided := 0
+	for _, h := range hearings {
+		if _, err := RuleArbitrationHearing(db, h.ID, "player", ""); err != nil {
+			fmt.Printf("ERROR [AwardUnfiledHearings] %s: %v\n", h.PlayerName, err)
+			continue
+		}
+		decided++
+	}
+	return decided, nil
+}
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Balance Ledger ---
+
+// BalanceEntry is one posting against a team's ISBP or MiLB balance.
+type BalanceEntry struct {
+	ID               string    `json:"id"`
+	TeamID           string    `json:"team_id"`
+	TeamName         string    `json:"team_name"`
+	BalanceType      string    `json:"balance_type"` // 'isbp', 'milb'
+	Amount           float64   `json:"amount"`
+	BalanceAfter     float64   `json:"balance_after"`
+	EntryType        string    `json:"entry_type"`
+	ReferenceType    string    `json:"reference_type"`
+	ReferenceID      string    `json:"reference_id"`
+	CounterpartyID   string    `json:"counterparty_team_id"`
+	CounterpartyName string    `json:"counterparty_name"`
+	Description      string    `json:"description"`
+	CreatedBy        string    `json:"created_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// BalanceDrift flags a team whose stored balance no longer matches its ledger.
+type BalanceDrift struct {
+	TeamID      string  `json:"team_id"`
+	TeamName    string  `json:"team_name"`
+	BalanceType string  `json:"balance_type"`
+	Stored      float64 `json:"stored"`
+	Ledger      float64 `json:"ledger"`
+	Drift       float64 `json:"drift"`
+}
+
+func balanceColumn(balanceType string) (string, error) {
+	switch balanceType {
+	case "isbp":
+		return "isbp_balance", nil
+	case "milb":
+		return "milb_balance", nil
+	}
+	return "", fmt.Errorf("unknown balance type %q", balanceType)
+}
+
+// PostBalanceEntry applies e.Amount to the team's balance and records it in the ledger
+// with the resulting running balance. Returns the new balance.
+func PostBalanceEntry(ctx context.Context, tx pgx.Tx, e BalanceEntry) (float64, error) {
+	col, err := balanceColumn(e.BalanceType)
+	if err != nil {
+		return 0, err
+	}
+	if e.CreatedBy == "" {
+		e.CreatedBy = "system"
+	}
+
+	var leagueID string
+	var after float64
+	err = tx.QueryRow(ctx, fmt.Sprintf(`
+		UPDATE teams SET %s = COALESCE(%s, 0) + $1 WHERE id = $2
+		RETURNING league_id, %s
+	`, col, col, col), e.Amount, e.TeamID).Scan(&leagueID, &after)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO balance_ledger (team_id, league_id, balance_type, amount, balance_after, entry_type,
+		                            reference_type, reference_id, counterparty_team_id, description, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, '')::uuid, $10, $11)
+	`, e.TeamID, leagueID, e.BalanceType, e.Amount, after, e.EntryType,
+		e.ReferenceType, e.ReferenceID, e.CounterpartyID, e.Description, e.CreatedBy)
+	return after, err
+}
+
+// SyncTeamBalance sets one of a team's balances to an externally supplied value (e.g. the
+// WordPress site settings), posting the difference to the ledger as a 'sync' entry.
+func SyncTeamBalance(db *pgxpool.Pool, teamID, balanceType string, balance float64, note string) error {
+	col, err := balanceColumn(balanceType)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var current float64
+	err = tx.QueryRow(ctx, fmt.Sprintf(`SELECT COALESCE(%s, 0) FROM teams WHERE id = $1 FOR UPDATE`, col), teamID).Scan(&current)
+	if err != nil {
+		return err
+	}
+	if delta := balance - current; delta != 0 {
+		_, err = PostBalanceEntry(ctx, tx, BalanceEntry{TeamID: teamID, BalanceType: balanceType, Amount: delta,
+			EntryType: "sync", Description: note, CreatedBy: "sync"})
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// TransferBalance moves amount from one team to another as a matching debit and credit.
+func TransferBalance(ctx context.Context, tx pgx.Tx, fromTeamID, toTeamID, balanceType string, amount float64, entryType, refType, refID, description string) error {
+	if amount == 0 {
+		return nil
+	}
+	debit := BalanceEntry{TeamID: fromTeamID, BalanceType: balanceType, Amount: -amount, EntryType: entryType,
+		ReferenceType: refType, ReferenceID: refID, CounterpartyID: toTeamID, Description: description}
+	if _, err := PostBalanceEntry(ctx, tx, debit); err != nil {
+		return err
+	}
+	credit := debit
+	credit.TeamID, credit.CounterpartyID, credit.Amount = toTeamID, fromTeamID, amount
+	_, err := PostBalanceEntry(ctx, tx, credit)
+	return err
+}
+
+// AllocateLeagueBalance credits every team in the league (e.g. the annual ISBP allocation).
+// Returns the number of teams credited.
+func AllocateLeagueBalance(db *pgxpool.Pool, leagueID, balanceType string, amount float64, description, createdBy string) (int, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id FROM teams WHERE league_id = $1`, leagueID)
+	if err != nil {
+		return 0, err
+	}
+	var teamIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err == nil {
+			teamIDs = append(teamIDs, id)
+		}
+	}
+	rows.Close()
+
+	for _, teamID := range teamIDs {
+		_, err = PostBalanceEntry(ctx, tx, BalanceEntry{TeamID: teamID, BalanceType: balanceType, Amount: amount,
+			EntryType: "allocation", Description: description, CreatedBy: createdBy})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(teamIDs), tx.Commit(ctx)
+}
+
+// GetBalanceLedger returns a team's ledger, newest first. An empty balanceType returns both balances.
+func GetBalanceLedger(db *pgxpool.Pool, teamID, balanceType string, limit int) ([]BalanceEntry, error) {
+	return queryBalanceLedger(db, `bl.team_id = $1`, teamID, balanceType, limit)
+}
+
+// GetLeagueBalanceLedger returns recent ledger entries for every team in a league, newest first.
+func GetLeagueBalanceLedger(db *pgxpool.Pool, leagueID, balanceType string, limit int) ([]BalanceEntry, error) {
+	return queryBalanceLedger(db, `bl.league_id = $1`, leagueID, balanceType, limit)
+}
+
+func queryBalanceLedger(db *pgxpool.Pool, where, id, balanceType string, limit int) ([]BalanceEntry, error) {
+	if limit <= 0 {
+		limit = 200
+	}
+	rows, err := db.Query(context.Background(), fmt.Sprintf(`
+		SELECT bl.id, bl.team_id, COALESCE(t.name, ''), bl.balance_type, bl.amount, bl.balance_after, bl.entry_type,
+		       COALESCE(bl.reference_type, ''), COALESCE(bl.reference_id, ''),
+		       COALESCE(bl.counterparty_team_id::text, ''), COALESCE(ct.name, ''),
+		       COALESCE(bl.description, ''), COALESCE(bl.created_by, ''), bl.created_at
+		FROM balance_ledger bl
+		LEFT JOIN teams t ON bl.team_id = t.id
+		LEFT JOIN teams ct ON bl.counterparty_team_id = ct.id
+		WHERE %s AND ($2 = '' OR bl.balance_type = $2)
+		ORDER BY bl.created_at DESC
+		LIMIT $3
+	`, where), id, balanceType, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []BalanceEntry
+	for rows.Next() {
+		var e BalanceEntry
+		if err := rows.Scan(&e.ID, &e.TeamID, &e.TeamName, &e.BalanceType, &e.Amount, &e.BalanceAfter, &e.EntryType,
+			&e.ReferenceType, &e.ReferenceID, &e.CounterpartyID, &e.CounterpartyName,
+			&e.Description, &e.CreatedBy, &e.CreatedAt); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// ReconcileBalances compares each team's stored balances with the sum of its ledger entries.
+// An empty leagueID checks every league. Only teams that have drifted are returned.
+func ReconcileBalances(db *pgxpool.Pool, leagueID string) ([]BalanceDrift, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT t.id, t.name, bt.balance_type,
+		       CASE bt.balance_type WHEN 'isbp' THEN COALESCE(t.isbp_balance, 0) ELSE COALESCE(t.milb_balance, 0) END,
+		       COALESCE((SELECT SUM(bl.amount) FROM balance_ledger bl
+		                 WHERE bl.team_id = t.id AND bl.balance_type = bt.balance_type), 0)
+		FROM teams t
+		CROSS JOIN (VALUES ('isbp'), ('milb')) AS bt(balance_type)
+		WHERE ($1 = '' OR t.league_id::text = $1)
+		ORDER BY t.name, bt.balance_type
+	`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drifts []BalanceDrift
+	for rows.Next() {
+		var d BalanceDrift
+		if err := rows.Scan(&d.TeamID, &d.TeamName, &d.BalanceType, &d.Stored, &d.Ledger); err != nil {
+			continue
+		}
+		d.Drift = d.Stored - d.Ledger
+		if math.Abs(d.Drift) >= 0.01 {
+			drifts = append(drifts, d)
+		}
+	}
+	return drifts, nil
+}
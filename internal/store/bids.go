@@ -22,11 +22,12 @@ type BidRecord struct {
 }
 
 type bidHistoryEntry struct {
-	TeamID    string  `json:"history_team_id"`
-	Amount    float64 `json:"history_bid_amount"`
-	Years     int     `json:"history_bid_years"`
-	AAV       float64 `json:"history_bid_aav"`
-	Timestamp string  `json:"history_timestamp"`
+	TeamID    string    `json:"history_team_id"`
+	Amount    float64   `json:"history_bid_amount"`
+	Years     int       `json:"history_bid_years"`
+	AAV       float64   `json:"history_bid_aav"`
+	Schedule  []float64 `json:"history_bid_schedule,omitempty"`
+	Timestamp string    `json:"history_timestamp"`
 }
 
 func GetBidHistory(db *pgxpool.Pool, leagueID, teamID string) ([]BidRecord, error) {
@@ -95,12 +96,13 @@ func GetBidHistory(db *pgxpool.Pool, leagueID, teamID string) ([]BidRecord, erro
 }
 
 // AppendBidHistory appends a bid entry to a player's bid_history JSONB column.
-func AppendBidHistory(db *pgxpool.Pool, playerID, teamID string, bidPoints float64, years int, aav float64) {
+func AppendBidHistory(db *pgxpool.Pool, playerID, teamID string, bidPoints float64, years int, aav float64, schedule []float64) {
 	entry := bidHistoryEntry{
 		TeamID:    teamID,
 		Amount:    bidPoints,
 		Years:     years,
 		AAV:       aav,
+		Schedule:  schedule,
 		Timestamp: time.Now().Format("2006-01-02T15:04:05"),
 	}
 	entryJSON, err := json.Marshal([]bidHistoryEntry{entry})
@@ -3,8 +3,6 @@ package store
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -19,20 +17,31 @@ type OptionPlayer struct {
 	SalaryText string  `json:"salary_text"`
 	Salary     float64 `json:"salary"`
 	Buyout     float64 `json:"buyout"`
+	OptionType string  `json:"option_type"` // 'team' or 'mutual'
+
+	// Decision window, filled in by the team options page
+	Deadline      string `json:"deadline,omitempty"`
+	DefaultAction string `json:"default_action,omitempty"`
 }
 
 func GetPlayersWithOptions(db *pgxpool.Pool, teamID string, year int) ([]OptionPlayer, error) {
 	col := fmt.Sprintf("contract_%d", year)
+	// Legacy "(TO)" contracts with no option row for the year (an explicit row, e.g. a player
+	// option, takes precedence), plus undecided team/mutual options from contract_options
 	query := fmt.Sprintf(`
-		SELECT p.id, p.first_name || ' ' || p.last_name, p.team_id, t.name, p.league_id, %s
+		SELECT p.id, p.first_name || ' ' || p.last_name, p.team_id, t.name, p.league_id, COALESCE(p.%s, ''),
+		       COALESCE(co.option_type, 'team'), COALESCE(co.buyout, 0)
 		FROM players p
 		JOIN teams t ON p.team_id = t.id
-		WHERE %s LIKE '%%(TO)%%'
+		LEFT JOIN contract_options co ON co.player_id = p.id AND co.year = $1 AND co.option_type IN ('team', 'mutual')
+		WHERE ((co.id IS NULL AND p.%s LIKE '%%(TO)%%'
+		        AND NOT EXISTS (SELECT 1 FROM contract_options x WHERE x.player_id = p.id AND x.year = $1))
+		   OR (co.status = 'pending' AND COALESCE(co.team_decision, '') = ''))
 	`, col, col)
 
-	var args []interface{}
+	args := []interface{}{year}
 	if teamID != "" {
-		query += " AND p.team_id = $1"
+		query += " AND p.team_id = $2"
 		args = append(args, teamID)
 	}
 
@@ -46,14 +55,15 @@ func GetPlayersWithOptions(db *pgxpool.Pool, teamID string, year int) ([]OptionP
 	for rows.Next() {
 		var p OptionPlayer
 		p.Year = year
-		if err := rows.Scan(&p.ID, &p.Name, &p.TeamID, &p.TeamName, &p.LeagueID, &p.SalaryText); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.TeamID, &p.TeamName, &p.LeagueID, &p.SalaryText, &p.OptionType, &p.Buyout); err != nil {
 			continue
 		}
 
-		// Parse Salary
-		clean := strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(p.SalaryText, "(TO)", ""), "$", ""), ",", "")
-		p.Salary, _ = strconv.ParseFloat(strings.TrimSpace(clean), 64)
-		p.Buyout = p.Salary * 0.30
+		// Parse Salary; contracts without a negotiated buyout default to 30%
+		p.Salary = parseContractAmount(p.SalaryText)
+		if p.Buyout <= 0 {
+			p.Buyout = p.Salary * 0.30
+		}
 
 		players = append(players, p)
 	}
@@ -67,58 +77,59 @@ func ProcessOptionDecision(db *pgxpool.Pool, playerID string, year int, action s
 	defer tx.Rollback(ctx)
 
 	// Get Current Data
-	var pName, teamID, leagueID, salaryText string
+	var pName, teamID, teamName, leagueID, salaryText string
 	col := fmt.Sprintf("contract_%d", year)
-	err = tx.QueryRow(ctx, fmt.Sprintf("SELECT first_name || ' ' || last_name, team_id, league_id, %s FROM players WHERE id = $1", col), playerID).Scan(&pName, &teamID, &leagueID, &salaryText)
+	err = tx.QueryRow(ctx, fmt.Sprintf(`
+		SELECT p.first_name || ' ' || p.last_name, p.team_id, t.name, p.league_id, COALESCE(p.%s, '')
+		FROM players p JOIN teams t ON p.team_id = t.id
+		WHERE p.id = $1`, col), playerID).Scan(&pName, &teamID, &teamName, &leagueID, &salaryText)
 	if err != nil { return err }
 
-	clean := strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(salaryText, "(TO)", ""), "$", ""), ",", "")
-	salary, _ := strconv.ParseFloat(strings.TrimSpace(clean), 64)
+	salary := parseContractAmount(salaryText)
+
+	// Structured option (extensions / commissioner-entered); legacy (TO) contracts have no row
+	o := ContractOption{PlayerID: playerID, PlayerName: pName, TeamID: teamID, LeagueID: leagueID, Year: year, OptionType: "team"}
+	tx.QueryRow(ctx, `
+		SELECT id, option_type, COALESCE(buyout, 0), COALESCE(player_decision, '')
+		FROM contract_options
+		WHERE player_id = $1 AND year = $2 AND option_type IN ('team', 'mutual') AND status = 'pending'
+	`, playerID, year).Scan(&o.ID, &o.OptionType, &o.Buyout, &o.PlayerDecision)
+
+	if o.ID != "" {
+		decision := "decline"
+		if action == "exercise" { decision = "exercise" }
+		_, err = tx.Exec(ctx, `UPDATE contract_options SET team_decision = $1 WHERE id = $2`, decision, o.ID)
+		if err != nil { return err }
+	}
 
-	var summary string
+	var summary, transType string
 	if action == "exercise" {
-		// Just strip the (TO)
-		newVal := fmt.Sprintf("%d", int64(salary))
-		_, err = tx.Exec(ctx, fmt.Sprintf("UPDATE players SET %s = $1 WHERE id = $2", col), newVal, playerID)
-		summary = fmt.Sprintf("%s EXERCISED the %d Team Option for %s ($%s).", teamID, year, pName, newVal)
+		transType = "Roster Move"
+		if o.OptionType == "mutual" && o.PlayerDecision != "in" {
+			// Mutual option: wait for the player's side
+			summary = fmt.Sprintf("%s exercised the team side of the %d Mutual Option for %s; awaiting the player's decision.", teamName, year, pName)
+		} else {
+			err = exerciseOption(ctx, tx, o, "team")
+			summary = fmt.Sprintf("%s EXERCISED the %d Team Option for %s ($%.0f).", teamName, year, pName, salary)
+		}
 	} else {
-		// Decline: 30% buyout
-		buyout := salary * 0.30
-		buyoutVal := fmt.Sprintf("%d", int64(buyout))
-		
-		// 1. Current year becomes buyout (as Dead Cap)
-		// Note: We'll set the contract value to the buyout, then drop the player.
-		// The legacy site seems to keep the buyout in the contract field for the remainder of the year.
-		
-		// 2. Clear all years
-		updateQuery := fmt.Sprintf(`
-			UPDATE players SET 
-				%s = $1,
-				contract_2026 = CASE WHEN %d = 2026 THEN $1 ELSE '' END,
-				contract_2027 = CASE WHEN %d = 2027 THEN $1 ELSE '' END,
-				contract_2028 = CASE WHEN %d = 2028 THEN $1 ELSE '' END,
-				contract_2029 = CASE WHEN %d = 2029 THEN $1 ELSE '' END,
-				contract_2030 = CASE WHEN %d = 2030 THEN $1 ELSE '' END,
-				contract_2031 = '', contract_2032 = '', contract_2033 = '', contract_2034 = '', contract_2035 = '',
-				contract_2036 = '', contract_2037 = '', contract_2038 = '', contract_2039 = '', contract_2040 = '',
-				team_id = NULL,
-				status_40_man = false,
-				status_26_man = false,
-				fa_status = 'available'
-			WHERE id = $2
-		`, col, year, year, year, year, year)
-		
-		_, err = tx.Exec(ctx, updateQuery, buyoutVal, playerID)
-		summary = fmt.Sprintf("%s DECLINED the %d Team Option for %s. Buyout: $%s. Player is now a Free Agent.", teamID, year, pName, buyoutVal)
+		transType = "Dropped Player"
+		buyout := declinedOptionBuyout(o.Buyout, salary)
+		// Buyout is charged to the team as dead cap for the option year
+		err = declineOption(ctx, tx, o, buyout, "team")
+		label := "Team"
+		if o.OptionType == "mutual" { label = "Mutual" }
+		summary = fmt.Sprintf("%s DECLINED the %d %s Option for %s. Buyout: $%.0f (dead cap). Player is now a Free Agent.",
+			teamName, year, label, pName, buyout)
 	}
 
 	if err != nil { return err }
 
 	// Log Activity
 	_, err = tx.Exec(ctx, `
-		INSERT INTO activity_log (league_id, primary_team_id, transaction_type, summary)
-		VALUES ($1, $2, 'Team Option', $3)
-	`, leagueID, teamID, summary)
+		INSERT INTO transactions (league_id, team_id, player_id, transaction_type, summary, status)
+		VALUES ($1, $2, $3, $4, $5, 'COMPLETED')
+	`, leagueID, teamID, playerID, transType, summary)
 
 	if err != nil { return err }
 
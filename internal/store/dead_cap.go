@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Dead Cap Rules ---
+// Every release-type move (DFA that clears waivers, arbitration decline, commissioner release)
+// charges dead cap through ReleaseDeadCapSchedule so previews and the real charge always agree.
+
+// DFADeadCapPct is the share of a released player's salary that stays on the team's books as
+// dead cap for a contract year: 75% in the season of the release, 50% for every later year.
+func DFADeadCapPct(year, releaseYear int) float64 {
+	if year == releaseYear {
+		return 0.75
+	}
+	return 0.50
+}
+
+// ReleaseDeadCapSchedule returns the dead cap owed per contract year when a player with the given
+// salaries is released in releaseYear. Years before the release and non-dollar years are skipped
+// (callers leave out unexercised team option years).
+func ReleaseDeadCapSchedule(salaries map[int]float64, releaseYear int) map[int]float64 {
+	schedule := make(map[int]float64)
+	for y := releaseYear; y <= 2040; y++ {
+		if sal := salaries[y]; sal > 0 {
+			schedule[y] = sal * DFADeadCapPct(y, releaseYear)
+		}
+	}
+	return schedule
+}
+
+type DeadCapYear struct {
+	Year           int     `json:"year"`
+	Salary         float64 `json:"salary"`
+	Pct            float64 `json:"pct"`
+	DeadCap        float64 `json:"dead_cap"`
+	PayrollBefore  float64 `json:"payroll_before"`
+	PayrollAfter   float64 `json:"payroll_after"`
+	LuxuryTaxLimit float64 `json:"luxury_tax_limit"`
+	TaxSpaceAfter  float64 `json:"tax_space_after"`
+}
+
+// DeadCapPreview is what a release-type move will cost the team, shown before the owner confirms.
+type DeadCapPreview struct {
+	PlayerID      string        `json:"player_id"`
+	PlayerName    string        `json:"player_name"`
+	TeamID        string        `json:"team_id"`
+	Action        string        `json:"action"`
+	ReleaseYear   int           `json:"release_year"`
+	Applies       bool          `json:"applies"` // false when the move can't end in a release (waive, DFA to minors)
+	Message       string        `json:"message"`
+	Years         []DeadCapYear `json:"years"`
+	TotalDeadCap  float64       `json:"total_dead_cap"`
+	SalaryCleared float64       `json:"salary_cleared"`
+}
+
+// PreviewReleaseDeadCap builds the dead-cap schedule and post-release payroll for a move.
+// action is 'dfa' (released if unclaimed), 'dfa_minors', 'waive', 'arb_decline' or 'release'.
+func PreviewReleaseDeadCap(db *pgxpool.Pool, playerID, action string, releaseYear int) (*DeadCapPreview, error) {
+	line, err := getContractLine(db, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if line.TeamID == "" {
+		return nil, fmt.Errorf("player is not on a team")
+	}
+
+	p := &DeadCapPreview{
+		PlayerID:    line.PlayerID,
+		PlayerName:  line.PlayerName,
+		TeamID:      line.TeamID,
+		Action:      action,
+		ReleaseYear: releaseYear,
+		Years:       []DeadCapYear{},
+	}
+
+	switch action {
+	case "waive", "dfa_minors":
+		p.Message = fmt.Sprintf("No dead cap. If %s clears waivers the player stays with the team; if claimed, the contract goes to the claiming team.", line.PlayerName)
+		return p, nil
+	case "dfa":
+		p.Message = fmt.Sprintf("Charged only if %s clears waivers unclaimed. A claiming team takes on the full contract.", line.PlayerName)
+	case "arb_decline":
+		p.Message = fmt.Sprintf("Declining arbitration releases %s before the %d season.", line.PlayerName, releaseYear)
+	default:
+		p.Message = fmt.Sprintf("%s is released to free agency immediately.", line.PlayerName)
+	}
+	p.Applies = true
+
+	var leagueID string
+	db.QueryRow(context.Background(), `SELECT league_id FROM teams WHERE id = $1`, line.TeamID).Scan(&leagueID)
+
+	salaries, err := releaseSalaries(context.Background(), db, playerID)
+	if err != nil {
+		return nil, err
+	}
+	schedule := ReleaseDeadCapSchedule(salaries, releaseYear)
+	for y := releaseYear; y <= 2040; y++ {
+		sal := salaries[y]
+		if sal <= 0 {
+			continue
+		}
+		summary := CalculateYearlySummary(db, line.TeamID, leagueID, y)
+		dy := DeadCapYear{
+			Year:           y,
+			Salary:         sal,
+			Pct:            DFADeadCapPct(y, releaseYear) * 100,
+			DeadCap:        schedule[y],
+			PayrollBefore:  summary.TotalPayroll,
+			PayrollAfter:   summary.TotalPayroll - sal + schedule[y],
+			LuxuryTaxLimit: summary.LuxuryTaxLimit,
+		}
+		if dy.LuxuryTaxLimit > 0 {
+			dy.TaxSpaceAfter = dy.LuxuryTaxLimit - dy.PayrollAfter
+		}
+		p.Years = append(p.Years, dy)
+		p.TotalDeadCap += dy.DeadCap
+		p.SalaryCleared += sal
+	}
+	return p, nil
+}
+
+// releaseSalaries reads the contract years a release charges dead cap on. Unexercised team
+// option years ("(TO)") are left out: releasing the player simply declines the option.
+func releaseSalaries(ctx context.Context, q interface {
+	QueryRow(context.Context, string, ...any) pgx.Row
+}, playerID string) (map[int]float64, error) {
+	var cols []string
+	for y := 2026; y <= 2040; y++ {
+		cols = append(cols, fmt.Sprintf("COALESCE(contract_%d, '')", y))
+	}
+	contracts := make([]string, 15)
+	dest := make([]interface{}, len(contracts))
+	for i := range contracts {
+		dest[i] = &contracts[i]
+	}
+	if err := q.QueryRow(ctx, "SELECT "+strings.Join(cols, ", ")+" FROM players WHERE id = $1", playerID).Scan(dest...); err != nil {
+		return nil, err
+	}
+	salaries := make(map[int]float64)
+	for i, raw := range contracts {
+		if strings.Contains(strings.ToUpper(raw), "(TO)") {
+			continue
+		}
+		salaries[2026+i] = parseContractAmount(raw)
+	}
+	return salaries, nil
+}
+
+// ApplyReleaseDeadCap charges the team dead cap for releasing a player in releaseYear. label names
+// the move in the penalty note (e.g. "DFA Release"). Call before the contract is cleared.
+// The contract only carries this team's share; salary a former team retained in a trade stays
+// on that team's books via retained_salaries.
+func ApplyReleaseDeadCap(ctx context.Context, tx pgx.Tx, playerID, teamID string, releaseYear int, label string) (float64, error) {
+	salaries, err := releaseSalaries(ctx, tx, playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for y, amount := range ReleaseDeadCapSchedule(salaries, releaseYear) {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO dead_cap_penalties (team_id, player_id, amount, year, note)
+			VALUES ($1, $2, $3, $4, $5)
+		`, teamID, playerID, amount, y, fmt.Sprintf("%s (%.0f%%)", label, DFADeadCapPct(y, releaseYear)*100))
+		if err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+// ReleasePlayerWithDeadCap is the commissioner release: charges dead cap for releaseYear on,
+// clears the contract from that year and returns the player to free agency.
+func ReleasePlayerWithDeadCap(db *pgxpool.Pool, playerID string, releaseYear int, label string) (float64, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var teamID string
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(team_id::TEXT, '') FROM players WHERE id = $1`, playerID).Scan(&teamID); err != nil {
+		return 0, err
+	}
+	if teamID == "" {
+		return 0, fmt.Errorf("player is not on a team")
+	}
+
+	total, err := ApplyReleaseDeadCap(ctx, tx, playerID, teamID, releaseYear, label)
+	if err != nil {
+		return 0, err
+	}
+	if err := releaseFromYear(ctx, tx, playerID, releaseYear); err != nil {
+		return 0, err
+	}
+	return total, tx.Commit(ctx)
+}
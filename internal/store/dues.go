@@ -0,0 +1,339 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- IRL Dues ---
+
+// DuesInvoice is one team's real-life dues for a season. Amounts are real-life USD.
+type DuesInvoice struct {
+	ID             string         `json:"id"`
+	LeagueID       string         `json:"league_id"`
+	TeamID         string         `json:"team_id"`
+	TeamName       string         `json:"team_name"`
+	OwnerName      string         `json:"owner_name"`
+	Year           int            `json:"year"`
+	DueDate        *time.Time     `json:"due_date"`
+	Notes          string         `json:"notes"`
+	LastRemindedAt *time.Time     `json:"last_reminded_at"`
+	ReminderCount  int            `json:"reminder_count"`
+	Total          float64        `json:"total"`
+	Paid           float64        `json:"paid"`
+	Outstanding    float64        `json:"outstanding"`
+	Status         string         `json:"status"` // 'open', 'partial', 'paid', 'overdue'
+	LineItems      []DuesLineItem `json:"line_items"`
+	Payments       []DuesPayment  `json:"payments"`
+}
+
+type DuesLineItem struct {
+	ID          string    `json:"id"`
+	ItemType    string    `json:"item_type"` // 'buy_in', 'luxury_tax', 'fee'
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DuesPayment struct {
+	ID         string    `json:"id"`
+	Amount     float64   `json:"amount"`
+	Method     string    `json:"method"`
+	Note       string    `json:"note"`
+	PaidOn     time.Time `json:"paid_on"`
+	RecordedBy string    `json:"recorded_by"`
+}
+
+// DuesSummary totals a league's invoices for a season.
+type DuesSummary struct {
+	Invoiced    float64 `json:"invoiced"`
+	Paid        float64 `json:"paid"`
+	Outstanding float64 `json:"outstanding"`
+	Overdue     int     `json:"overdue"`
+}
+
+func (inv *DuesInvoice) setStatus(now time.Time) {
+	inv.Outstanding = inv.Total - inv.Paid
+	switch {
+	case inv.Total > 0 && inv.Outstanding < 0.005:
+		inv.Status = "paid"
+	case inv.Outstanding >= 0.005 && inv.DueDate != nil && now.After(inv.DueDate.AddDate(0, 0, 1)):
+		inv.Status = "overdue"
+	case inv.Paid > 0:
+		inv.Status = "partial"
+	default:
+		inv.Status = "open"
+	}
+}
+
+// SyncDuesInvoice creates or refreshes a team's invoice for the season. The buy-in and luxury-tax
+// line items are replaced with the current amounts; manual fees and payments are left alone.
+// An empty dueDate keeps the invoice's existing due date.
+func SyncDuesInvoice(db *pgxpool.Pool, leagueID, teamID, ownerName string, year int, buyIn, luxuryTax float64, dueDate, createdBy string) (string, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var invoiceID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO dues_invoices (league_id, team_id, year, owner_name, due_date, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::date, $6)
+		ON CONFLICT (team_id, year) DO UPDATE SET
+			owner_name = EXCLUDED.owner_name,
+			due_date = COALESCE(EXCLUDED.due_date, dues_invoices.due_date),
+			updated_at = NOW()
+		RETURNING id
+	`, leagueID, teamID, year, ownerName, dueDate, createdBy).Scan(&invoiceID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM dues_line_items WHERE invoice_id = $1 AND item_type IN ('buy_in', 'luxury_tax')`, invoiceID)
+	if err != nil {
+		return "", err
+	}
+
+	items := []struct {
+		itemType, desc string
+		amount         float64
+	}{
+		{"buy_in", fmt.Sprintf("%d buy-in", year), buyIn},
+		{"luxury_tax", fmt.Sprintf("%d luxury tax penalty", year), luxuryTax},
+	}
+	for _, it := range items {
+		if it.amount <= 0 {
+			continue
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO dues_line_items (invoice_id, item_type, description, amount, created_by)
+			VALUES ($1, $2, $3, ROUND($4::numeric, 2), $5)
+		`, invoiceID, it.itemType, it.desc, it.amount, createdBy)
+		if err != nil {
+			return "", err
+		}
+	}
+	return invoiceID, tx.Commit(ctx)
+}
+
+// AddDuesFee adds a manual line item (late fee, credit, etc.) to an invoice. Negative amounts are credits.
+func AddDuesFee(db *pgxpool.Pool, invoiceID, description string, amount float64, createdBy string) error {
+	if amount == 0 {
+		return fmt.Errorf("fee amount must be non-zero")
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO dues_line_items (invoice_id, item_type, description, amount, created_by)
+		VALUES ($1, 'fee', $2, ROUND($3::numeric, 2), $4)
+	`, invoiceID, description, amount, createdBy)
+	if err == nil {
+		touchDuesInvoice(db, invoiceID)
+	}
+	return err
+}
+
+// DeleteDuesFee removes a manual line item. Generated buy-in and tax items can't be deleted.
+func DeleteDuesFee(db *pgxpool.Pool, itemID string) error {
+	result, err := db.Exec(context.Background(), `DELETE FROM dues_line_items WHERE id = $1 AND item_type = 'fee'`, itemID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("fee not found")
+	}
+	return nil
+}
+
+// RecordDuesPayment records a full or partial payment against an invoice.
+func RecordDuesPayment(db *pgxpool.Pool, invoiceID string, amount float64, method, note, paidOn, recordedBy string) error {
+	if amount <= 0 {
+		return fmt.Errorf("payment amount must be positive")
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO dues_payments (invoice_id, amount, method, note, paid_on, recorded_by)
+		VALUES ($1, ROUND($2::numeric, 2), $3, $4, COALESCE(NULLIF($5, '')::date, CURRENT_DATE), $6)
+	`, invoiceID, amount, method, note, paidOn, recordedBy)
+	if err == nil {
+		touchDuesInvoice(db, invoiceID)
+	}
+	return err
+}
+
+// DeleteDuesPayment removes a payment recorded in error.
+func DeleteDuesPayment(db *pgxpool.Pool, paymentID string) error {
+	result, err := db.Exec(context.Background(), `DELETE FROM dues_payments WHERE id = $1`, paymentID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("payment not found")
+	}
+	return nil
+}
+
+// UpdateDuesInvoice sets an invoice's due date and notes.
+func UpdateDuesInvoice(db *pgxpool.Pool, invoiceID, dueDate, notes string) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE dues_invoices SET due_date = NULLIF($2, '')::date, notes = $3, updated_at = NOW() WHERE id = $1
+	`, invoiceID, dueDate, notes)
+	return err
+}
+
+func touchDuesInvoice(db *pgxpool.Pool, invoiceID string) {
+	db.Exec(context.Background(), `UPDATE dues_invoices SET updated_at = NOW() WHERE id = $1`, invoiceID)
+}
+
+const duesInvoiceSelect = `
+	SELECT di.id, di.league_id, di.team_id, COALESCE(t.name, ''), COALESCE(di.owner_name, ''), di.year,
+	       di.due_date, COALESCE(di.notes, ''), di.last_reminded_at, COALESCE(di.reminder_count, 0),
+	       COALESCE((SELECT SUM(li.amount) FROM dues_line_items li WHERE li.invoice_id = di.id), 0),
+	       COALESCE((SELECT SUM(dp.amount) FROM dues_payments dp WHERE dp.invoice_id = di.id), 0)
+	FROM dues_invoices di
+	LEFT JOIN teams t ON di.team_id = t.id
+`
+
+func queryDuesInvoices(db *pgxpool.Pool, where string, args ...interface{}) ([]DuesInvoice, error) {
+	ctx := context.Background()
+	rows, err := db.Query(ctx, duesInvoiceSelect+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var invoices []DuesInvoice
+	for rows.Next() {
+		var inv DuesInvoice
+		if err := rows.Scan(&inv.ID, &inv.LeagueID, &inv.TeamID, &inv.TeamName, &inv.OwnerName, &inv.Year,
+			&inv.DueDate, &inv.Notes, &inv.LastRemindedAt, &inv.ReminderCount, &inv.Total, &inv.Paid); err != nil {
+			continue
+		}
+		inv.setStatus(now)
+		invoices = append(invoices, inv)
+	}
+	rows.Close()
+
+	for i := range invoices {
+		loadDuesDetail(ctx, db, &invoices[i])
+	}
+	return invoices, nil
+}
+
+func loadDuesDetail(ctx context.Context, db *pgxpool.Pool, inv *DuesInvoice) {
+	rows, err := db.Query(ctx, `
+		SELECT id, item_type, COALESCE(description, ''), amount, COALESCE(created_by, ''), created_at
+		FROM dues_line_items WHERE invoice_id = $1
+		ORDER BY CASE item_type WHEN 'buy_in' THEN 0 WHEN 'luxury_tax' THEN 1 ELSE 2 END, created_at
+	`, inv.ID)
+	if err == nil {
+		for rows.Next() {
+			var li DuesLineItem
+			if err := rows.Scan(&li.ID, &li.ItemType, &li.Description, &li.Amount, &li.CreatedBy, &li.CreatedAt); err == nil {
+				inv.LineItems = append(inv.LineItems, li)
+			}
+		}
+		rows.Close()
+	}
+
+	rows, err = db.Query(ctx, `
+		SELECT id, amount, COALESCE(method, ''), COALESCE(note, ''), paid_on, COALESCE(recorded_by, '')
+		FROM dues_payments WHERE invoice_id = $1
+		ORDER BY paid_on, created_at
+	`, inv.ID)
+	if err == nil {
+		for rows.Next() {
+			var p DuesPayment
+			if err := rows.Scan(&p.ID, &p.Amount, &p.Method, &p.Note, &p.PaidOn, &p.RecordedBy); err == nil {
+				inv.Payments = append(inv.Payments, p)
+			}
+		}
+		rows.Close()
+	}
+}
+
+// GetLeagueDuesInvoices returns every invoice in a league for the season with line items and
+// payments, plus league totals.
+func GetLeagueDuesInvoices(db *pgxpool.Pool, leagueID string, year int) ([]DuesInvoice, DuesSummary, error) {
+	var summary DuesSummary
+	invoices, err := queryDuesInvoices(db, `WHERE di.league_id = $1 AND di.year = $2 ORDER BY t.name`, leagueID, year)
+	if err != nil {
+		return nil, summary, err
+	}
+	for _, inv := range invoices {
+		summary.Invoiced += inv.Total
+		summary.Paid += inv.Paid
+		summary.Outstanding += inv.Outstanding
+		if inv.Status == "overdue" {
+			summary.Overdue++
+		}
+	}
+	return invoices, summary, nil
+}
+
+// GetDuesInvoice returns a single invoice with line items and payments.
+func GetDuesInvoice(db *pgxpool.Pool, invoiceID string) (*DuesInvoice, error) {
+	invoices, err := queryDuesInvoices(db, `WHERE di.id = $1`, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, fmt.Errorf("invoice not found")
+	}
+	return &invoices[0], nil
+}
+
+// GetDuesInvoiceLeagueID returns the league an invoice belongs to.
+func GetDuesInvoiceLeagueID(db *pgxpool.Pool, invoiceID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `SELECT league_id FROM dues_invoices WHERE id = $1`, invoiceID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// GetDuesLineItemLeagueID returns the league of the invoice a line item is on.
+func GetDuesLineItemLeagueID(db *pgxpool.Pool, itemID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `
+		SELECT di.league_id FROM dues_line_items li JOIN dues_invoices di ON di.id = li.invoice_id WHERE li.id = $1
+	`, itemID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// GetDuesPaymentLeagueID returns the league of the invoice a payment was recorded against.
+func GetDuesPaymentLeagueID(db *pgxpool.Pool, paymentID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `
+		SELECT di.league_id FROM dues_payments dp JOIN dues_invoices di ON di.id = dp.invoice_id WHERE dp.id = $1
+	`, paymentID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// GetOverdueDuesInvoices returns unpaid invoices past their due date across all leagues
+// that haven't been reminded within the last remindEvery.
+func GetOverdueDuesInvoices(db *pgxpool.Pool, remindEvery time.Duration) ([]DuesInvoice, error) {
+	invoices, err := queryDuesInvoices(db, `
+		WHERE di.due_date < CURRENT_DATE
+		  AND (di.last_reminded_at IS NULL OR di.last_reminded_at < $1)
+		ORDER BY di.due_date
+	`, time.Now().Add(-remindEvery))
+	if err != nil {
+		return nil, err
+	}
+	var overdue []DuesInvoice
+	for _, inv := range invoices {
+		if inv.Status == "overdue" {
+			overdue = append(overdue, inv)
+		}
+	}
+	return overdue, nil
+}
+
+// MarkDuesReminded records that a reminder was sent for an invoice.
+func MarkDuesReminded(db *pgxpool.Pool, invoiceID string) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE dues_invoices SET last_reminded_at = NOW(), reminder_count = COALESCE(reminder_count, 0) + 1 WHERE id = $1
+	`, invoiceID)
+	return err
+}
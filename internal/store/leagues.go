@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -141,18 +142,76 @@ func GetKeyDates(db *pgxpool.Pool, leagueID string) ([]KeyDate, error) {
 // --- League Settings (Business Rules) ---
 
 type LeagueSettings struct {
-	Roster26ManLimit int `json:"roster_26_man_limit"`
-	Roster40ManLimit int `json:"roster_40_man_limit"`
-	SP26ManLimit     int `json:"sp_26_man_limit"`
+	Roster26ManLimit    int    `json:"roster_26_man_limit"`
+	Roster40ManLimit    int    `json:"roster_40_man_limit"`
+	SP26ManLimit        int    `json:"sp_26_man_limit"`
+	OptionDefaultAction string `json:"option_default_action"` // applied to undecided team options after option_deadline
+
+	// Player-side decisions: 'commissioner', 'always_in', 'always_out', 'salary_threshold'
+	PlayerOptionRule      string  `json:"player_option_rule"`
+	PlayerOptionThreshold float64 `json:"player_option_threshold"`
+
+	// Salary schedule constraints for bids and extensions
+	MinSalary         float64 `json:"contract_min_salary"`
+	MaxSalaryVariance float64 `json:"contract_max_yoy_variance"` // 0.25 = consecutive years may differ by 25%
+	DiscountRate      float64 `json:"contract_discount_rate"`
+
+	// Whether minor-league stat lines score fantasy points
+	MiLBScoring bool `json:"milb_scoring"`
+
+	// How two-way players count toward roster limits
+	TwoWaySPRule      string `json:"two_way_sp_rule"`      // pitching_role, always, exempt
+	TwoWayRosterSlots int    `json:"two_way_roster_slots"` // 26-man slots taken (1 or 2)
+
+	// Where standings and waiver priority come from: fantrax or native (in-house matchups)
+	StandingsSource string `json:"standings_source"`
+}
+
+// CountsTowardSPLimit reports whether a 26-man player with this position counts toward the SP limit.
+// Two-way players follow the league's two-way rule; everyone else counts only when listed as SP.
+func (s LeagueSettings) CountsTowardSPLimit(position string, isTwoWay bool) bool {
+	if !isTwoWay {
+		return position == "SP"
+	}
+	switch s.TwoWaySPRule {
+	case "always":
+		return true
+	case "exempt":
+		return false
+	}
+	for _, pos := range strings.Split(position, ",") {
+		if strings.TrimSpace(pos) == "SP" {
+			return true
+		}
+	}
+	return false
+}
+
+// RosterSlots returns how many 26-man slots a player occupies.
+func (s LeagueSettings) RosterSlots(isTwoWay bool) int {
+	if isTwoWay {
+		return s.TwoWayRosterSlots
+	}
+	return 1
 }
 
 // GetLeagueSettings returns configurable limits for a league/year, with defaults.
 func GetLeagueSettings(db *pgxpool.Pool, leagueID string, year int) LeagueSettings {
-	s := LeagueSettings{Roster26ManLimit: 26, Roster40ManLimit: 40, SP26ManLimit: 6}
+	s := LeagueSettings{Roster26ManLimit: 26, Roster40ManLimit: 40, SP26ManLimit: 6, OptionDefaultAction: "decline",
+		PlayerOptionRule: "commissioner", MinSalary: 760000, MaxSalaryVariance: 0.25, DiscountRate: 0.05,
+		TwoWaySPRule: "pitching_role", TwoWayRosterSlots: 1, StandingsSource: "fantrax"}
 	db.QueryRow(context.Background(), `
-		SELECT COALESCE(roster_26_man_limit, 26), COALESCE(roster_40_man_limit, 40), COALESCE(sp_26_man_limit, 6)
+		SELECT COALESCE(roster_26_man_limit, 26), COALESCE(roster_40_man_limit, 40), COALESCE(sp_26_man_limit, 6),
+		       COALESCE(option_default_action, 'decline'),
+		       COALESCE(player_option_rule, 'commissioner'), COALESCE(player_option_threshold, 0),
+		       COALESCE(contract_min_salary, 760000), COALESCE(contract_max_yoy_variance, 0.25), COALESCE(contract_discount_rate, 0.05),
+		       COALESCE(milb_scoring, FALSE),
+		       COALESCE(two_way_sp_rule, 'pitching_role'), COALESCE(two_way_roster_slots, 1),
+		       COALESCE(standings_source, 'fantrax')
 		FROM league_settings WHERE league_id = $1 AND year = $2
-	`, leagueID, year).Scan(&s.Roster26ManLimit, &s.Roster40ManLimit, &s.SP26ManLimit)
+	`, leagueID, year).Scan(&s.Roster26ManLimit, &s.Roster40ManLimit, &s.SP26ManLimit, &s.OptionDefaultAction,
+		&s.PlayerOptionRule, &s.PlayerOptionThreshold, &s.MinSalary, &s.MaxSalaryVariance, &s.DiscountRate,
+		&s.MiLBScoring, &s.TwoWaySPRule, &s.TwoWayRosterSlots, &s.StandingsSource)
 	return s
 }
 
@@ -169,6 +228,19 @@ func UpsertLeagueSettings(db *pgxpool.Pool, leagueID string, year, limit26, limi
 	return err
 }
 
+// SetOptionDefaultAction saves the action ('decline' or 'exercise') applied to undecided team options.
+func SetOptionDefaultAction(db *pgxpool.Pool, leagueID string, year int, action string) error {
+	if action != "exercise" {
+		action = "decline"
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, option_default_action)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (league_id, year) DO UPDATE SET option_default_action = EXCLUDED.option_default_action
+	`, leagueID, year, action)
+	return err
+}
+
 // GetLeagueDateValue returns a single date value from league_dates. Returns zero time if not found.
 func GetLeagueDateValue(db *pgxpool.Pool, leagueID string, year int, dateType string) (time.Time, error) {
 	var d time.Time
@@ -209,3 +281,89 @@ func IsRosterExpansionActive(db *pgxpool.Pool, leagueID string, year int) bool {
 	now := time.Now()
 	return !now.Before(start) && !now.After(end)
 }
+
+// SetPlayerOptionRule saves how player-side option and opt-out decisions are made.
+func SetPlayerOptionRule(db *pgxpool.Pool, leagueID string, year int, rule string, threshold float64) error {
+	switch rule {
+	case "always_in", "always_out", "salary_threshold":
+	default:
+		rule = "commissioner"
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, player_option_rule, player_option_threshold)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (league_id, year) DO UPDATE SET
+			player_option_rule = EXCLUDED.player_option_rule,
+			player_option_threshold = EXCLUDED.player_option_threshold
+	`, leagueID, year, rule, threshold)
+	return err
+}
+
+// SetMiLBScoring turns fantasy scoring of minor-league stat lines on or off for a league/year.
+// Returns whether the setting changed, so callers know to rescore stored lines.
+func SetMiLBScoring(db *pgxpool.Pool, leagueID string, year int, enabled bool) (bool, error) {
+	if GetLeagueSettings(db, leagueID, year).MiLBScoring == enabled {
+		return false, nil
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, milb_scoring)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (league_id, year) DO UPDATE SET milb_scoring = EXCLUDED.milb_scoring
+	`, leagueID, year, enabled)
+	return err == nil, err
+}
+
+// SetTwoWayRules saves how two-way players count toward the SP limit and the 26-man roster.
+func SetTwoWayRules(db *pgxpool.Pool, leagueID string, year int, spRule string, rosterSlots int) error {
+	switch spRule {
+	case "always", "exempt":
+	default:
+		spRule = "pitching_role"
+	}
+	if rosterSlots != 2 {
+		rosterSlots = 1
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, two_way_sp_rule, two_way_roster_slots)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (league_id, year) DO UPDATE SET
+			two_way_sp_rule = EXCLUDED.two_way_sp_rule,
+			two_way_roster_slots = EXCLUDED.two_way_roster_slots
+	`, leagueID, year, spRule, rosterSlots)
+	return err
+}
+
+// SetStandingsSource saves whether standings and waiver priority come from Fantrax or native matchups.
+func SetStandingsSource(db *pgxpool.Pool, leagueID string, year int, source string) error {
+	if source != "native" {
+		source = "fantrax"
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, standings_source)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (league_id, year) DO UPDATE SET standings_source = EXCLUDED.standings_source
+	`, leagueID, year, source)
+	return err
+}
+
+// SetSalaryScheduleRules saves the per-year salary constraints applied to bids and extensions.
+func SetSalaryScheduleRules(db *pgxpool.Pool, leagueID string, year int, minSalary, maxVariance, discountRate float64) error {
+	if minSalary <= 0 {
+		minSalary = 760000
+	}
+	if maxVariance <= 0 {
+		maxVariance = 0.25
+	}
+	if discountRate < 0 {
+		discountRate = 0
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, contract_min_salary, contract_max_yoy_variance, contract_discount_rate)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (league_id, year) DO UPDATE SET
+			contract_min_salary = EXCLUDED.contract_min_salary,
+			contract_max_yoy_variance = EXCLUDED.contract_max_yoy_variance,
+			contract_discount_rate = EXCLUDED.contract_discount_rate
+	`, leagueID, year, minSalary, maxVariance, discountRate)
+	return err
+}
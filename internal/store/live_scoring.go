@@ -0,0 +1,214 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Live Scoring ---
+
+// LiveStatLine is one player's line on the live scoreboard. Provisional lines come from
+// live_player_stats and are replaced by the final daily_player_stats line once the date is processed.
+type LiveStatLine struct {
+	PlayerID      string             `json:"player_id"`
+	PlayerName    string             `json:"player_name"`
+	Position      string             `json:"position"`
+	GamePk        int                `json:"game_pk"`
+	StatType      string             `json:"stat_type"`
+	Opponent      string             `json:"opponent"`
+	GameState     string             `json:"game_state"`
+	InningLabel   string             `json:"inning_label"`
+	RawStats      map[string]float64 `json:"raw_stats"`
+	Summary       string             `json:"summary"`
+	FantasyPoints float64            `json:"fantasy_points"`
+	Provisional   bool               `json:"provisional"`
+}
+
+type LiveTeamScore struct {
+	TeamID            string         `json:"team_id"`
+	TeamName          string         `json:"team_name"`
+	FinalPoints       float64        `json:"final_points"`
+	ProvisionalPoints float64        `json:"provisional_points"`
+	TotalPoints       float64        `json:"total_points"`
+	Lines             []LiveStatLine `json:"lines"`
+}
+
+type LiveScoreboard struct {
+	LeagueID    string          `json:"league_id"`
+	Date        string          `json:"date"`
+	Teams       []LiveTeamScore `json:"teams"`
+	Provisional bool            `json:"provisional"` // any provisional line on the board
+	LastUpdated *time.Time      `json:"last_updated"`
+}
+
+// UpsertLiveStatLines writes provisional stat lines for one game, tagged with the game's state.
+func UpsertLiveStatLines(db *pgxpool.Pool, lines []DailyPlayerStats, gameState, inningLabel string) error {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, s := range lines {
+		rawJSON, err := json.Marshal(s.RawStats)
+		if err != nil {
+			return err
+		}
+		var teamID, leagueID interface{}
+		if s.TeamID != "" && s.TeamID != "00000000-0000-0000-0000-000000000000" {
+			teamID = s.TeamID
+		}
+		if s.LeagueID != "" {
+			leagueID = s.LeagueID
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO live_player_stats (player_id, mlb_id, game_pk, game_date, stat_type, raw_stats, fantasy_points,
+				team_id, league_id, opponent, game_state, inning_label, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+			ON CONFLICT (player_id, game_pk, stat_type) DO UPDATE SET
+				raw_stats = EXCLUDED.raw_stats,
+				fantasy_points = EXCLUDED.fantasy_points,
+				team_id = EXCLUDED.team_id,
+				league_id = EXCLUDED.league_id,
+				opponent = EXCLUDED.opponent,
+				game_state = EXCLUDED.game_state,
+				inning_label = EXCLUDED.inning_label,
+				updated_at = NOW()
+		`, s.PlayerID, s.MlbID, s.GamePk, s.GameDate, s.StatType, rawJSON, s.FantasyPoints,
+			teamID, leagueID, s.Opponent, gameState, inningLabel)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ClearLiveStats drops provisional lines for a date once nightly processing has stored the final ones.
+func ClearLiveStats(db *pgxpool.Pool, date string) error {
+	_, err := db.Exec(context.Background(), `DELETE FROM live_player_stats WHERE game_date = $1`, date)
+	return err
+}
+
+// GetLiveScoreboard returns per-team fantasy points for a league on one date, for rostered players only.
+// Final lines come from daily_player_stats; live lines fill in games not yet processed nightly.
+// teamID is optional.
+func GetLiveScoreboard(db *pgxpool.Pool, leagueID, teamID, date string) (*LiveScoreboard, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT s.player_id, p.first_name || ' ' || p.last_name, COALESCE(p.position, ''),
+			s.team_id, t.name, s.game_pk, s.stat_type, COALESCE(s.opponent, ''),
+			s.game_state, s.inning_label, s.raw_stats, s.fantasy_points, s.provisional, s.updated_at
+		FROM (
+			SELECT d.player_id, d.team_id, d.game_pk, d.stat_type, d.opponent,
+				'Final' AS game_state, '' AS inning_label, d.raw_stats, d.fantasy_points,
+				FALSE AS provisional, NULL::TIMESTAMPTZ AS updated_at
+			FROM daily_player_stats d
+			WHERE d.league_id = $1 AND d.game_date = $2 AND d.team_id IS NOT NULL
+			UNION ALL
+			SELECT l.player_id, l.team_id, l.game_pk, l.stat_type, l.opponent,
+				l.game_state, l.inning_label, l.raw_stats, l.fantasy_points,
+				TRUE AS provisional, l.updated_at
+			FROM live_player_stats l
+			WHERE l.league_id = $1 AND l.game_date = $2 AND l.team_id IS NOT NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM daily_player_stats d
+					WHERE d.player_id = l.player_id AND d.game_pk = l.game_pk AND d.stat_type = l.stat_type
+				)
+		) s
+		JOIN players p ON p.id = s.player_id
+		JOIN teams t ON t.id = s.team_id
+	`
+	args := []interface{}{leagueID, date}
+	if teamID != "" {
+		query += ` WHERE s.team_id = $3`
+		args = append(args, teamID)
+	}
+	query += ` ORDER BY t.name, s.fantasy_points DESC`
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	board := &LiveScoreboard{LeagueID: leagueID, Date: date}
+	teamIdx := make(map[string]int)
+	for rows.Next() {
+		var l LiveStatLine
+		var lineTeamID, teamName string
+		var rawJSON []byte
+		var updatedAt *time.Time
+		if err := rows.Scan(&l.PlayerID, &l.PlayerName, &l.Position, &lineTeamID, &teamName, &l.GamePk, &l.StatType,
+			&l.Opponent, &l.GameState, &l.InningLabel, &rawJSON, &l.FantasyPoints, &l.Provisional, &updatedAt); err != nil {
+			return nil, err
+		}
+		json.Unmarshal(rawJSON, &l.RawStats)
+		l.Summary = StatLineSummary(l.StatType, l.RawStats)
+
+		idx, ok := teamIdx[lineTeamID]
+		if !ok {
+			idx = len(board.Teams)
+			teamIdx[lineTeamID] = idx
+			board.Teams = append(board.Teams, LiveTeamScore{TeamID: lineTeamID, TeamName: teamName})
+		}
+		team := &board.Teams[idx]
+		if l.Provisional {
+			team.ProvisionalPoints += l.FantasyPoints
+			board.Provisional = true
+		} else {
+			team.FinalPoints += l.FantasyPoints
+		}
+		team.TotalPoints += l.FantasyPoints
+		team.Lines = append(team.Lines, l)
+
+		if updatedAt != nil && (board.LastUpdated == nil || updatedAt.After(*board.LastUpdated)) {
+			board.LastUpdated = updatedAt
+		}
+	}
+
+	sort.SliceStable(board.Teams, func(i, j int) bool {
+		return board.Teams[i].TotalPoints > board.Teams[j].TotalPoints
+	})
+	return board, rows.Err()
+}
+
+// StatLineSummary renders a short box-score line, e.g. "6.0 IP, 2 ER, 7 K" or "2 H, 1 HR, 3 RBI".
+func StatLineSummary(statType string, raw map[string]float64) string {
+	var parts []string
+	add := func(key, label string) {
+		if v := raw[key]; v != 0 {
+			parts = append(parts, fmt.Sprintf("%g %s", v, label))
+		}
+	}
+
+	if statType == "pitching" {
+		// raw["ip"] is in true thirds; show it the box-score way (6.2 = six and two-thirds)
+		outs := int(math.Round(raw["ip"] * 3))
+		parts = append(parts, fmt.Sprintf("%d.%d IP", outs/3, outs%3))
+		add("er", "ER")
+		add("k", "K")
+		add("bb", "BB")
+		add("sv", "SV")
+		add("hld", "HLD")
+		add("bs", "BS")
+	} else {
+		parts = append(parts, fmt.Sprintf("%g H", raw["h"]))
+		add("hr", "HR")
+		add("rbi", "RBI")
+		add("r", "R")
+		add("bb", "BB")
+		add("sb", "SB")
+		add("k", "K")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,376 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Head-to-Head Matchups ---
+
+// Matchup is one week's head-to-head pairing. Scores are the fantasy points earned by each
+// team's 26-man roster, as locked at first pitch, on each day of the week.
+type Matchup struct {
+	ID           string    `json:"id"`
+	Week         int       `json:"week"`
+	WeekStart    time.Time `json:"week_start"`
+	WeekEnd      time.Time `json:"week_end"`
+	HomeTeamID   string    `json:"home_team_id"`
+	HomeTeamName string    `json:"home_team_name"`
+	HomeScore    float64   `json:"home_score"`
+	AwayTeamID   string    `json:"away_team_id"`
+	AwayTeamName string    `json:"away_team_name"`
+	AwayScore    float64   `json:"away_score"`
+}
+
+// StandingRow is a team's head-to-head record computed from completed matchups.
+type StandingRow struct {
+	Rank          int     `json:"rank"`
+	TeamID        string  `json:"team_id"`
+	TeamName      string  `json:"team_name"`
+	FantraxTeamID string  `json:"fantrax_team_id"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	WinPct        float64 `json:"win_pct"`
+	GamesBack     float64 `json:"games_back"`
+	PointsFor     float64 `json:"points_for"`
+	PointsAgainst float64 `json:"points_against"`
+}
+
+// Record formats the W-L-T record.
+func (r StandingRow) Record() string {
+	return fmt.Sprintf("%d-%d-%d", r.Wins, r.Losses, r.Ties)
+}
+
+// GenerateSchedule replaces a league's schedule for a season with a round-robin of weekly
+// (Monday-Sunday) matchups starting the week of start. Teams cycle through every opponent,
+// swapping home and away each time through; with an odd team count one team sits out each week.
+// Refuses once a week of the existing schedule has started. Returns matchups created.
+func GenerateSchedule(db *pgxpool.Pool, leagueID string, year int, start time.Time, weeks int, today string) (int, error) {
+	ctx := context.Background()
+	if weeks < 1 {
+		return 0, fmt.Errorf("schedule needs at least one week")
+	}
+
+	rows, err := db.Query(ctx, `SELECT id FROM teams WHERE league_id = $1 ORDER BY name`, leagueID)
+	if err != nil {
+		return 0, err
+	}
+	var teamIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err == nil {
+			teamIDs = append(teamIDs, id)
+		}
+	}
+	rows.Close()
+	if len(teamIDs) < 2 {
+		return 0, fmt.Errorf("league needs at least two teams")
+	}
+
+	// Weeks run Monday through Sunday
+	offset := (int(start.Weekday()) + 6) % 7
+	monday := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -offset)
+
+	rounds := roundRobinRounds(teamIDs)
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the league row so two generations can't interleave with the started-week check
+	if _, err := tx.Exec(ctx, `SELECT id FROM leagues WHERE id = $1 FOR UPDATE`, leagueID); err != nil {
+		return 0, err
+	}
+	var started int
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM matchups WHERE league_id = $1 AND year = $2 AND week_start <= $3::date
+	`, leagueID, year, today).Scan(&started); err != nil {
+		return 0, err
+	}
+	if started > 0 {
+		return 0, fmt.Errorf("schedule already has started weeks")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM matchups WHERE league_id = $1 AND year = $2`, leagueID, year); err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for w := 0; w < weeks; w++ {
+		weekStart := monday.AddDate(0, 0, 7*w)
+		weekEnd := weekStart.AddDate(0, 0, 6)
+		swap := (w/len(rounds))%2 == 1
+		for _, pair := range rounds[w%len(rounds)] {
+			home, away := pair[0], pair[1]
+			if home == "" || away == "" {
+				continue // bye
+			}
+			if swap {
+				home, away = away, home
+			}
+			_, err := tx.Exec(ctx, `
+				INSERT INTO matchups (league_id, year, week, week_start, week_end, home_team_id, away_team_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+			`, leagueID, year, w+1, weekStart.Format("2006-01-02"), weekEnd.Format("2006-01-02"), home, away)
+			if err != nil {
+				return 0, err
+			}
+			created++
+		}
+	}
+	return created, tx.Commit(ctx)
+}
+
+// roundRobinRounds pairs every team with every other once using the circle method.
+// An empty ID in a pair marks a bye when the team count is odd.
+func roundRobinRounds(teamIDs []string) [][][2]string {
+	ids := append([]string{}, teamIDs...)
+	if len(ids)%2 == 1 {
+		ids = append(ids, "")
+	}
+	n := len(ids)
+
+	var rounds [][][2]string
+	for r := 0; r < n-1; r++ {
+		var pairs [][2]string
+		for i := 0; i < n/2; i++ {
+			home, away := ids[i], ids[n-1-i]
+			// Alternate the fixed team's side so home games even out
+			if i == 0 && r%2 == 1 {
+				home, away = away, home
+			}
+			pairs = append(pairs, [2]string{home, away})
+		}
+		rounds = append(rounds, pairs)
+
+		// Rotate everyone but the first team
+		last := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = last
+	}
+	return rounds
+}
+
+// HasSchedule reports whether a league has any matchups for a season.
+func HasSchedule(db *pgxpool.Pool, leagueID string, year int) bool {
+	var exists bool
+	db.QueryRow(context.Background(), `
+		SELECT EXISTS (SELECT 1 FROM matchups WHERE league_id = $1 AND year = $2)
+	`, leagueID, year).Scan(&exists)
+	return exists
+}
+
+// GetMatchups returns a league's schedule for a season with each side's score to date.
+// week 0 returns every week.
+func GetMatchups(db *pgxpool.Pool, leagueID string, year, week int) ([]Matchup, error) {
+	rows, err := db.Query(context.Background(), `
+		WITH team_scores AS (
+			SELECT rs.team_id, rs.snapshot_date AS game_date, SUM(dps.fantasy_points) AS points
+			FROM roster_snapshots rs
+			JOIN daily_player_stats dps ON dps.player_id = rs.player_id AND dps.game_date = rs.snapshot_date
+			WHERE rs.league_id = $1 AND rs.roster_status = '26'
+			GROUP BY rs.team_id, rs.snapshot_date
+		)
+		SELECT m.id, m.week, m.week_start, m.week_end,
+		       m.home_team_id, ht.name,
+		       COALESCE((SELECT SUM(s.points) FROM team_scores s
+		                 WHERE s.team_id = m.home_team_id AND s.game_date BETWEEN m.week_start AND m.week_end), 0),
+		       m.away_team_id, awt.name,
+		       COALESCE((SELECT SUM(s.points) FROM team_scores s
+		                 WHERE s.team_id = m.away_team_id AND s.game_date BETWEEN m.week_start AND m.week_end), 0)
+		FROM matchups m
+		JOIN teams ht ON ht.id = m.home_team_id
+		JOIN teams awt ON awt.id = m.away_team_id
+		WHERE m.league_id = $1 AND m.year = $2 AND ($3 = 0 OR m.week = $3)
+		ORDER BY m.week, ht.name
+	`, leagueID, year, week)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matchups []Matchup
+	for rows.Next() {
+		var m Matchup
+		if err := rows.Scan(&m.ID, &m.Week, &m.WeekStart, &m.WeekEnd,
+			&m.HomeTeamID, &m.HomeTeamName, &m.HomeScore,
+			&m.AwayTeamID, &m.AwayTeamName, &m.AwayScore); err != nil {
+			return nil, err
+		}
+		matchups = append(matchups, m)
+	}
+	return matchups, rows.Err()
+}
+
+// GetScheduleWeeks returns the week numbers in a league's schedule for a season.
+func GetScheduleWeeks(db *pgxpool.Pool, leagueID string, year int) []int {
+	rows, err := db.Query(context.Background(), `
+		SELECT DISTINCT week FROM matchups WHERE league_id = $1 AND year = $2 ORDER BY week
+	`, leagueID, year)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var weeks []int
+	for rows.Next() {
+		var w int
+		if err := rows.Scan(&w); err == nil {
+			weeks = append(weeks, w)
+		}
+	}
+	return weeks
+}
+
+// GetCurrentWeek returns the schedule week containing date, the last week if the season is
+// over, or 1 if it hasn't started. 0 when the league has no schedule.
+func GetCurrentWeek(db *pgxpool.Pool, leagueID string, year int, date string) int {
+	var week int
+	db.QueryRow(context.Background(), `
+		SELECT COALESCE(
+			(SELECT MIN(week) FROM matchups WHERE league_id = $1 AND year = $2 AND week_end >= $3::date),
+			(SELECT MAX(week) FROM matchups WHERE league_id = $1 AND year = $2), 0)
+	`, leagueID, year, date).Scan(&week)
+	return week
+}
+
+// GetNativeStandings computes head-to-head standings from matchups whose week ended before
+// asOf. Ties on win percentage are broken by record in games between the tied teams, then
+// by points for.
+func GetNativeStandings(db *pgxpool.Pool, leagueID string, year int, asOf string) ([]StandingRow, error) {
+	ctx := context.Background()
+	rows, err := db.Query(ctx, `
+		SELECT id, name, COALESCE(fantrax_team_id, '') FROM teams WHERE league_id = $1 ORDER BY name
+	`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	byTeam := make(map[string]*StandingRow)
+	var standings []*StandingRow
+	for rows.Next() {
+		r := &StandingRow{}
+		if err := rows.Scan(&r.TeamID, &r.TeamName, &r.FantraxTeamID); err != nil {
+			continue
+		}
+		byTeam[r.TeamID] = r
+		standings = append(standings, r)
+	}
+	rows.Close()
+
+	matchups, err := GetMatchups(db, leagueID, year, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// headToHead[a][b] is a's record against b (ties count half a win), for the tiebreaker
+	type h2h struct{ wins, games float64 }
+	headToHead := make(map[string]map[string]*h2h)
+	record := func(team, opp string, outcome float64) {
+		if headToHead[team] == nil {
+			headToHead[team] = make(map[string]*h2h)
+		}
+		if headToHead[team][opp] == nil {
+			headToHead[team][opp] = &h2h{}
+		}
+		headToHead[team][opp].wins += outcome
+		headToHead[team][opp].games++
+	}
+
+	for _, m := range matchups {
+		if m.WeekEnd.Format("2006-01-02") >= asOf {
+			continue
+		}
+		home, away := byTeam[m.HomeTeamID], byTeam[m.AwayTeamID]
+		if home == nil || away == nil {
+			continue
+		}
+		homeScore := math.Round(m.HomeScore*100) / 100
+		awayScore := math.Round(m.AwayScore*100) / 100
+		home.PointsFor += m.HomeScore
+		home.PointsAgainst += m.AwayScore
+		away.PointsFor += m.AwayScore
+		away.PointsAgainst += m.HomeScore
+		switch {
+		case homeScore > awayScore:
+			home.Wins++
+			away.Losses++
+			record(home.TeamID, away.TeamID, 1)
+			record(away.TeamID, home.TeamID, 0)
+		case awayScore > homeScore:
+			away.Wins++
+			home.Losses++
+			record(away.TeamID, home.TeamID, 1)
+			record(home.TeamID, away.TeamID, 0)
+		default:
+			home.Ties++
+			away.Ties++
+			record(home.TeamID, away.TeamID, 0.5)
+			record(away.TeamID, home.TeamID, 0.5)
+		}
+	}
+
+	for _, r := range standings {
+		if games := r.Wins + r.Losses + r.Ties; games > 0 {
+			r.WinPct = (float64(r.Wins) + 0.5*float64(r.Ties)) / float64(games)
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].WinPct != standings[j].WinPct {
+			return standings[i].WinPct > standings[j].WinPct
+		}
+		return standings[i].PointsFor > standings[j].PointsFor
+	})
+
+	// Re-order each group tied on win percentage by record against the rest of the group
+	for start := 0; start < len(standings); {
+		end := start + 1
+		for end < len(standings) && standings[end].WinPct == standings[start].WinPct {
+			end++
+		}
+		if end-start > 1 {
+			group := standings[start:end]
+			groupPct := make(map[string]float64)
+			for _, r := range group {
+				var wins, games float64
+				for _, opp := range group {
+					if rec := headToHead[r.TeamID][opp.TeamID]; rec != nil {
+						wins += rec.wins
+						games += rec.games
+					}
+				}
+				if games > 0 {
+					groupPct[r.TeamID] = wins / games
+				} else {
+					groupPct[r.TeamID] = 0.5
+				}
+			}
+			sort.SliceStable(group, func(i, j int) bool {
+				if groupPct[group[i].TeamID] != groupPct[group[j].TeamID] {
+					return groupPct[group[i].TeamID] > groupPct[group[j].TeamID]
+				}
+				return group[i].PointsFor > group[j].PointsFor
+			})
+		}
+		start = end
+	}
+
+	out := make([]StandingRow, len(standings))
+	if len(standings) > 0 {
+		leader := standings[0]
+		for i, r := range standings {
+			r.Rank = i + 1
+			r.GamesBack = float64((leader.Wins-r.Wins)+(r.Losses-leader.Losses)) / 2
+			out[i] = *r
+		}
+	}
+	return out, nil
+}
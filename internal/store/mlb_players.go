@@ -0,0 +1,285 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Canonical MLB Players ---
+
+// MLBPlayer is the league-independent identity of a real player. Each league's players row
+// links to one through mlb_player_id and mirrors its bio columns.
+type MLBPlayer struct {
+	ID             string `json:"id"`
+	MLBID          int    `json:"mlb_id"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Position       string `json:"position"`
+	MLBTeam        string `json:"mlb_team"`
+	IsMinorLeaguer bool   `json:"is_minor_leaguer"`
+	IsTwoWay       bool   `json:"is_two_way"`
+}
+
+// propagateMLBPlayerSQL copies a canonical record's bio onto every league's linked players row.
+const propagateMLBPlayerSQL = `
+	UPDATE players p SET
+		first_name = mp.first_name, last_name = mp.last_name,
+		position = COALESCE(mp.position, p.position), mlb_team = COALESCE(mp.mlb_team, p.mlb_team),
+		mlb_id = mp.mlb_id, is_minor_leaguer = mp.is_minor_leaguer, is_two_way = mp.is_two_way
+	FROM mlb_players mp
+	WHERE mp.id = $1 AND p.mlb_player_id = mp.id
+`
+
+// SyncPlayerCopyToMaster makes a league's players row the source for its canonical record:
+// the row is linked (creating the canonical record if needed), its bio is written to the
+// canonical record, and every other league's copy is brought in line. Players without an
+// MLB ID are matched by name, MLB team and position, since names alone collide, and a bio
+// change that lands on another unmatched record's identity merges the two.
+// A newly linked copy takes the canonical two-way designation rather than overwriting it.
+// Used after imports, syncs and commissioner edits. Returns the canonical ID.
+func SyncPlayerCopyToMaster(db *pgxpool.Pool, playerID string) (string, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var firstName, lastName, position, mlbTeam, masterID string
+	var mlbID int
+	var isMinor, isTwoWay bool
+	err = tx.QueryRow(ctx, `
+		SELECT first_name, COALESCE(last_name, ''), COALESCE(position, ''), COALESCE(mlb_team, ''),
+		       COALESCE(mlb_id, 0), is_minor_leaguer, is_two_way, COALESCE(mlb_player_id::TEXT, '')
+		FROM players WHERE id = $1
+		FOR UPDATE
+	`, playerID).Scan(&firstName, &lastName, &position, &mlbTeam, &mlbID, &isMinor, &isTwoWay, &masterID)
+	if err != nil {
+		return "", fmt.Errorf("player not found: %w", err)
+	}
+	linked := masterID != ""
+
+	if masterID == "" {
+		// New copy: find the canonical record by MLB ID, or by name, MLB team and position while unmatched
+		if mlbID > 0 {
+			err = tx.QueryRow(ctx, `
+				INSERT INTO mlb_players (mlb_id, first_name, last_name, position, mlb_team, is_minor_leaguer, is_two_way)
+				VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+				ON CONFLICT (mlb_id) DO UPDATE SET updated_at = NOW()
+				RETURNING id
+			`, mlbID, firstName, lastName, position, mlbTeam, isMinor, isTwoWay).Scan(&masterID)
+		} else {
+			err = tx.QueryRow(ctx, `
+				INSERT INTO mlb_players (first_name, last_name, position, mlb_team, is_minor_leaguer, is_two_way)
+				VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6)
+				ON CONFLICT (LOWER(first_name), LOWER(last_name), LOWER(COALESCE(mlb_team, '')), LOWER(COALESCE(position, '')))
+					WHERE mlb_id IS NULL
+					DO UPDATE SET updated_at = NOW()
+				RETURNING id
+			`, firstName, lastName, position, mlbTeam, isMinor, isTwoWay).Scan(&masterID)
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE players SET mlb_player_id = $1 WHERE id = $2`, masterID, playerID); err != nil {
+			return "", err
+		}
+	}
+
+	// A copy carrying an MLB ID the canonical record lacks fills it in (merging if another record has it)
+	if mlbID > 0 {
+		if masterID, err = setMLBPlayerMLBID(ctx, tx, masterID, mlbID); err != nil {
+			return "", err
+		}
+	}
+
+	// An unmatched record whose bio now matches another unmatched record's identity is the same
+	// player, so it merges into that record rather than colliding with it
+	var existingID string
+	tx.QueryRow(ctx, `
+		SELECT o.id FROM mlb_players o
+		JOIN mlb_players mp ON mp.id = $5 AND mp.mlb_id IS NULL
+		WHERE o.mlb_id IS NULL AND o.id <> mp.id
+		  AND LOWER(o.first_name) = LOWER($1) AND LOWER(o.last_name) = LOWER($2)
+		  AND LOWER(COALESCE(o.position, '')) = LOWER(COALESCE(NULLIF($3, ''), mp.position, ''))
+		  AND LOWER(COALESCE(o.mlb_team, '')) = LOWER(COALESCE(NULLIF($4, ''), mp.mlb_team, ''))
+	`, firstName, lastName, position, mlbTeam, masterID).Scan(&existingID)
+	if existingID != "" {
+		if _, err := tx.Exec(ctx, `UPDATE players SET mlb_player_id = $1 WHERE mlb_player_id = $2`, existingID, masterID); err != nil {
+			return "", err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM mlb_players WHERE id = $1`, masterID); err != nil {
+			return "", err
+		}
+		masterID = existingID
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE mlb_players SET first_name = $1, last_name = $2,
+			position = COALESCE(NULLIF($3, ''), position), mlb_team = COALESCE(NULLIF($4, ''), mlb_team),
+			is_two_way = CASE WHEN $6 THEN $7 ELSE is_two_way END,
+			updated_at = NOW()
+		WHERE id = $5
+	`, firstName, lastName, position, mlbTeam, masterID, linked, isTwoWay)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, propagateMLBPlayerSQL, masterID); err != nil {
+		return "", err
+	}
+	return masterID, tx.Commit(ctx)
+}
+
+// SetMLBPlayerMLBID assigns an MLB ID to a canonical record. If another record already holds
+// that ID, the two are merged: linked players move to the existing record and this one is
+// removed. Returns the surviving canonical ID.
+func SetMLBPlayerMLBID(db *pgxpool.Pool, masterID string, mlbID int) (string, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	masterID, err = setMLBPlayerMLBID(ctx, tx, masterID, mlbID)
+	if err != nil {
+		return "", err
+	}
+	return masterID, tx.Commit(ctx)
+}
+
+func setMLBPlayerMLBID(ctx context.Context, tx pgx.Tx, masterID string, mlbID int) (string, error) {
+	var existingID string
+	tx.QueryRow(ctx, `SELECT id FROM mlb_players WHERE mlb_id = $1 AND id <> $2`, mlbID, masterID).Scan(&existingID)
+
+	if existingID != "" {
+		if _, err := tx.Exec(ctx, `UPDATE players SET mlb_player_id = $1 WHERE mlb_player_id = $2`, existingID, masterID); err != nil {
+			return "", err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM mlb_players WHERE id = $1`, masterID); err != nil {
+			return "", err
+		}
+		masterID = existingID
+	} else {
+		if _, err := tx.Exec(ctx, `UPDATE mlb_players SET mlb_id = $1, updated_at = NOW() WHERE id = $2`, mlbID, masterID); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, propagateMLBPlayerSQL, masterID); err != nil {
+		return "", err
+	}
+	return masterID, nil
+}
+
+// SetMinorLeaguerByMLBID updates minor leaguer status on the canonical record and its league copies.
+func SetMinorLeaguerByMLBID(db *pgxpool.Pool, mlbID int, isMinor bool) error {
+	_, err := db.Exec(context.Background(), `
+		WITH mp AS (
+			UPDATE mlb_players SET is_minor_leaguer = $2, updated_at = NOW()
+			WHERE mlb_id = $1
+			RETURNING id
+		)
+		UPDATE players SET is_minor_leaguer = $2 WHERE mlb_player_id IN (SELECT id FROM mp)
+	`, mlbID, isMinor)
+	return err
+}
+
+// SetBirthDateByMLBID records a canonical player's birth date (YYYY-MM-DD).
+func SetBirthDateByMLBID(db *pgxpool.Pool, mlbID int, birthDate string) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE mlb_players SET birth_date = $2::date, updated_at = NOW()
+		WHERE mlb_id = $1 AND birth_date IS DISTINCT FROM $2::date
+	`, mlbID, birthDate)
+	return err
+}
+
+// MarkUnmatchedAsMinorLeaguers flags canonical records without an MLB ID as minor leaguers.
+// Returns the number of league copies updated.
+func MarkUnmatchedAsMinorLeaguers(db *pgxpool.Pool) (int64, error) {
+	res, err := db.Exec(context.Background(), `
+		WITH mp AS (
+			UPDATE mlb_players SET is_minor_leaguer = TRUE, updated_at = NOW()
+			WHERE mlb_id IS NULL AND is_minor_leaguer = FALSE
+			RETURNING id
+		)
+		UPDATE players SET is_minor_leaguer = TRUE WHERE mlb_player_id IN (SELECT id FROM mp)
+	`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+// GetKnownMLBIDs returns every MLB ID on a canonical record.
+func GetKnownMLBIDs(db *pgxpool.Pool) ([]int, error) {
+	rows, err := db.Query(context.Background(), `SELECT mlb_id FROM mlb_players WHERE mlb_id IS NOT NULL ORDER BY mlb_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// GetMLBIDsMissingBirthDate returns canonical MLB IDs with no birth date recorded.
+func GetMLBIDsMissingBirthDate(db *pgxpool.Pool) ([]int, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT mlb_id FROM mlb_players WHERE mlb_id IS NOT NULL AND birth_date IS NULL ORDER BY mlb_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetUnmatchedRosteredMLBPlayers returns canonical records without an MLB ID that are rostered in any league.
+func GetUnmatchedRosteredMLBPlayers(db *pgxpool.Pool) ([]MLBPlayer, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT mp.id, mp.first_name, mp.last_name, COALESCE(mp.position, ''), COALESCE(mp.mlb_team, ''), mp.is_minor_leaguer
+		FROM mlb_players mp
+		WHERE mp.mlb_id IS NULL
+		  AND EXISTS (
+		      SELECT 1 FROM players p
+		      WHERE p.mlb_player_id = mp.id
+		        AND p.team_id IS NOT NULL
+		        AND p.team_id <> '00000000-0000-0000-0000-000000000000')
+		ORDER BY mp.last_name, mp.first_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []MLBPlayer
+	for rows.Next() {
+		var p MLBPlayer
+		if err := rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Position, &p.MLBTeam, &p.IsMinorLeaguer); err != nil {
+			continue
+		}
+		players = append(players, p)
+	}
+	return players, nil
+}
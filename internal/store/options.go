@@ -0,0 +1,380 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Contract Options & Opt-Outs ---
+
+// ContractOption is a structured option or opt-out clause attached to a player's contract.
+type ContractOption struct {
+	ID             string  `json:"id"`
+	PlayerID       string  `json:"player_id"`
+	PlayerName     string  `json:"player_name"`
+	TeamID         string  `json:"team_id"`
+	TeamName       string  `json:"team_name"`
+	LeagueID       string  `json:"league_id"`
+	Year           int     `json:"year"`
+	OptionType     string  `json:"option_type"` // 'team', 'player', 'mutual', 'opt_out'
+	Salary         float64 `json:"salary"`      // salary at stake (option year, or the year after an opt-out)
+	Buyout         float64 `json:"buyout"`
+	Deadline       string  `json:"deadline"`
+	TeamDecision   string  `json:"team_decision"`
+	PlayerDecision string  `json:"player_decision"`
+	DecidedBy      string  `json:"decided_by"`
+	Status         string  `json:"status"`
+}
+
+// ExtensionContract is the contract_data payload stored on EXTENSION / REAL_LIFE_EXTENSION
+// pending actions. OptionYears are always the trailing years; OptionType says who holds them.
+type ExtensionContract struct {
+	Salaries     map[string]float64 `json:"salaries"`
+	OptionYears  []int              `json:"option_years"`
+	OptionType   string             `json:"option_type,omitempty"` // '' or 'team', 'player', 'mutual'
+	OptionBuyout float64            `json:"option_buyout,omitempty"`
+	OptOutAfter  int                `json:"opt_out_after,omitempty"`
+}
+
+// AddContractOption attaches an option or opt-out clause to a player's contract.
+func AddContractOption(db *pgxpool.Pool, playerID string, year int, optionType string, buyout float64, deadline string) error {
+	var deadlineVal *string
+	if deadline != "" {
+		deadlineVal = &deadline
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO contract_options (player_id, year, option_type, buyout, decision_deadline)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (player_id, year, option_type) DO UPDATE SET
+			buyout = EXCLUDED.buyout, decision_deadline = EXCLUDED.decision_deadline
+	`, playerID, year, optionType, buyout, deadlineVal)
+	return err
+}
+
+func addContractOptionTx(ctx context.Context, tx pgx.Tx, playerID string, year int, optionType string, buyout float64) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO contract_options (player_id, year, option_type, buyout)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (player_id, year, option_type) DO UPDATE SET buyout = EXCLUDED.buyout, status = 'pending',
+			team_decision = NULL, player_decision = NULL, decided_by = NULL, resolved_at = NULL
+	`, playerID, year, optionType, buyout)
+	return err
+}
+
+// GetContractOptionLeagueID returns the league of the player an option clause belongs to.
+func GetContractOptionLeagueID(db *pgxpool.Pool, optionID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `
+		SELECT p.league_id FROM contract_options co JOIN players p ON p.id = co.player_id WHERE co.id = $1
+	`, optionID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// GetContractOptions returns a league's option clauses, optionally filtered by status.
+func GetContractOptions(db *pgxpool.Pool, leagueID, status string) ([]ContractOption, error) {
+	query := `
+		SELECT co.id, p.id, p.first_name || ' ' || p.last_name, COALESCE(p.team_id::TEXT, ''), COALESCE(t.name, 'Free Agent'),
+		       p.league_id, co.year, co.option_type, COALESCE(co.buyout, 0),
+		       COALESCE(co.decision_deadline::TEXT, ''), COALESCE(co.team_decision, ''), COALESCE(co.player_decision, ''),
+		       COALESCE(co.decided_by, ''), co.status
+		FROM contract_options co
+		JOIN players p ON co.player_id = p.id
+		LEFT JOIN teams t ON p.team_id = t.id
+		WHERE p.league_id = $1
+	`
+	args := []interface{}{leagueID}
+	if status != "" {
+		query += " AND co.status = $2"
+		args = append(args, status)
+	}
+	query += " ORDER BY co.year, t.name, p.last_name"
+
+	rows, err := db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var options []ContractOption
+	for rows.Next() {
+		var o ContractOption
+		if err := rows.Scan(&o.ID, &o.PlayerID, &o.PlayerName, &o.TeamID, &o.TeamName, &o.LeagueID, &o.Year,
+			&o.OptionType, &o.Buyout, &o.Deadline, &o.TeamDecision, &o.PlayerDecision, &o.DecidedBy, &o.Status); err != nil {
+			continue
+		}
+		options = append(options, o)
+	}
+
+	for i := range options {
+		options[i].Salary = optionSalaryAtStake(db, options[i].PlayerID, options[i].Year, options[i].OptionType)
+		if options[i].Deadline == "" {
+			if d, err := GetLeagueDateValue(db, leagueID, options[i].Year-1, "option_deadline"); err == nil {
+				options[i].Deadline = d.Format("2006-01-02")
+			}
+		}
+	}
+	return options, nil
+}
+
+// GetOptionDeadline returns the decision deadline for a player's option year: the clause's own
+// deadline when set, otherwise the league's option_deadline for the current year.
+func GetOptionDeadline(db *pgxpool.Pool, playerID, leagueID string, year int) (time.Time, error) {
+	var d time.Time
+	err := db.QueryRow(context.Background(), `
+		SELECT decision_deadline FROM contract_options
+		WHERE player_id = $1 AND year = $2 AND option_type IN ('team', 'mutual') AND decision_deadline IS NOT NULL
+	`, playerID, year).Scan(&d)
+	if err == nil {
+		return d, nil
+	}
+	return GetLeagueDateValue(db, leagueID, time.Now().Year(), "option_deadline")
+}
+
+// optionSalaryAtStake returns the option-year salary, or for an opt-out the next year's salary.
+func optionSalaryAtStake(db *pgxpool.Pool, playerID string, year int, optionType string) float64 {
+	if optionType == "opt_out" {
+		year++
+	}
+	if year < 2026 || year > 2040 {
+		return 0
+	}
+	var val string
+	db.QueryRow(context.Background(), fmt.Sprintf("SELECT COALESCE(contract_%d, '') FROM players WHERE id = $1", year), playerID).Scan(&val)
+	return parseContractAmount(val)
+}
+
+func getContractOption(ctx context.Context, tx pgx.Tx, optionID string) (ContractOption, error) {
+	var o ContractOption
+	err := tx.QueryRow(ctx, `
+		SELECT co.id, p.id, p.first_name || ' ' || p.last_name, COALESCE(p.team_id::TEXT, ''), p.league_id,
+		       co.year, co.option_type, COALESCE(co.buyout, 0), COALESCE(co.team_decision, ''),
+		       COALESCE(co.player_decision, ''), co.status
+		FROM contract_options co
+		JOIN players p ON co.player_id = p.id
+		WHERE co.id = $1
+	`, optionID).Scan(&o.ID, &o.PlayerID, &o.PlayerName, &o.TeamID, &o.LeagueID, &o.Year, &o.OptionType, &o.Buyout,
+		&o.TeamDecision, &o.PlayerDecision, &o.Status)
+	return o, err
+}
+
+// releaseFromYear clears a player's contract from fromYear onward and returns the player to free agency.
+// Salary an earlier team retained in a trade is not on the contract and stays in retained_salaries.
+func releaseFromYear(ctx context.Context, tx pgx.Tx, playerID string, fromYear int) error {
+	var sets []string
+	for y := fromYear; y <= 2040; y++ {
+		if y >= 2026 {
+			sets = append(sets, fmt.Sprintf("contract_%d = ''", y))
+		}
+	}
+	sets = append(sets, "team_id = NULL", "status_40_man = FALSE", "status_26_man = FALSE", "fa_status = 'available'")
+	_, err := tx.Exec(ctx, fmt.Sprintf("UPDATE players SET %s WHERE id = $1", strings.Join(sets, ", ")), playerID)
+	return err
+}
+
+// declineOption releases the player, charges the buyout to the team as dead cap, voids retention
+// on the option year and closes the option.
+func declineOption(ctx context.Context, tx pgx.Tx, o ContractOption, buyout float64, decidedBy string) error {
+	if err := releaseFromYear(ctx, tx, o.PlayerID, o.Year); err != nil {
+		return err
+	}
+	// The option year is never paid, so salary retained on it in a trade goes with it
+	if err := voidRetainedSalary(ctx, tx, o.PlayerID, o.Year); err != nil {
+		return err
+	}
+	if buyout > 0 && o.TeamID != "" {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO dead_cap_penalties (team_id, player_id, amount, year, note)
+			VALUES ($1, $2, $3, $4, $5)
+		`, o.TeamID, o.PlayerID, buyout, o.Year, fmt.Sprintf("Option Buyout (%s option declined)", o.OptionType))
+		if err != nil {
+			return err
+		}
+	}
+	if o.ID == "" {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `UPDATE contract_options SET status = 'declined', decided_by = $1, resolved_at = NOW() WHERE id = $2`, decidedBy, o.ID)
+	return err
+}
+
+// exerciseOption guarantees the option year (stripping a legacy "(TO)" marker) and closes the option.
+func exerciseOption(ctx context.Context, tx pgx.Tx, o ContractOption, decidedBy string) error {
+	col := fmt.Sprintf("contract_%d", o.Year)
+	_, err := tx.Exec(ctx, fmt.Sprintf(`UPDATE players SET %s = TRIM(REPLACE(%s, '(TO)', '')) WHERE id = $1`, col, col), o.PlayerID)
+	if err != nil {
+		return err
+	}
+	if o.ID == "" {
+		return nil
+	}
+	_, err = tx.Exec(ctx, `UPDATE contract_options SET status = 'exercised', decided_by = $1, resolved_at = NOW() WHERE id = $2`, decidedBy, o.ID)
+	return err
+}
+
+// RecordPlayerDecision applies the player's side of a player option, mutual option or opt-out.
+// decision is 'in' (exercise / stay) or 'out' (decline / leave); decidedBy is 'rule' or 'commissioner'.
+func RecordPlayerDecision(db *pgxpool.Pool, optionID, decision, decidedBy string) (string, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	o, err := getContractOption(ctx, tx, optionID)
+	if err != nil {
+		return "", err
+	}
+	if o.Status != "pending" {
+		return "", fmt.Errorf("option is already %s", o.Status)
+	}
+	if o.OptionType == "team" {
+		return "", fmt.Errorf("team options are decided by the team")
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE contract_options SET player_decision = $1 WHERE id = $2`, decision, optionID)
+	if err != nil {
+		return "", err
+	}
+
+	var summary string
+	switch o.OptionType {
+	case "opt_out":
+		if decision == "out" {
+			if err = releaseFromYear(ctx, tx, o.PlayerID, o.Year+1); err != nil {
+				return "", err
+			}
+			_, err = tx.Exec(ctx, `UPDATE contract_options SET status = 'opted_out', decided_by = $1, resolved_at = NOW() WHERE id = $2`, decidedBy, optionID)
+			summary = fmt.Sprintf("%s OPTED OUT of the contract after %d and is now a Free Agent.", o.PlayerName, o.Year)
+		} else {
+			_, err = tx.Exec(ctx, `UPDATE contract_options SET status = 'stayed', decided_by = $1, resolved_at = NOW() WHERE id = $2`, decidedBy, optionID)
+			summary = fmt.Sprintf("%s declined the opt-out after %d and remains under contract.", o.PlayerName, o.Year)
+		}
+	case "player":
+		if decision == "out" {
+			err = declineOption(ctx, tx, o, o.Buyout, decidedBy)
+			summary = fmt.Sprintf("%s DECLINED the %d Player Option and is now a Free Agent.", o.PlayerName, o.Year)
+		} else {
+			err = exerciseOption(ctx, tx, o, decidedBy)
+			summary = fmt.Sprintf("%s EXERCISED the %d Player Option.", o.PlayerName, o.Year)
+		}
+	case "mutual":
+		if decision == "out" {
+			err = declineOption(ctx, tx, o, mutualBuyout(db, o), decidedBy)
+			summary = fmt.Sprintf("%s DECLINED the player side of the %d Mutual Option and is now a Free Agent.", o.PlayerName, o.Year)
+		} else if o.TeamDecision == "exercise" {
+			err = exerciseOption(ctx, tx, o, decidedBy)
+			summary = fmt.Sprintf("Both sides EXERCISED the %d Mutual Option for %s.", o.Year, o.PlayerName)
+		} else {
+			summary = fmt.Sprintf("%s accepted the player side of the %d Mutual Option; awaiting the team's decision.", o.PlayerName, o.Year)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	transType := "Roster Move"
+	if decision == "out" {
+		transType = "Dropped Player"
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (league_id, team_id, player_id, transaction_type, summary, status)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, 'COMPLETED')
+	`, o.LeagueID, o.TeamID, o.PlayerID, transType, summary)
+	if err != nil {
+		return "", err
+	}
+
+	return summary, tx.Commit(ctx)
+}
+
+// mutualBuyout returns the clause's buyout, or the league default of 30% of the option salary.
+func mutualBuyout(db *pgxpool.Pool, o ContractOption) float64 {
+	if o.Buyout > 0 {
+		return o.Buyout
+	}
+	return declinedOptionBuyout(0, optionSalaryAtStake(db, o.PlayerID, o.Year, o.OptionType))
+}
+
+// declinedOptionBuyout is the dead cap for declining a team or mutual option: the clause's
+// buyout, or 30% of the option year's salary when it has none. Shared by real declines and the
+// payroll planner so the two can't drift apart.
+func declinedOptionBuyout(clauseBuyout, salary float64) float64 {
+	if clauseBuyout > 0 {
+		return clauseBuyout
+	}
+	return salary * 0.30
+}
+
+// PlayerOptionRuleDecision returns the rule-based player decision ('in'/'out'), or "" when the
+// league requires a commissioner ruling.
+func PlayerOptionRuleDecision(settings LeagueSettings, salaryAtStake float64) string {
+	switch settings.PlayerOptionRule {
+	case "always_in":
+		return "in"
+	case "always_out":
+		return "out"
+	case "salary_threshold":
+		if salaryAtStake >= settings.PlayerOptionThreshold {
+			return "in"
+		}
+		return "out"
+	}
+	return ""
+}
+
+// ApplyPlayerOptionRule decides every pending player-side clause due this offseason using the
+// league's configured rule. Returns the number of decisions made.
+func ApplyPlayerOptionRule(db *pgxpool.Pool, leagueID string, season int) (int, error) {
+	settings := GetLeagueSettings(db, leagueID, season)
+	if settings.PlayerOptionRule == "commissioner" {
+		return 0, nil
+	}
+
+	options, err := GetContractOptions(db, leagueID, "pending")
+	if err != nil {
+		return 0, err
+	}
+
+	decided := 0
+	for _, o := range options {
+		due := (o.OptionType == "opt_out" && o.Year == season) || (o.OptionType != "opt_out" && o.Year == season+1)
+		if !due || o.OptionType == "team" || o.PlayerDecision != "" {
+			continue
+		}
+		decision := PlayerOptionRuleDecision(settings, o.Salary)
+		if decision == "" {
+			continue
+		}
+		if _, err := RecordPlayerDecision(db, o.ID, decision, "rule"); err != nil {
+			fmt.Printf("ERROR [ApplyPlayerOptionRule] %s: %v\n", o.PlayerName, err)
+			continue
+		}
+		decided++
+	}
+	return decided, nil
+}
+
+// applyExtensionOptions records the option and opt-out clauses of an approved extension.
+func applyExtensionOptions(ctx context.Context, tx pgx.Tx, playerID string, ext ExtensionContract) error {
+	optionType := ext.OptionType
+	if optionType == "" {
+		optionType = "team"
+	}
+	for _, y := range ext.OptionYears {
+		if err := addContractOptionTx(ctx, tx, playerID, y, optionType, ext.OptionBuyout); err != nil {
+			return err
+		}
+	}
+	if ext.OptOutAfter > 0 {
+		if err := addContractOptionTx(ctx, tx, playerID, ext.OptOutAfter, "opt_out", 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,407 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Payroll Planner ---
+
+// ScenarioMove is one hypothetical transaction in a payroll scenario.
+//
+//	release:     drop the player from FromYear on; dead cap by ReleaseDeadCapSchedule
+//	trade_out:   send the player away from FromYear on (no dead cap)
+//	trade_in:    acquire a player from another team from FromYear on
+//	option:      Decision 'exercise' keeps option year Year, 'decline' drops it (and later years) and charges the buyout
+//	arbitration: set the player's Year salary to Salaries[Year]
+//	sign:        add a hypothetical free agent with the given Salaries
+type ScenarioMove struct {
+	Type       string             `json:"type"`
+	PlayerID   string             `json:"player_id,omitempty"`
+	PlayerName string             `json:"player_name,omitempty"`
+	FromYear   int                `json:"from_year,omitempty"`
+	Year       int                `json:"year,omitempty"`
+	Decision   string             `json:"decision,omitempty"`
+	Salaries   map[string]float64 `json:"salaries,omitempty"` // year -> salary
+	Note       string             `json:"note,omitempty"`
+}
+
+type PayrollScenario struct {
+	ID            string         `json:"id"`
+	TeamID        string         `json:"team_id"`
+	CreatedBy     string         `json:"created_by"`
+	CreatedByName string         `json:"created_by_name"`
+	Name          string         `json:"name"`
+	Notes         string         `json:"notes"`
+	StartYear     int            `json:"start_year"`
+	Moves         []ScenarioMove `json:"moves"`
+	Shared        bool           `json:"shared"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// ContractLine is a player's contract by year as the planner sees it. Non-dollar entries
+// ("ARB", "TC", "UFA") are kept in Labels.
+type ContractLine struct {
+	PlayerID   string          `json:"player_id"`
+	PlayerName string          `json:"player_name"`
+	Position   string          `json:"position"`
+	TeamID     string          `json:"team_id"`
+	Salaries   map[int]float64 `json:"salaries"`
+	Labels     map[int]string  `json:"labels"`
+}
+
+// ScenarioYear compares committed payroll with the scenario for one contract year.
+type ScenarioYear struct {
+	Year           int     `json:"year"`
+	BaseTotal      float64 `json:"base_total"`
+	ActivePayroll  float64 `json:"active_payroll"`
+	DeadCap        float64 `json:"dead_cap"`
+	RetainedSalary float64 `json:"retained_salary"`
+	TotalPayroll   float64 `json:"total_payroll"`
+	LuxuryTaxLimit float64 `json:"luxury_tax_limit"`
+	TaxSpace       float64 `json:"tax_space"`
+	Delta          float64 `json:"delta"`
+}
+
+// ScenarioEffect describes what one move did, year by year.
+type ScenarioEffect struct {
+	Move    ScenarioMove    `json:"move"`
+	Summary string          `json:"summary"`
+	Active  map[int]float64 `json:"active"`   // change to active payroll
+	DeadCap map[int]float64 `json:"dead_cap"` // dead cap added
+	Warning string          `json:"warning,omitempty"`
+}
+
+type ScenarioResult struct {
+	Years   []ScenarioYear   `json:"years"`
+	Effects []ScenarioEffect `json:"effects"`
+}
+
+// GetContractLines returns contract lines for every player on a team.
+func GetContractLines(db *pgxpool.Pool, teamID string) ([]ContractLine, error) {
+	return queryContractLines(db, `p.team_id = $1`, teamID)
+}
+
+func getContractLine(db *pgxpool.Pool, playerID string) (*ContractLine, error) {
+	lines, err := queryContractLines(db, `p.id = $1`, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("player not found")
+	}
+	return &lines[0], nil
+}
+
+func queryContractLines(db *pgxpool.Pool, where string, arg string) ([]ContractLine, error) {
+	query := `SELECT p.id, p.first_name || ' ' || p.last_name, COALESCE(p.position, ''), COALESCE(p.team_id::TEXT, '')`
+	for y := 2026; y <= 2040; y++ {
+		query += fmt.Sprintf(", COALESCE(p.contract_%d, '')", y)
+	}
+	query += " FROM players p WHERE " + where + " ORDER BY p.last_name, p.first_name"
+
+	rows, err := db.Query(context.Background(), query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []ContractLine
+	for rows.Next() {
+		var l ContractLine
+		contracts := make([]string, 15)
+		dest := []interface{}{&l.PlayerID, &l.PlayerName, &l.Position, &l.TeamID}
+		for i := range contracts {
+			dest = append(dest, &contracts[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			continue
+		}
+		l.Salaries = make(map[int]float64)
+		l.Labels = make(map[int]string)
+		for i, raw := range contracts {
+			if raw == "" {
+				continue
+			}
+			year := 2026 + i
+			if amt := parseContractAmount(raw); amt > 0 {
+				l.Salaries[year] = amt
+			} else {
+				l.Labels[year] = raw
+			}
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// EvaluatePayrollScenario applies moves to the team's committed payroll (CalculateYearlySummary)
+// for startYear through 2040. Moves are applied in order; a player can only leave the roster once.
+func EvaluatePayrollScenario(db *pgxpool.Pool, teamID string, startYear int, moves []ScenarioMove) (*ScenarioResult, error) {
+	ctx := context.Background()
+	var leagueID string
+	if err := db.QueryRow(ctx, `SELECT league_id FROM teams WHERE id = $1`, teamID).Scan(&leagueID); err != nil {
+		return nil, fmt.Errorf("team not found")
+	}
+	if startYear < 2026 {
+		startYear = 2026
+	}
+
+	roster, err := GetContractLines(db, teamID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*ContractLine)
+	for i := range roster {
+		byID[roster[i].PlayerID] = &roster[i]
+	}
+
+	activeDelta := make(map[int]float64)
+	deadDelta := make(map[int]float64)
+	departed := make(map[string]bool)
+	result := &ScenarioResult{}
+
+	for _, m := range moves {
+		e := ScenarioEffect{Move: m, Active: make(map[int]float64), DeadCap: make(map[int]float64)}
+		from := m.FromYear
+		if from < startYear {
+			from = startYear
+		}
+		p := byID[m.PlayerID]
+		name := m.PlayerName
+		if p != nil {
+			name = p.PlayerName
+		}
+
+		switch m.Type {
+		case "release", "trade_out":
+			if p == nil {
+				e.Warning = "player is not on this roster"
+				break
+			}
+			if departed[m.PlayerID] {
+				e.Warning = "player already leaves the roster in this scenario"
+				break
+			}
+			departed[m.PlayerID] = true
+			for y := from; y <= 2040; y++ {
+				if sal := p.Salaries[y]; sal > 0 {
+					e.Active[y] -= sal
+				}
+			}
+			if m.Type == "release" {
+				if salaries, err := releaseSalaries(ctx, db, m.PlayerID); err == nil {
+					e.DeadCap = ReleaseDeadCapSchedule(salaries, from)
+				}
+			}
+			if m.Type == "release" {
+				e.Summary = fmt.Sprintf("Release %s (%d)", name, from)
+			} else {
+				e.Summary = fmt.Sprintf("Trade away %s (%d)", name, from)
+			}
+
+		case "trade_in":
+			line, err := getContractLine(db, m.PlayerID)
+			if err != nil || line.TeamID == teamID {
+				e.Warning = "player not found on another team"
+				break
+			}
+			name = line.PlayerName
+			for y := from; y <= 2040; y++ {
+				if sal := line.Salaries[y]; sal > 0 {
+					e.Active[y] += sal
+				}
+			}
+			e.Summary = fmt.Sprintf("Acquire %s (%d)", name, from)
+
+		case "option":
+			if p == nil {
+				e.Warning = "player is not on this roster"
+				break
+			}
+			if m.Decision != "decline" {
+				e.Summary = fmt.Sprintf("Exercise %s %d option", name, m.Year)
+				break
+			}
+			if departed[m.PlayerID] {
+				e.Warning = "player already leaves the roster in this scenario"
+				break
+			}
+			departed[m.PlayerID] = true
+			for y := m.Year; y <= 2040; y++ {
+				if y >= startYear && p.Salaries[y] > 0 {
+					e.Active[y] -= p.Salaries[y]
+				}
+			}
+			// Same buyout a real decline charges (ProcessOptionDecision)
+			var clauseBuyout float64
+			db.QueryRow(ctx, `
+				SELECT COALESCE(buyout, 0) FROM contract_options
+				WHERE player_id = $1 AND year = $2 AND option_type IN ('team', 'mutual') AND status = 'pending'
+				ORDER BY created_at DESC LIMIT 1
+			`, m.PlayerID, m.Year).Scan(&clauseBuyout)
+			buyout := declinedOptionBuyout(clauseBuyout, p.Salaries[m.Year])
+			if buyout > 0 && m.Year >= startYear {
+				e.DeadCap[m.Year] += buyout
+			}
+			e.Summary = fmt.Sprintf("Decline %s %d option", name, m.Year)
+
+		case "arbitration":
+			if p == nil {
+				e.Warning = "player is not on this roster"
+				break
+			}
+			amt := m.Salaries[strconv.Itoa(m.Year)]
+			if m.Year >= startYear {
+				e.Active[m.Year] += amt - p.Salaries[m.Year]
+			}
+			e.Summary = fmt.Sprintf("%s %d arbitration at $%.0f", name, m.Year, amt)
+
+		case "sign":
+			for ys, amt := range m.Salaries {
+				y, err := strconv.Atoi(ys)
+				if err != nil || y < startYear || y > 2040 || amt <= 0 {
+					continue
+				}
+				e.Active[y] += amt
+			}
+			if name == "" {
+				name = "free agent"
+			}
+			e.Summary = fmt.Sprintf("Sign %s", name)
+
+		default:
+			e.Warning = fmt.Sprintf("unknown move type %q", m.Type)
+		}
+
+		for y, v := range e.Active {
+			activeDelta[y] += v
+		}
+		for y, v := range e.DeadCap {
+			deadDelta[y] += v
+		}
+		if e.Summary == "" {
+			e.Summary = fmt.Sprintf("%s %s", m.Type, name)
+		}
+		result.Effects = append(result.Effects, e)
+	}
+
+	for y := startYear; y <= 2040; y++ {
+		base := CalculateYearlySummary(db, teamID, leagueID, y)
+		sy := ScenarioYear{
+			Year:           y,
+			BaseTotal:      base.TotalPayroll,
+			ActivePayroll:  base.ActivePayroll + activeDelta[y],
+			DeadCap:        base.DeadCap + deadDelta[y],
+			RetainedSalary: base.RetainedSalary,
+			LuxuryTaxLimit: base.LuxuryTaxLimit,
+		}
+		sy.TotalPayroll = sy.ActivePayroll + sy.DeadCap + sy.RetainedSalary
+		sy.Delta = sy.TotalPayroll - sy.BaseTotal
+		if sy.LuxuryTaxLimit > 0 {
+			sy.TaxSpace = sy.LuxuryTaxLimit - sy.TotalPayroll
+		}
+		result.Years = append(result.Years, sy)
+	}
+	return result, nil
+}
+
+// SavePayrollScenario creates a scenario (empty s.ID) or updates one owned by s.CreatedBy.
+// Returns the scenario ID.
+func SavePayrollScenario(db *pgxpool.Pool, s PayrollScenario) (string, error) {
+	movesJSON, err := json.Marshal(s.Moves)
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	if s.ID == "" {
+		err = db.QueryRow(ctx, `
+			INSERT INTO payroll_scenarios (team_id, created_by, name, notes, start_year, moves, shared)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, s.TeamID, s.CreatedBy, s.Name, s.Notes, s.StartYear, movesJSON, s.Shared).Scan(&s.ID)
+		return s.ID, err
+	}
+	result, err := db.Exec(ctx, `
+		UPDATE payroll_scenarios SET name = $3, notes = $4, start_year = $5, moves = $6, shared = $7, updated_at = NOW()
+		WHERE id = $1 AND created_by = $2
+	`, s.ID, s.CreatedBy, s.Name, s.Notes, s.StartYear, movesJSON, s.Shared)
+	if err != nil {
+		return "", err
+	}
+	if result.RowsAffected() == 0 {
+		return "", fmt.Errorf("only the scenario's creator can edit it")
+	}
+	return s.ID, nil
+}
+
+// DeletePayrollScenario removes a scenario. Only its creator can delete it.
+func DeletePayrollScenario(db *pgxpool.Pool, scenarioID, userID string) error {
+	result, err := db.Exec(context.Background(), `DELETE FROM payroll_scenarios WHERE id = $1 AND created_by = $2`, scenarioID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("only the scenario's creator can delete it")
+	}
+	return nil
+}
+
+const payrollScenarioSelect = `
+	SELECT ps.id, ps.team_id, ps.created_by, COALESCE(u.username, ''), ps.name, COALESCE(ps.notes, ''),
+	       ps.start_year, ps.moves, COALESCE(ps.shared, FALSE), ps.updated_at
+	FROM payroll_scenarios ps
+	LEFT JOIN users u ON ps.created_by = u.id
+`
+
+func scanPayrollScenarios(db *pgxpool.Pool, where string, args ...interface{}) ([]PayrollScenario, error) {
+	rows, err := db.Query(context.Background(), payrollScenarioSelect+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scenarios []PayrollScenario
+	for rows.Next() {
+		var s PayrollScenario
+		var movesRaw []byte
+		if err := rows.Scan(&s.ID, &s.TeamID, &s.CreatedBy, &s.CreatedByName, &s.Name, &s.Notes,
+			&s.StartYear, &movesRaw, &s.Shared, &s.UpdatedAt); err != nil {
+			continue
+		}
+		json.Unmarshal(movesRaw, &s.Moves)
+		scenarios = append(scenarios, s)
+	}
+	return scenarios, nil
+}
+
+// GetPayrollScenarios returns the team's scenarios visible to userID: their own plus any a
+// co-owner has shared. Most recently edited first.
+func GetPayrollScenarios(db *pgxpool.Pool, teamID, userID string) ([]PayrollScenario, error) {
+	scenarios, err := scanPayrollScenarios(db, `
+		WHERE ps.team_id = $1 AND (ps.created_by = $2 OR ps.shared = TRUE)
+		ORDER BY ps.updated_at DESC
+	`, teamID, userID)
+	sort.SliceStable(scenarios, func(i, j int) bool {
+		return scenarios[i].CreatedBy == userID && scenarios[j].CreatedBy != userID
+	})
+	return scenarios, err
+}
+
+// GetPayrollScenario returns a scenario if userID created it or it has been shared with the team.
+func GetPayrollScenario(db *pgxpool.Pool, scenarioID, userID string) (*PayrollScenario, error) {
+	scenarios, err := scanPayrollScenarios(db, `WHERE ps.id = $1 AND (ps.created_by = $2 OR ps.shared = TRUE)`, scenarioID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(scenarios) == 0 {
+		return nil, fmt.Errorf("scenario not found")
+	}
+	return &scenarios[0], nil
+}
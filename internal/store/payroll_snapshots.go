@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Payroll Snapshots ---
+
+// PayrollSnapshot is a team's CalculateYearlySummary for one contract year as of a given date.
+type PayrollSnapshot struct {
+	SnapshotDate   string  `json:"snapshot_date"` // YYYY-MM-DD
+	TeamID         string  `json:"team_id"`
+	TeamName       string  `json:"team_name"`
+	LeagueID       string  `json:"league_id"`
+	Year           int     `json:"year"`
+	ActivePayroll  float64 `json:"active_payroll"`
+	DeadCap        float64 `json:"dead_cap"`
+	RetainedSalary float64 `json:"retained_salary"`
+	TotalPayroll   float64 `json:"total_payroll"`
+	LuxuryTaxLimit float64 `json:"luxury_tax_limit"`
+	TaxSpace       float64 `json:"tax_space"`
+}
+
+// SnapshotPayrolls records every team's payroll for the current contract year through 2040 as of
+// date. Years with no payroll, dead cap or retained salary are skipped unless the team has an
+// earlier snapshot for that year, so a payroll that drops to zero is recorded. Rerunning for the same
+// date overwrites that day's snapshot. Returns the number of rows written.
+func SnapshotPayrolls(db *pgxpool.Pool, date time.Time) (int, error) {
+	ctx := context.Background()
+	rows, err := db.Query(ctx, `SELECT id, league_id FROM teams WHERE league_id IS NOT NULL`)
+	if err != nil {
+		return 0, err
+	}
+	type teamRef struct{ ID, LeagueID string }
+	var teams []teamRef
+	for rows.Next() {
+		var t teamRef
+		if err := rows.Scan(&t.ID, &t.LeagueID); err == nil {
+			teams = append(teams, t)
+		}
+	}
+	rows.Close()
+
+	startYear := date.Year()
+	if startYear < 2026 {
+		startYear = 2026
+	}
+	day := date.Format("2006-01-02")
+
+	// Team-years with an earlier snapshot: a drop to zero must be recorded or GetPayrollAsOf keeps
+	// returning the old figure
+	snapshotted := make(map[string]bool)
+	prior, err := db.Query(ctx, `
+		SELECT DISTINCT team_id, year FROM payroll_snapshots WHERE snapshot_date < $1::date AND year >= $2
+	`, day, startYear)
+	if err != nil {
+		return 0, err
+	}
+	for prior.Next() {
+		var teamID string
+		var year int
+		if err := prior.Scan(&teamID, &year); err == nil {
+			snapshotted[fmt.Sprintf("%s|%d", teamID, year)] = true
+		}
+	}
+	prior.Close()
+
+	written := 0
+	for _, t := range teams {
+		for year := startYear; year <= 2040; year++ {
+			s := CalculateYearlySummary(db, t.ID, t.LeagueID, year)
+			if s.TotalPayroll == 0 && !snapshotted[fmt.Sprintf("%s|%d", t.ID, year)] {
+				continue
+			}
+			_, err := db.Exec(ctx, `
+				INSERT INTO payroll_snapshots (snapshot_date, team_id, league_id, year, active_payroll, dead_cap,
+				                               retained_salary, total_payroll, luxury_tax_limit, tax_space)
+				VALUES ($1::date, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+				ON CONFLICT (snapshot_date, team_id, year) DO UPDATE SET
+					active_payroll = EXCLUDED.active_payroll, dead_cap = EXCLUDED.dead_cap,
+					retained_salary = EXCLUDED.retained_salary, total_payroll = EXCLUDED.total_payroll,
+					luxury_tax_limit = EXCLUDED.luxury_tax_limit, tax_space = EXCLUDED.tax_space,
+					created_at = NOW()
+			`, day, t.ID, t.LeagueID, year, s.ActivePayroll, s.DeadCap, s.RetainedSalary, s.TotalPayroll, s.LuxuryTaxLimit, s.TaxSpace)
+			if err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+	return written, nil
+}
+
+const payrollSnapshotSelect = `
+	SELECT to_char(ps.snapshot_date, 'YYYY-MM-DD'), ps.team_id, COALESCE(t.name, ''), ps.league_id, ps.year,
+	       ps.active_payroll, ps.dead_cap, ps.retained_salary, ps.total_payroll, ps.luxury_tax_limit, ps.tax_space
+	FROM payroll_snapshots ps
+	LEFT JOIN teams t ON ps.team_id = t.id
+`
+
+func queryPayrollSnapshots(db *pgxpool.Pool, query string, args ...interface{}) ([]PayrollSnapshot, error) {
+	rows, err := db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snaps []PayrollSnapshot
+	for rows.Next() {
+		var s PayrollSnapshot
+		if err := rows.Scan(&s.SnapshotDate, &s.TeamID, &s.TeamName, &s.LeagueID, &s.Year,
+			&s.ActivePayroll, &s.DeadCap, &s.RetainedSalary, &s.TotalPayroll, &s.LuxuryTaxLimit, &s.TaxSpace); err != nil {
+			continue
+		}
+		snaps = append(snaps, s)
+	}
+	return snaps, nil
+}
+
+// GetTeamPayrollHistory returns a team's daily snapshots for one contract year, oldest first.
+// from/to (YYYY-MM-DD) are optional bounds.
+func GetTeamPayrollHistory(db *pgxpool.Pool, teamID string, year int, from, to string) ([]PayrollSnapshot, error) {
+	return queryPayrollSnapshots(db, payrollSnapshotSelect+`
+		WHERE ps.team_id = $1 AND ps.year = $2
+		  AND ($3 = '' OR ps.snapshot_date >= $3::date)
+		  AND ($4 = '' OR ps.snapshot_date <= $4::date)
+		ORDER BY ps.snapshot_date
+	`, teamID, year, from, to)
+}
+
+// GetLeaguePayrollHistory returns every team's daily snapshots in a league for one contract year,
+// ordered by team then date.
+func GetLeaguePayrollHistory(db *pgxpool.Pool, leagueID string, year int, from, to string) ([]PayrollSnapshot, error) {
+	return queryPayrollSnapshots(db, payrollSnapshotSelect+`
+		WHERE ps.league_id = $1 AND ps.year = $2
+		  AND ($3 = '' OR ps.snapshot_date >= $3::date)
+		  AND ($4 = '' OR ps.snapshot_date <= $4::date)
+		ORDER BY t.name, ps.snapshot_date
+	`, leagueID, year, from, to)
+}
+
+// GetPayrollAsOf returns each team's most recent snapshot on or before date (YYYY-MM-DD) for one
+// contract year. Pass a teamID to limit to one team, otherwise every team in leagueID.
+func GetPayrollAsOf(db *pgxpool.Pool, leagueID, teamID string, year int, date string) ([]PayrollSnapshot, error) {
+	return queryPayrollSnapshots(db, `
+		SELECT * FROM (
+			SELECT DISTINCT ON (ps.team_id)
+			       to_char(ps.snapshot_date, 'YYYY-MM-DD'), ps.team_id, COALESCE(t.name, '') AS team_name, ps.league_id, ps.year,
+			       ps.active_payroll, ps.dead_cap, ps.retained_salary, ps.total_payroll, ps.luxury_tax_limit, ps.tax_space
+			FROM payroll_snapshots ps
+			LEFT JOIN teams t ON ps.team_id = t.id
+			WHERE ps.year = $3 AND ps.snapshot_date <= $4::date
+			  AND (($2 <> '' AND ps.team_id::text = $2) OR ($2 = '' AND ps.league_id::text = $1))
+			ORDER BY ps.team_id, ps.snapshot_date DESC
+		) latest
+		ORDER BY total_payroll DESC
+	`, leagueID, teamID, year, date)
+}
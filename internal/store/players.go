@@ -28,7 +28,9 @@ func GetFreeAgents(db *pgxpool.Pool, filter PlayerSearchFilter) ([]RosterPlayer,
 	query := `
 		SELECT id, first_name, last_name, position, mlb_team, COALESCE(fa_status, ''),
 		       COALESCE(contract_2026, ''), COALESCE(is_international_free_agent, FALSE),
-		       COALESCE(is_minor_leaguer, FALSE)
+		       COALESCE(is_minor_leaguer, FALSE),
+		       EXISTS (SELECT 1 FROM qualifying_offers qo
+		               WHERE qo.player_id = players.id AND qo.status = 'declined' AND qo.signed_team_id IS NULL)
 		FROM players
 		WHERE (team_id IS NULL OR team_id = '00000000-0000-0000-0000-000000000000')
 		AND league_id = $1
@@ -74,7 +76,7 @@ func GetFreeAgents(db *pgxpool.Pool, filter PlayerSearchFilter) ([]RosterPlayer,
 		var p RosterPlayer
 		p.Contracts = make(map[int]string)
 		var rawStatus, c26 string
-		if err := rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Position, &p.MLBTeam, &rawStatus, &c26, &p.IsIFA, &p.IsMinorLeaguer); err != nil {
+		if err := rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Position, &p.MLBTeam, &rawStatus, &c26, &p.IsIFA, &p.IsMinorLeaguer, &p.QOAttached); err != nil {
 			continue
 		}
 		p.Contracts[2026] = c26
@@ -190,7 +192,7 @@ func GetPlayerByID(db *pgxpool.Pool, id string) (*RosterPlayer, error) {
 		       COALESCE(p.roster_moves_log, '[]'::jsonb),
 		       COALESCE(p.is_international_free_agent, FALSE),
 		       COALESCE(p.dfa_only, FALSE),
-		       COALESCE(p.is_minor_leaguer, FALSE),
+		       COALESCE(p.is_minor_leaguer, FALSE), p.is_two_way,
 		       p.bid_end_time, COALESCE(p.pending_bid_amount, 0),
 		       COALESCE((SELECT t.name FROM teams t WHERE t.id = p.pending_bid_team_id), ''),
 		       COALESCE(p.contract_2026, ''), COALESCE(p.contract_2027, ''), COALESCE(p.contract_2028, ''),
@@ -207,7 +209,7 @@ func GetPlayerByID(db *pgxpool.Pool, id string) (*RosterPlayer, error) {
 		&p.ID, &p.FirstName, &p.LastName, &p.Position, &p.MLBTeam, &rawStatus,
 		&p.Status40Man, &p.Status26Man, &p.StatusIL, &p.OptionYears, &p.OptionsThisSeason,
 		&teamID, &p.LeagueID, &p.LeagueName,
-		&p.Rule5Year, &movesLogRaw, &p.IsIFA, &p.DFAOnly, &p.IsMinorLeaguer,
+		&p.Rule5Year, &movesLogRaw, &p.IsIFA, &p.DFAOnly, &p.IsMinorLeaguer, &p.IsTwoWay,
 		&p.BidEndTime, &p.PendingBidAmount, &p.PendingBidTeamName,
 	}
 	for i := range contracts {
@@ -405,10 +407,12 @@ func ApprovePlayerRequest(db *pgxpool.Pool, requestID, reviewerID string) error
 	}
 
 	// Create the player as a free agent
-	_, err = tx.Exec(ctx, `
+	var playerID string
+	err = tx.QueryRow(ctx, `
 		INSERT INTO players (first_name, last_name, position, mlb_team, league_id, fa_status, is_international_free_agent)
 		VALUES ($1, $2, $3, $4, $5, 'available', $6)
-	`, req.FirstName, req.LastName, req.Position, req.MLBTeam, req.LeagueID, req.IsIFA)
+		RETURNING id
+	`, req.FirstName, req.LastName, req.Position, req.MLBTeam, req.LeagueID, req.IsIFA).Scan(&playerID)
 	if err != nil {
 		return fmt.Errorf("failed to create player: %w", err)
 	}
@@ -426,6 +430,11 @@ func ApprovePlayerRequest(db *pgxpool.Pool, requestID, reviewerID string) error
 		return err
 	}
 
+	// Link to the canonical record (shared with other leagues' copies of the same player)
+	if _, err := SyncPlayerCopyToMaster(db, playerID); err != nil {
+		fmt.Printf("ERROR [ApprovePlayerAddRequest]: canonical sync %s: %v\n", playerID, err)
+	}
+
 	// Log activity (outside transaction)
 	summary := fmt.Sprintf("Player added via request: %s %s (%s)", req.FirstName, req.LastName, req.Position)
 	LogActivity(db, req.LeagueID, "", "Added Player", summary)
@@ -0,0 +1,362 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Projections ---
+
+// ProjectionRow is one player's projected counting stats from an imported file, keyed by the
+// same stat keys the scoring categories use.
+type ProjectionRow struct {
+	MlbID      int                `json:"mlb_id"` // 0 when the file didn't supply one
+	PlayerName string             `json:"player_name"`
+	MLBTeam    string             `json:"mlb_team"`
+	Stats      map[string]float64 `json:"stats"`
+}
+
+// ProjectionImportResult summarizes one import.
+type ProjectionImportResult struct {
+	Imported  int `json:"imported"`
+	Rematched int `json:"rematched"` // imported rows matched by an earlier review
+	Queued    int `json:"queued"`    // rows held for review
+}
+
+// ProjectionSummary is what's loaded for one season and stat type.
+type ProjectionSummary struct {
+	Season     int       `json:"season"`
+	StatType   string    `json:"stat_type"`
+	Source     string    `json:"source"`
+	Players    int       `json:"players"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// ProjectionCandidate is a known player whose name matches a queued row.
+type ProjectionCandidate struct {
+	MlbID   int    `json:"mlb_id"`
+	Name    string `json:"name"`
+	MLBTeam string `json:"mlb_team"`
+}
+
+// ProjectionQueueItem is an imported row waiting for a commissioner to match or dismiss.
+type ProjectionQueueItem struct {
+	ID         string                `json:"id"`
+	Season     int                   `json:"season"`
+	StatType   string                `json:"stat_type"`
+	Source     string                `json:"source"`
+	PlayerName string                `json:"player_name"`
+	MLBTeam    string                `json:"mlb_team"`
+	MlbID      int                   `json:"mlb_id"`
+	Stats      map[string]float64    `json:"stats"`
+	CreatedAt  time.Time             `json:"created_at"`
+	Candidates []ProjectionCandidate `json:"candidates"`
+}
+
+// ImportProjections replaces a season and stat type's projections with a file. Rows whose MLB ID
+// is a known player are stored; rows a commissioner matched in an earlier import (same name and
+// team) take that match; the rest go to the review queue. Pending queue rows from an earlier
+// import of the same season and stat type are replaced.
+func ImportProjections(db *pgxpool.Pool, season int, statType, source string, rows []ProjectionRow) (ProjectionImportResult, error) {
+	ctx := context.Background()
+	var result ProjectionImportResult
+	if statType != "hitting" && statType != "pitching" {
+		return result, fmt.Errorf("unknown stat type %q", statType)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback(ctx)
+
+	var ids []int
+	for _, r := range rows {
+		if r.MlbID > 0 {
+			ids = append(ids, r.MlbID)
+		}
+	}
+	known := make(map[int]bool)
+	idRows, err := tx.Query(ctx, `SELECT mlb_id FROM mlb_players WHERE mlb_id = ANY($1)`, ids)
+	if err != nil {
+		return result, err
+	}
+	for idRows.Next() {
+		var id int
+		if err := idRows.Scan(&id); err == nil {
+			known[id] = true
+		}
+	}
+	idRows.Close()
+
+	// Earlier review decisions, latest first, keyed by name and team
+	resolved := make(map[string]int)
+	resRows, err := tx.Query(ctx, `
+		SELECT DISTINCT ON (LOWER(player_name), LOWER(mlb_team)) LOWER(player_name), LOWER(mlb_team), resolved_mlb_id
+		FROM projection_review_queue
+		WHERE status = 'resolved' AND resolved_mlb_id IS NOT NULL AND player_name <> ''
+		ORDER BY LOWER(player_name), LOWER(mlb_team), resolved_at DESC
+	`)
+	if err != nil {
+		return result, err
+	}
+	for resRows.Next() {
+		var name, team string
+		var id int
+		if err := resRows.Scan(&name, &team, &id); err == nil {
+			resolved[name+"|"+team] = id
+		}
+	}
+	resRows.Close()
+
+	_, err = tx.Exec(ctx, `DELETE FROM player_projections WHERE season = $1 AND stat_type = $2`, season, statType)
+	if err != nil {
+		return result, err
+	}
+	_, err = tx.Exec(ctx, `
+		DELETE FROM projection_review_queue WHERE season = $1 AND stat_type = $2 AND status = 'pending'
+	`, season, statType)
+	if err != nil {
+		return result, err
+	}
+
+	for _, r := range rows {
+		rawJSON, err := json.Marshal(r.Stats)
+		if err != nil {
+			return result, err
+		}
+		mlbID := 0
+		if known[r.MlbID] {
+			mlbID = r.MlbID
+		} else if id, ok := resolved[strings.ToLower(r.PlayerName)+"|"+strings.ToLower(r.MLBTeam)]; ok && r.PlayerName != "" {
+			mlbID = id
+			result.Rematched++
+		}
+		if mlbID > 0 {
+			if err := upsertProjection(ctx, tx, season, mlbID, statType, source, rawJSON); err != nil {
+				return result, err
+			}
+			result.Imported++
+			continue
+		}
+		var suppliedID interface{}
+		if r.MlbID > 0 {
+			suppliedID = r.MlbID
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO projection_review_queue (season, stat_type, source, player_name, mlb_team, mlb_id, raw_stats)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, season, statType, source, r.PlayerName, r.MLBTeam, suppliedID, rawJSON)
+		if err != nil {
+			return result, err
+		}
+		result.Queued++
+	}
+	return result, tx.Commit(ctx)
+}
+
+func upsertProjection(ctx context.Context, tx pgx.Tx, season, mlbID int, statType, source string, rawJSON []byte) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO player_projections (season, mlb_id, stat_type, source, raw_stats)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (season, mlb_id, stat_type) DO UPDATE SET
+			source = EXCLUDED.source, raw_stats = EXCLUDED.raw_stats, imported_at = NOW()
+	`, season, mlbID, statType, source, rawJSON)
+	return err
+}
+
+// GetProjectionSummaries lists the projections loaded for each season and stat type, newest first.
+func GetProjectionSummaries(db *pgxpool.Pool) ([]ProjectionSummary, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT season, stat_type, STRING_AGG(DISTINCT source, ', '), COUNT(*), MAX(imported_at)
+		FROM player_projections
+		GROUP BY season, stat_type
+		ORDER BY season DESC, stat_type
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []ProjectionSummary
+	for rows.Next() {
+		var s ProjectionSummary
+		if err := rows.Scan(&s.Season, &s.StatType, &s.Source, &s.Players, &s.ImportedAt); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
+
+// GetProjectionQueue returns pending review rows with up to three known players matching each name.
+func GetProjectionQueue(db *pgxpool.Pool) ([]ProjectionQueueItem, error) {
+	ctx := context.Background()
+	rows, err := db.Query(ctx, `
+		SELECT id, season, stat_type, source, player_name, mlb_team, COALESCE(mlb_id, 0), raw_stats, created_at
+		FROM projection_review_queue
+		WHERE status = 'pending'
+		ORDER BY season DESC, stat_type, player_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	var items []ProjectionQueueItem
+	for rows.Next() {
+		var q ProjectionQueueItem
+		var rawJSON []byte
+		if err := rows.Scan(&q.ID, &q.Season, &q.StatType, &q.Source, &q.PlayerName, &q.MLBTeam, &q.MlbID, &rawJSON, &q.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		json.Unmarshal(rawJSON, &q.Stats)
+		items = append(items, q)
+	}
+	rows.Close()
+
+	for i := range items {
+		if items[i].PlayerName == "" {
+			continue
+		}
+		cands, err := db.Query(ctx, `
+			SELECT mlb_id, first_name || ' ' || last_name, COALESCE(mlb_team, '')
+			FROM mlb_players
+			WHERE mlb_id IS NOT NULL AND LOWER(first_name || ' ' || last_name) = LOWER($1)
+			ORDER BY (COALESCE(mlb_team, '') = $2) DESC
+			LIMIT 3
+		`, items[i].PlayerName, items[i].MLBTeam)
+		if err != nil {
+			continue
+		}
+		for cands.Next() {
+			var c ProjectionCandidate
+			if err := cands.Scan(&c.MlbID, &c.Name, &c.MLBTeam); err == nil {
+				items[i].Candidates = append(items[i].Candidates, c)
+			}
+		}
+		cands.Close()
+	}
+	return items, nil
+}
+
+// ResolveProjectionQueueItem matches a queued row to a known MLB ID and stores its projection.
+func ResolveProjectionQueueItem(db *pgxpool.Pool, id string, mlbID int, userID string) error {
+	ctx := context.Background()
+	var exists bool
+	db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM mlb_players WHERE mlb_id = $1)`, mlbID).Scan(&exists)
+	if !exists {
+		return fmt.Errorf("no player with MLB ID %d", mlbID)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var season int
+	var statType, source string
+	var rawJSON []byte
+	err = tx.QueryRow(ctx, `
+		UPDATE projection_review_queue
+		SET status = 'resolved', resolved_mlb_id = $2, resolved_by = $3, resolved_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING season, stat_type, source, raw_stats
+	`, id, mlbID, userID).Scan(&season, &statType, &source, &rawJSON)
+	if err != nil {
+		return fmt.Errorf("queue item not found or already handled")
+	}
+	if err := upsertProjection(ctx, tx, season, mlbID, statType, source, rawJSON); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DismissProjectionQueueItem drops a queued row without importing it.
+func DismissProjectionQueueItem(db *pgxpool.Pool, id, userID string) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE projection_review_queue
+		SET status = 'dismissed', resolved_by = $2, resolved_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, id, userID)
+	return err
+}
+
+// GetProjectionSeason returns the season a league's projections are shown for: next season once
+// the league has rolled over into it and its projections are loaded, otherwise the current season.
+// Projections are scored with that season's rules, which the rollover creates.
+func GetProjectionSeason(db *pgxpool.Pool, leagueID string, currentYear int) int {
+	season := currentYear
+	db.QueryRow(context.Background(), `
+		SELECT CASE WHEN EXISTS (SELECT 1 FROM season_rollovers WHERE league_id = $1 AND to_year = $2 + 1)
+		             AND EXISTS (SELECT 1 FROM player_projections WHERE season = $2 + 1)
+		            THEN $2 + 1 ELSE $2 END
+	`, leagueID, currentYear).Scan(&season)
+	return season
+}
+
+// GetProjectedPoints scores players' projections for a season with a league's scoring rules.
+// Hitting and pitching projections are added together, so two-way players get both.
+// Players without a projection are absent from the map. A nil playerIDs scores every player
+// in the league.
+func GetProjectedPoints(db *pgxpool.Pool, leagueID string, season int, playerIDs []string) (map[string]float64, error) {
+	ctx := context.Background()
+	result := make(map[string]float64)
+	if playerIDs != nil && len(playerIDs) == 0 {
+		return result, nil
+	}
+
+	maps := make(map[string]map[string]float64)
+	for _, statType := range []string{"pitching", "hitting"} {
+		m, err := GetLeagueScoringMap(db, leagueID, season, statType)
+		if err != nil {
+			return nil, err
+		}
+		maps[statType] = m
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT p.id, pp.stat_type, pp.raw_stats
+		FROM players p
+		JOIN player_projections pp ON pp.mlb_id = p.mlb_id AND pp.season = $1
+		WHERE CASE WHEN $2::TEXT[] IS NULL THEN p.league_id::TEXT = $3 ELSE p.id::TEXT = ANY($2) END
+	`, season, playerIDs, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerID, statType string
+		var rawJSON []byte
+		if err := rows.Scan(&playerID, &statType, &rawJSON); err != nil {
+			continue
+		}
+		var raw map[string]float64
+		json.Unmarshal(rawJSON, &raw)
+		result[playerID] += CalculateFantasyPoints(raw, maps[statType])
+	}
+	return result, rows.Err()
+}
+
+// ApplyProjectedPoints fills ProjectedPoints on players from one league for a season.
+func ApplyProjectedPoints(db *pgxpool.Pool, leagueID string, season int, players []RosterPlayer) error {
+	ids := make([]string, len(players))
+	for i, p := range players {
+		ids[i] = p.ID
+	}
+	proj, err := GetProjectedPoints(db, leagueID, season, ids)
+	if err != nil {
+		return err
+	}
+	for i := range players {
+		players[i].ProjectedPoints = proj[players[i].ID]
+	}
+	return nil
+}
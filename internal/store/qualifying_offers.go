@@ -0,0 +1,428 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Qualifying Offers ---
+
+// QualifyingOfferRules are the per-league QO settings stored on league_settings.
+type QualifyingOfferRules struct {
+	Enabled            bool    `json:"enabled"`
+	TopN               int     `json:"top_n"`             // offer amount = average of the top N salaries
+	AcceptRule         string  `json:"accept_rule"`       // 'commissioner', 'always_accept', 'always_decline', 'salary'
+	CompensationType   string  `json:"compensation_type"` // 'none', 'isbp', 'milb'
+	CompensationAmount float64 `json:"compensation_amount"`
+	SigningTeamPays    bool    `json:"signing_team_pays"` // false = the league grants the compensation
+}
+
+type QualifyingOffer struct {
+	ID                 string     `json:"id"`
+	LeagueID           string     `json:"league_id"`
+	PlayerID           string     `json:"player_id"`
+	PlayerName         string     `json:"player_name"`
+	TeamID             string     `json:"team_id"`
+	TeamName           string     `json:"team_name"`
+	Season             int        `json:"season"`
+	Amount             float64    `json:"amount"`
+	ExpiringSalary     float64    `json:"expiring_salary"`
+	Status             string     `json:"status"` // 'tendered', 'accepted', 'declined'
+	DecidedBy          string     `json:"decided_by"`
+	SignedTeamName     string     `json:"signed_team_name"`
+	CompensationType   string     `json:"compensation_type"`
+	CompensationAmount float64    `json:"compensation_amount"`
+	CompensatedAt      *time.Time `json:"compensated_at"`
+}
+
+// QOCandidate is a player on an expiring contract who can be tendered a qualifying offer.
+type QOCandidate struct {
+	PlayerID       string  `json:"player_id"`
+	PlayerName     string  `json:"player_name"`
+	Position       string  `json:"position"`
+	ExpiringSalary float64 `json:"expiring_salary"`
+	OfferID        string  `json:"offer_id"`
+	OfferStatus    string  `json:"offer_status"`
+}
+
+func GetQualifyingOfferRules(db *pgxpool.Pool, leagueID string, season int) QualifyingOfferRules {
+	r := QualifyingOfferRules{TopN: 125, AcceptRule: "salary", CompensationType: "isbp", SigningTeamPays: true}
+	db.QueryRow(context.Background(), `
+		SELECT COALESCE(qo_enabled, FALSE), COALESCE(qo_top_n, 125), COALESCE(qo_accept_rule, 'salary'),
+		       COALESCE(qo_compensation_type, 'isbp'), COALESCE(qo_compensation_amount, 0), COALESCE(qo_signing_team_pays, TRUE)
+		FROM league_settings WHERE league_id = $1 AND year = $2
+	`, leagueID, season).Scan(&r.Enabled, &r.TopN, &r.AcceptRule, &r.CompensationType, &r.CompensationAmount, &r.SigningTeamPays)
+	return r
+}
+
+func UpsertQualifyingOfferRules(db *pgxpool.Pool, leagueID string, season int, r QualifyingOfferRules) error {
+	switch r.AcceptRule {
+	case "commissioner", "always_accept", "always_decline":
+	default:
+		r.AcceptRule = "salary"
+	}
+	switch r.CompensationType {
+	case "isbp", "milb":
+	default:
+		r.CompensationType = "none"
+	}
+	if r.TopN <= 0 {
+		r.TopN = 125
+	}
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO league_settings (league_id, year, qo_enabled, qo_top_n, qo_accept_rule,
+			qo_compensation_type, qo_compensation_amount, qo_signing_team_pays)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (league_id, year) DO UPDATE SET
+			qo_enabled = EXCLUDED.qo_enabled,
+			qo_top_n = EXCLUDED.qo_top_n,
+			qo_accept_rule = EXCLUDED.qo_accept_rule,
+			qo_compensation_type = EXCLUDED.qo_compensation_type,
+			qo_compensation_amount = EXCLUDED.qo_compensation_amount,
+			qo_signing_team_pays = EXCLUDED.qo_signing_team_pays
+	`, leagueID, season, r.Enabled, r.TopN, r.AcceptRule, r.CompensationType, r.CompensationAmount, r.SigningTeamPays)
+	return err
+}
+
+// ComputeQualifyingOfferAmount averages the league's top N salaries for the season,
+// rounded to the nearest $10K.
+func ComputeQualifyingOfferAmount(db *pgxpool.Pool, leagueID string, season int) (float64, error) {
+	if season < 2026 || season > 2040 {
+		return 0, fmt.Errorf("no contract column for %d", season)
+	}
+	rows, err := db.Query(context.Background(), fmt.Sprintf(`
+		SELECT COALESCE(contract_%d, '') FROM players
+		WHERE league_id = $1 AND team_id IS NOT NULL
+	`, season), leagueID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var salaries []float64
+	for rows.Next() {
+		var val string
+		if err := rows.Scan(&val); err != nil {
+			continue
+		}
+		if amt := parseContractAmount(val); amt > 0 {
+			salaries = append(salaries, amt)
+		}
+	}
+	if len(salaries) == 0 {
+		return 0, fmt.Errorf("no %d salaries found for this league", season)
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(salaries)))
+	n := GetQualifyingOfferRules(db, leagueID, season).TopN
+	if n > len(salaries) {
+		n = len(salaries)
+	}
+	var total float64
+	for _, s := range salaries[:n] {
+		total += s
+	}
+	return float64(int64(total/float64(n)/10000+0.5)) * 10000, nil
+}
+
+// GetQOCandidates lists a team's players whose contracts expire after the season, with any offer already made.
+func GetQOCandidates(db *pgxpool.Pool, teamID string, season int) ([]QOCandidate, error) {
+	if season < 2026 || season >= 2040 {
+		return nil, fmt.Errorf("no contract columns for %d", season)
+	}
+	rows, err := db.Query(context.Background(), fmt.Sprintf(`
+		SELECT p.id, p.first_name || ' ' || p.last_name, p.position,
+		       COALESCE(p.contract_%d, ''), COALESCE(p.contract_%d, ''),
+		       COALESCE(qo.id::TEXT, ''), COALESCE(qo.status, '')
+		FROM players p
+		LEFT JOIN qualifying_offers qo ON qo.player_id = p.id AND qo.season = $2
+		WHERE p.team_id = $1
+		ORDER BY p.last_name
+	`, season, season+1), teamID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []QOCandidate
+	for rows.Next() {
+		var c QOCandidate
+		var current, next string
+		if err := rows.Scan(&c.PlayerID, &c.PlayerName, &c.Position, &current, &next, &c.OfferID, &c.OfferStatus); err != nil {
+			continue
+		}
+		if c.OfferID == "" && !isExpiringContract(current, next) {
+			continue
+		}
+		c.ExpiringSalary = parseContractAmount(current)
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// TenderQualifyingOffer extends a one-year offer for season + 1 at the league's QO amount.
+// The league comes from the team; offers close once the season's contract rollover has run.
+func TenderQualifyingOffer(db *pgxpool.Pool, teamID, playerID string, season int) (float64, error) {
+	leagueID, err := GetTeamLeagueID(db, teamID)
+	if err != nil {
+		return 0, fmt.Errorf("team not found")
+	}
+	if IsRolloverComplete(db, leagueID, season) {
+		return 0, fmt.Errorf("the %d contract rollover is complete; qualifying offers are closed", season)
+	}
+	rules := GetQualifyingOfferRules(db, leagueID, season)
+	if !rules.Enabled {
+		return 0, fmt.Errorf("qualifying offers are not enabled for this league")
+	}
+
+	candidates, err := GetQOCandidates(db, teamID, season)
+	if err != nil {
+		return 0, err
+	}
+	eligible := false
+	for _, c := range candidates {
+		if c.PlayerID == playerID {
+			if c.OfferID != "" {
+				return 0, fmt.Errorf("a qualifying offer was already made to this player")
+			}
+			eligible = true
+		}
+	}
+	if !eligible {
+		return 0, fmt.Errorf("player is not on an expiring contract with this team")
+	}
+
+	amount, err := ComputeQualifyingOfferAmount(db, leagueID, season)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = db.Exec(context.Background(), `
+		INSERT INTO qualifying_offers (league_id, player_id, team_id, season, amount)
+		VALUES ($1, $2, $3, $4, $5)
+	`, leagueID, playerID, teamID, season, amount)
+	return amount, err
+}
+
+// GetQualifyingOfferLeagueID returns the league an offer belongs to.
+func GetQualifyingOfferLeagueID(db *pgxpool.Pool, offerID string) (string, error) {
+	var leagueID string
+	err := db.QueryRow(context.Background(), `SELECT league_id FROM qualifying_offers WHERE id = $1`, offerID).Scan(&leagueID)
+	return leagueID, err
+}
+
+// GetQualifyingOffers lists a league's offers for a season, optionally filtered by status.
+func GetQualifyingOffers(db *pgxpool.Pool, leagueID string, season int, status string) ([]QualifyingOffer, error) {
+	query := fmt.Sprintf(`
+		SELECT qo.id, qo.league_id, qo.player_id, p.first_name || ' ' || p.last_name, COALESCE(qo.team_id::TEXT, ''),
+		       COALESCE(t.name, ''), qo.season, qo.amount, COALESCE(p.contract_%d, ''), qo.status,
+		       COALESCE(qo.decided_by, ''), COALESCE(st.name, ''), COALESCE(qo.compensation_type, ''),
+		       COALESCE(qo.compensation_amount, 0), qo.compensated_at
+		FROM qualifying_offers qo
+		JOIN players p ON qo.player_id = p.id
+		LEFT JOIN teams t ON qo.team_id = t.id
+		LEFT JOIN teams st ON qo.signed_team_id = st.id
+		WHERE qo.league_id = $1 AND qo.season = $2
+	`, season)
+	args := []interface{}{leagueID, season}
+	if status != "" {
+		query += " AND qo.status = $3"
+		args = append(args, status)
+	}
+	query += " ORDER BY t.name, p.last_name"
+
+	rows, err := db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var offers []QualifyingOffer
+	for rows.Next() {
+		var o QualifyingOffer
+		var expiring string
+		if err := rows.Scan(&o.ID, &o.LeagueID, &o.PlayerID, &o.PlayerName, &o.TeamID, &o.TeamName, &o.Season, &o.Amount,
+			&expiring, &o.Status, &o.DecidedBy, &o.SignedTeamName, &o.CompensationType, &o.CompensationAmount,
+			&o.CompensatedAt); err != nil {
+			continue
+		}
+		o.ExpiringSalary = parseContractAmount(expiring)
+		offers = append(offers, o)
+	}
+	return offers, nil
+}
+
+// QOAcceptDecision applies the league's accept rule. Returns ok=false when the commissioner decides.
+func QOAcceptDecision(rules QualifyingOfferRules, o QualifyingOffer) (accept bool, ok bool) {
+	switch rules.AcceptRule {
+	case "always_accept":
+		return true, true
+	case "always_decline":
+		return false, true
+	case "salary":
+		// A player only takes the one-year deal when it is at least what they just earned
+		return o.Amount >= o.ExpiringSalary, true
+	}
+	return false, false
+}
+
+// ResolveQualifyingOffer records the player's answer. Accepting writes the one-year salary for
+// season + 1; declining sends the player to free agency QO-attached (immediately if the
+// rollover already ran, otherwise at the rollover).
+func ResolveQualifyingOffer(db *pgxpool.Pool, offerID string, accept bool, decidedBy string) (string, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var playerID, playerName, leagueID, teamID, teamName, status string
+	var season int
+	var amount float64
+	err = tx.QueryRow(ctx, `
+		SELECT qo.player_id, p.first_name || ' ' || p.last_name, qo.league_id, COALESCE(qo.team_id::TEXT, ''),
+		       COALESCE(t.name, ''), qo.season, qo.amount, qo.status
+		FROM qualifying_offers qo
+		JOIN players p ON qo.player_id = p.id
+		LEFT JOIN teams t ON qo.team_id = t.id
+		WHERE qo.id = $1
+	`, offerID).Scan(&playerID, &playerName, &leagueID, &teamID, &teamName, &season, &amount, &status)
+	if err != nil {
+		return "", err
+	}
+	if status != "tendered" {
+		return "", fmt.Errorf("qualifying offer is already %s", status)
+	}
+
+	newStatus := "declined"
+	var summary, txType string
+	if accept {
+		newStatus = "accepted"
+		txType = "Roster Move"
+		_, err = tx.Exec(ctx, fmt.Sprintf("UPDATE players SET contract_%d = $1 WHERE id = $2", season+1),
+			fmt.Sprintf("%.0f", amount), playerID)
+		if err != nil {
+			return "", err
+		}
+		summary = fmt.Sprintf("%s accepted %s's qualifying offer: 1yr/$%.0f for %d", playerName, teamName, amount, season+1)
+	} else {
+		txType = "Dropped Player"
+		summary = fmt.Sprintf("%s declined %s's qualifying offer ($%.0f) and enters free agency with QO compensation attached",
+			playerName, teamName, amount)
+
+		var ran int
+		tx.QueryRow(ctx, `SELECT value FROM system_counters WHERE key = $1`, RolloverRunKey(leagueID, season)).Scan(&ran)
+		if ran > 0 {
+			if err := releaseFromYear(ctx, tx, playerID, season+1); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE qualifying_offers SET status = $1, decided_by = $2, resolved_at = NOW() WHERE id = $3`,
+		newStatus, decidedBy, offerID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (league_id, team_id, player_id, transaction_type, summary, status)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, 'COMPLETED')
+	`, leagueID, teamID, playerID, txType, summary)
+	if err != nil {
+		return "", err
+	}
+
+	return summary, tx.Commit(ctx)
+}
+
+// ApplyQualifyingOfferRule resolves every outstanding offer for the season using the league's
+// accept rule (no-op when the commissioner decides). Returns the summaries of resolved offers.
+func ApplyQualifyingOfferRule(db *pgxpool.Pool, leagueID string, season int) ([]string, error) {
+	rules := GetQualifyingOfferRules(db, leagueID, season)
+	offers, err := GetQualifyingOffers(db, leagueID, season, "tendered")
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []string
+	for _, o := range offers {
+		accept, ok := QOAcceptDecision(rules, o)
+		if !ok {
+			continue
+		}
+		summary, err := ResolveQualifyingOffer(db, o.ID, accept, "rule")
+		if err != nil {
+			fmt.Printf("ERROR [ApplyQualifyingOfferRule] %s: %v\n", o.PlayerName, err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// ApplyQOCompensation settles compensation when a QO-attached free agent signs, inside the
+// signing transaction. Re-signing with the original team owes nothing. Returns a summary
+// suffix for the signing transaction ("" when no compensation applies).
+func ApplyQOCompensation(ctx context.Context, tx pgx.Tx, playerID, signingTeamID string) (string, error) {
+	var offerID, leagueID, origTeamID, origTeamName string
+	var season int
+	err := tx.QueryRow(ctx, `
+		SELECT qo.id, qo.league_id, COALESCE(qo.team_id::TEXT, ''), COALESCE(t.name, ''), qo.season
+		FROM qualifying_offers qo
+		LEFT JOIN teams t ON qo.team_id = t.id
+		WHERE qo.player_id = $1 AND qo.status = 'declined' AND qo.signed_team_id IS NULL
+		ORDER BY qo.season DESC LIMIT 1
+	`, playerID).Scan(&offerID, &leagueID, &origTeamID, &origTeamName, &season)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var r QualifyingOfferRules
+	tx.QueryRow(ctx, `
+		SELECT COALESCE(qo_compensation_type, 'isbp'), COALESCE(qo_compensation_amount, 0), COALESCE(qo_signing_team_pays, TRUE)
+		FROM league_settings WHERE league_id = $1 AND year = $2
+	`, leagueID, season).Scan(&r.CompensationType, &r.CompensationAmount, &r.SigningTeamPays)
+
+	if origTeamID == "" || origTeamID == signingTeamID || r.CompensationAmount <= 0 ||
+		(r.CompensationType != "isbp" && r.CompensationType != "milb") {
+		_, err = tx.Exec(ctx, `UPDATE qualifying_offers SET signed_team_id = $1 WHERE id = $2`, signingTeamID, offerID)
+		return "", err
+	}
+
+	label := "ISBP"
+	if r.CompensationType == "milb" {
+		label = "MiLB"
+	}
+
+	if r.SigningTeamPays {
+		err = TransferBalance(ctx, tx, signingTeamID, origTeamID, r.CompensationType, r.CompensationAmount,
+			"qo_compensation", "qualifying_offer", offerID, "Qualifying offer compensation")
+	} else {
+		_, err = PostBalanceEntry(ctx, tx, BalanceEntry{TeamID: origTeamID, BalanceType: r.CompensationType, Amount: r.CompensationAmount,
+			EntryType: "qo_compensation", ReferenceType: "qualifying_offer", ReferenceID: offerID,
+			Description: "Qualifying offer compensation (league-funded)"})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE qualifying_offers SET signed_team_id = $1, compensation_type = $2, compensation_amount = $3, compensated_at = NOW()
+		WHERE id = $4
+	`, signingTeamID, r.CompensationType, r.CompensationAmount, offerID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(" — QO compensation: %s receives $%.0f %s", origTeamName, r.CompensationAmount, label), nil
+}
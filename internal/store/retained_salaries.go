@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Retained Salary Ledger ---
+
+// TradeRetention is the elective share of a player's salary the sending team keeps.
+// ThroughYear is the last contract year retained (0 = current year only).
+type TradeRetention struct {
+	Pct         int `json:"pct"`
+	ThroughYear int `json:"through_year"`
+}
+
+type RetainedSalary struct {
+	ID                string    `json:"id"`
+	TradeID           string    `json:"trade_id"`
+	PlayerID          string    `json:"player_id"`
+	PlayerName        string    `json:"player_name"`
+	PayingTeamID      string    `json:"paying_team_id"`
+	PayingTeamName    string    `json:"paying_team_name"`
+	ReceivingTeamID   string    `json:"receiving_team_id"`
+	ReceivingTeamName string    `json:"receiving_team_name"`
+	Year              int       `json:"year"`
+	Percentage        float64   `json:"percentage"`
+	Amount            float64   `json:"amount"`
+	Note              string    `json:"note"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// retainTradedSalary moves the sending team's share of a traded player's salary into the ledger.
+// The current year carries the mandatory date-based retention (datePct of the salary) plus the
+// elective percentage of what remains; later years through r.ThroughYear carry the elective share only.
+// The player's contract keeps just the receiving team's portion, so a later release or re-trade
+// only ever touches that portion while the ledger rows stay with the original payer.
+func retainTradedSalary(ctx context.Context, tx pgx.Tx, tradeID, playerID, payingTeamID, receivingTeamID string, datePct float64, r TradeRetention, currentYear int) error {
+	lastYear := currentYear
+	if r.Pct > 0 && r.ThroughYear > currentYear {
+		lastYear = r.ThroughYear
+	}
+
+	for year := currentYear; year <= lastYear && year <= 2040; year++ {
+		col := fmt.Sprintf("contract_%d", year)
+		var contractStr string
+		if err := tx.QueryRow(ctx, fmt.Sprintf("SELECT COALESCE(%s, '') FROM players WHERE id = $1", col), playerID).Scan(&contractStr); err != nil {
+			return err
+		}
+		salary := parseContractAmount(contractStr)
+		if salary <= 0 {
+			continue
+		}
+		// An unexercised team option stays an option for the receiving team; if it is declined
+		// the retention for that year is voided with it
+		suffix := ""
+		if isTeamOptionCell(contractStr) {
+			suffix = " (TO)"
+		}
+
+		retained := 0.0
+		var notes []string
+		if year == currentYear && datePct > 0 {
+			retained += salary * datePct
+			notes = append(notes, fmt.Sprintf("Pro-Rated (%.0f%%)", datePct*100))
+		}
+		if r.Pct > 0 {
+			retained += (salary - retained) * float64(r.Pct) / 100
+			notes = append(notes, fmt.Sprintf("%d%% Retained", r.Pct))
+		}
+		if retained <= 0 {
+			continue
+		}
+
+		note := notes[0]
+		if len(notes) > 1 {
+			note += " + " + notes[1]
+		}
+
+		if _, err := tx.Exec(ctx, fmt.Sprintf("UPDATE players SET %s = $1 WHERE id = $2", col), fmt.Sprintf("%.0f", salary-retained)+suffix, playerID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO retained_salaries (trade_id, player_id, paying_team_id, receiving_team_id, year, percentage, amount, note)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (trade_id, player_id, year) DO UPDATE
+			SET percentage = EXCLUDED.percentage, amount = EXCLUDED.amount, note = EXCLUDED.note, status = 'active'
+		`, tradeID, playerID, payingTeamID, receivingTeamID, year, retained/salary*100, retained, note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reverseRetainedSalaries hands a reversed trade's retained salary back to the player's contract
+// and marks the ledger rows reversed. Returns the number of rows reversed.
+func reverseRetainedSalaries(ctx context.Context, tx pgx.Tx, tradeID string) (int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, player_id, year, amount FROM retained_salaries
+		WHERE trade_id = $1 AND status = 'active'
+	`, tradeID)
+	if err != nil {
+		return 0, err
+	}
+	type entry struct {
+		ID, PlayerID string
+		Year         int
+		Amount       float64
+	}
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		if err := rows.Scan(&e.ID, &e.PlayerID, &e.Year, &e.Amount); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	rows.Close()
+
+	for _, e := range entries {
+		col := fmt.Sprintf("contract_%d", e.Year)
+		var contractStr string
+		tx.QueryRow(ctx, fmt.Sprintf("SELECT COALESCE(%s, '') FROM players WHERE id = $1", col), e.PlayerID).Scan(&contractStr)
+		// Only restore years the player is still paid for (a released year stays empty)
+		if salary := parseContractAmount(contractStr); salary > 0 {
+			suffix := ""
+			if isTeamOptionCell(contractStr) {
+				suffix = " (TO)"
+			}
+			if _, err := tx.Exec(ctx, fmt.Sprintf("UPDATE players SET %s = $1 WHERE id = $2", col), fmt.Sprintf("%.0f", salary+e.Amount)+suffix, e.PlayerID); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := tx.Exec(ctx, `UPDATE retained_salaries SET status = 'reversed' WHERE id = $1`, e.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(entries), nil
+}
+
+// isTeamOptionCell reports whether a contract cell is an unexercised team option, e.g. "$5000000 (TO)".
+func isTeamOptionCell(cell string) bool {
+	return strings.Contains(strings.ToUpper(cell), "(TO)")
+}
+
+// voidRetainedSalary retires retention for a contract year a new deal (extension) replaces or a
+// declined option removes.
+func voidRetainedSalary(ctx context.Context, tx pgx.Tx, playerID string, year int) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE retained_salaries SET status = 'voided'
+		WHERE player_id = $1 AND year = $2 AND status = 'active'
+	`, playerID, year)
+	return err
+}
+
+// GetTeamRetainedSalaries lists active ledger rows the team pays or benefits from.
+func GetTeamRetainedSalaries(db *pgxpool.Pool, teamID string) ([]RetainedSalary, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT rs.id, COALESCE(rs.trade_id::text, ''), rs.player_id, p.first_name || ' ' || p.last_name,
+		       rs.paying_team_id, COALESCE(pt.name, ''), COALESCE(rs.receiving_team_id::text, ''), COALESCE(rt.name, ''),
+		       rs.year, rs.percentage, rs.amount, COALESCE(rs.note, ''), rs.status, rs.created_at
+		FROM retained_salaries rs
+		JOIN players p ON rs.player_id = p.id
+		LEFT JOIN teams pt ON rs.paying_team_id = pt.id
+		LEFT JOIN teams rt ON rs.receiving_team_id = rt.id
+		WHERE (rs.paying_team_id = $1 OR rs.receiving_team_id = $1) AND rs.status = 'active'
+		ORDER BY rs.year ASC, rs.amount DESC
+	`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []RetainedSalary
+	for rows.Next() {
+		var r RetainedSalary
+		if err := rows.Scan(&r.ID, &r.TradeID, &r.PlayerID, &r.PlayerName, &r.PayingTeamID, &r.PayingTeamName,
+			&r.ReceivingTeamID, &r.ReceivingTeamName, &r.Year, &r.Percentage, &r.Amount, &r.Note, &r.Status, &r.CreatedAt); err != nil {
+			continue
+		}
+		entries = append(entries, r)
+	}
+	return entries, nil
+}
@@ -0,0 +1,240 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- End-of-Season Contract Rollover ---
+
+type RolloverChange struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	TeamID     string `json:"team_id"`
+	TeamName   string `json:"team_name"`
+	Action     string `json:"action"` // 'released', 'qo_pending', 'option_pending', 'option_exercised', 'option_declined'
+	Detail     string `json:"detail"`
+}
+
+type RolloverLogEntry struct {
+	RolloverChange
+	ID        string    `json:"id"`
+	LeagueID  string    `json:"league_id"`
+	Season    int       `json:"season"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RolloverRunKey is the system_counters key marking a league's rollover for a season as complete.
+func RolloverRunKey(leagueID string, season int) string {
+	return fmt.Sprintf("contract_rollover_%s_%d", leagueID, season)
+}
+
+// isExpiringContract reports whether a deal ends after the current season: next year is
+// marked UFA, or next year is blank while the current year is signed. Unsigned minor
+// leaguers (blank in both years) are left alone.
+func isExpiringContract(current, next string) bool {
+	current = strings.ToUpper(strings.TrimSpace(current))
+	next = strings.ToUpper(strings.TrimSpace(next))
+	if next == "UFA" {
+		return true
+	}
+	return next == "" && current != "" && current != "UFA"
+}
+
+// PreviewContractRollover lists every change a rollover of the given season would make
+// in a league, without writing anything (dry run).
+func PreviewContractRollover(db *pgxpool.Pool, leagueID string, season int) ([]RolloverChange, error) {
+	if season < 2026 || season >= 2040 {
+		return nil, fmt.Errorf("no contract columns for a %d rollover", season)
+	}
+	ctx := context.Background()
+	curCol := fmt.Sprintf("contract_%d", season)
+	nextCol := fmt.Sprintf("contract_%d", season+1)
+
+	rows, err := db.Query(ctx, fmt.Sprintf(`
+		SELECT p.id, p.first_name || ' ' || p.last_name, p.team_id, t.name,
+		       COALESCE(p.%s, ''), COALESCE(p.%s, ''), COALESCE(qo.status, '')
+		FROM players p
+		JOIN teams t ON p.team_id = t.id
+		LEFT JOIN qualifying_offers qo ON qo.player_id = p.id AND qo.season = $2
+		WHERE p.league_id = $1
+		ORDER BY t.name, p.last_name
+	`, curCol, nextCol), leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := GetLeagueSettings(db, leagueID, season)
+	deadlineText := "no deadline set"
+	if deadline, err := GetLeagueDateValue(db, leagueID, season, "option_deadline"); err == nil {
+		deadlineText = "due " + deadline.Format("January 2, 2006")
+	}
+
+	var changes []RolloverChange
+	for rows.Next() {
+		var ch RolloverChange
+		var current, next, qoStatus string
+		if err := rows.Scan(&ch.PlayerID, &ch.PlayerName, &ch.TeamID, &ch.TeamName, &current, &next, &qoStatus); err != nil {
+			continue
+		}
+
+		if isExpiringContract(current, next) && qoStatus == "tendered" {
+			// Released once the player answers the qualifying offer (see ResolveQualifyingOffer)
+			ch.Action = "qo_pending"
+			ch.Detail = fmt.Sprintf("Contract expired after %d. Qualifying offer outstanding; stays on the roster until the player responds.", season)
+			changes = append(changes, ch)
+		} else if isExpiringContract(current, next) {
+			ch.Action = "released"
+			if strings.TrimSpace(next) == "" {
+				ch.Detail = fmt.Sprintf("Contract expired after %d (no %d salary). Released to free agency.", season, season+1)
+			} else {
+				ch.Detail = fmt.Sprintf("Contract expired after %d (%d: %s). Released to free agency.", season, season+1, next)
+			}
+			changes = append(changes, ch)
+		} else if strings.Contains(next, "(TO)") {
+			ch.Action = "option_pending"
+			ch.Detail = fmt.Sprintf("%d team option ($%.0f) awaiting decision, %s (default: %s).",
+				season+1, parseContractAmount(next), deadlineText, settings.OptionDefaultAction)
+			changes = append(changes, ch)
+		}
+	}
+	return changes, nil
+}
+
+// ApplyContractRollover releases every expiring contract in a league to free agency,
+// logs a transaction per player and records the full change set for the commissioner report.
+func ApplyContractRollover(db *pgxpool.Pool, leagueID string, season int) ([]RolloverChange, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Claim the run key first: a concurrent run blocks here until this one commits, then finds it set
+	res, err := tx.Exec(ctx, `
+		INSERT INTO system_counters (key, value) VALUES ($1, 1)
+		ON CONFLICT (key) DO UPDATE SET value = 1 WHERE system_counters.value = 0
+	`, RolloverRunKey(leagueID, season))
+	if err != nil {
+		return nil, err
+	}
+	if res.RowsAffected() == 0 {
+		return nil, fmt.Errorf("contract rollover for %d has already been run", season)
+	}
+
+	changes, err := PreviewContractRollover(db, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ch := range changes {
+		if ch.Action == "released" {
+			_, err = tx.Exec(ctx, `
+				UPDATE players SET team_id = NULL, fa_status = 'available', status_40_man = FALSE, status_26_man = FALSE,
+					status_il = NULL, il_start_date = NULL, on_trade_block = FALSE
+				WHERE id = $1
+			`, ch.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = tx.Exec(ctx, `
+				INSERT INTO transactions (league_id, team_id, player_id, transaction_type, summary, status)
+				VALUES ($1, $2, $3, 'Dropped Player', $4, 'COMPLETED')
+			`, leagueID, ch.TeamID, ch.PlayerID, fmt.Sprintf("%s released %s to free agency (contract expired after %d)", ch.TeamName, ch.PlayerName, season))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO contract_rollover_log (league_id, season, player_id, player_name, team_id, team_name, action, detail)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, leagueID, season, ch.PlayerID, ch.PlayerName, ch.TeamID, ch.TeamName, ch.Action, ch.Detail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, tx.Commit(ctx)
+}
+
+// ApplyOptionDefaults resolves every undecided team option for the season after `season`
+// using the league's default action. Called once option_deadline has passed.
+func ApplyOptionDefaults(db *pgxpool.Pool, leagueID string, season int) ([]RolloverChange, error) {
+	optionYear := season + 1
+	options, err := GetPlayersWithOptions(db, "", optionYear)
+	if err != nil {
+		return nil, err
+	}
+
+	action := GetLeagueSettings(db, leagueID, season).OptionDefaultAction
+	var changes []RolloverChange
+	for _, o := range options {
+		if o.LeagueID != leagueID {
+			continue
+		}
+		if err := ProcessOptionDecision(db, o.ID, optionYear, action); err != nil {
+			fmt.Printf("ERROR [ApplyOptionDefaults] %s: %v\n", o.Name, err)
+			continue
+		}
+
+		ch := RolloverChange{PlayerID: o.ID, PlayerName: o.Name, TeamID: o.TeamID, TeamName: o.TeamName}
+		if action == "exercise" {
+			ch.Action = "option_exercised"
+			ch.Detail = fmt.Sprintf("Deadline passed without a decision. %d team option ($%.0f) exercised by default.", optionYear, o.Salary)
+		} else {
+			ch.Action = "option_declined"
+			ch.Detail = fmt.Sprintf("Deadline passed without a decision. %d team option declined by default; buyout $%.0f.", optionYear, o.Buyout)
+		}
+		changes = append(changes, ch)
+
+		_, err := db.Exec(context.Background(), `
+			INSERT INTO contract_rollover_log (league_id, season, player_id, player_name, team_id, team_name, action, detail)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, leagueID, season, ch.PlayerID, ch.PlayerName, ch.TeamID, ch.TeamName, ch.Action, ch.Detail)
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+// GetRolloverLog returns the commissioner report for a league's rollover season.
+func GetRolloverLog(db *pgxpool.Pool, leagueID string, season int) ([]RolloverLogEntry, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT id, league_id, season, COALESCE(player_id::TEXT, ''), player_name, COALESCE(team_id::TEXT, ''), team_name,
+		       action, COALESCE(detail, ''), created_at
+		FROM contract_rollover_log
+		WHERE league_id = $1 AND season = $2
+		ORDER BY created_at, team_name, player_name
+	`, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []RolloverLogEntry
+	for rows.Next() {
+		var e RolloverLogEntry
+		if err := rows.Scan(&e.ID, &e.LeagueID, &e.Season, &e.PlayerID, &e.PlayerName, &e.TeamID, &e.TeamName,
+			&e.Action, &e.Detail, &e.CreatedAt); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// IsRolloverComplete reports whether a league's rollover for a season has already been applied.
+func IsRolloverComplete(db *pgxpool.Pool, leagueID string, season int) bool {
+	var count int
+	err := db.QueryRow(context.Background(), `SELECT value FROM system_counters WHERE key = $1`, RolloverRunKey(leagueID, season)).Scan(&count)
+	return err == nil && count > 0
+}
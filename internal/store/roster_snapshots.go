@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Roster Snapshots ---
+
+// RosterSnapshotEntry is one player's membership on a team's locked roster for a date, with
+// the fantasy points they earned that day.
+type RosterSnapshotEntry struct {
+	PlayerID     string  `json:"player_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	Position     string  `json:"position"`
+	MLBTeam      string  `json:"mlb_team"`
+	RosterStatus string  `json:"roster_status"` // '26', '40', 'IL', 'minors'
+	StatusIL     string  `json:"status_il"`
+	Points       float64 `json:"points"`
+}
+
+// RosterSnapshot is a team's roster as locked at first pitch on a date. Only 26-man players'
+// points count toward the team (ActivePoints); everyone else's are shown as BenchPoints.
+type RosterSnapshot struct {
+	Date         string                `json:"date"` // YYYY-MM-DD
+	TeamID       string                `json:"team_id"`
+	TeamName     string                `json:"team_name"`
+	LeagueID     string                `json:"league_id"`
+	Locked       bool                  `json:"locked"`
+	FirstPitch   *time.Time            `json:"first_pitch"`
+	LockedAt     *time.Time            `json:"locked_at"`
+	Players      []RosterSnapshotEntry `json:"players"`
+	ActivePoints float64               `json:"active_points"`
+	BenchPoints  float64               `json:"bench_points"`
+}
+
+// rosterStatusSQL buckets a player row the way the roster page does: IL first, then 26-man,
+// 40-man, and everything else in the minors.
+const rosterStatusSQL = `
+	CASE WHEN COALESCE(p.status_il, '') <> '' THEN 'IL'
+	     WHEN p.status_26_man THEN '26'
+	     WHEN p.status_40_man THEN '40'
+	     ELSE 'minors' END`
+
+// LockRosterSnapshot records every rostered player's team and membership for a date. The first
+// lock for a date wins; later calls are no-ops. A nil firstPitch is the stats worker's fallback
+// for a date that missed its first-pitch lock, taken after that day's games.
+// Returns the number of players recorded (0 if the date was already locked).
+func LockRosterSnapshot(db *pgxpool.Pool, date string, firstPitch *time.Time) (int64, error) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	res, err := tx.Exec(ctx, `
+		INSERT INTO roster_snapshot_days (snapshot_date, first_pitch)
+		VALUES ($1::date, $2)
+		ON CONFLICT (snapshot_date) DO NOTHING
+	`, date, firstPitch)
+	if err != nil {
+		return 0, err
+	}
+	if res.RowsAffected() == 0 {
+		return 0, nil
+	}
+
+	res, err = tx.Exec(ctx, `
+		INSERT INTO roster_snapshots (snapshot_date, player_id, team_id, league_id, roster_status, status_il, position)
+		SELECT $1::date, p.id, t.id, t.league_id, `+rosterStatusSQL+`, NULLIF(p.status_il, ''), p.position
+		FROM players p
+		JOIN teams t ON t.id = p.team_id
+		ON CONFLICT (snapshot_date, player_id) DO NOTHING
+	`, date)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+// IsRosterSnapshotLocked reports whether rosters have been locked for a date.
+func IsRosterSnapshotLocked(db *pgxpool.Pool, date string) bool {
+	var exists bool
+	db.QueryRow(context.Background(), `
+		SELECT EXISTS (SELECT 1 FROM roster_snapshot_days WHERE snapshot_date = $1::date)
+	`, date).Scan(&exists)
+	return exists
+}
+
+// GetRosterSnapshot returns a team's locked roster for a date with each player's points that day.
+// Locked is false (and Players empty) when the date hasn't been locked yet.
+func GetRosterSnapshot(db *pgxpool.Pool, teamID, date string) (*RosterSnapshot, error) {
+	ctx := context.Background()
+	s := &RosterSnapshot{Date: date, TeamID: teamID}
+	err := db.QueryRow(ctx, `SELECT name, league_id FROM teams WHERE id = $1`, teamID).Scan(&s.TeamName, &s.LeagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstPitch, lockedAt *time.Time
+	err = db.QueryRow(ctx, `
+		SELECT first_pitch, locked_at FROM roster_snapshot_days WHERE snapshot_date = $1::date
+	`, date).Scan(&firstPitch, &lockedAt)
+	if err != nil {
+		return s, nil
+	}
+	s.Locked = true
+	s.FirstPitch = firstPitch
+	s.LockedAt = lockedAt
+
+	rows, err := db.Query(ctx, `
+		SELECT rs.player_id, p.first_name, p.last_name, COALESCE(rs.position, ''), COALESCE(p.mlb_team, ''),
+		       rs.roster_status, COALESCE(rs.status_il, ''),
+		       COALESCE((SELECT SUM(dps.fantasy_points) FROM daily_player_stats dps
+		                 WHERE dps.player_id = rs.player_id AND dps.game_date = rs.snapshot_date), 0)
+		FROM roster_snapshots rs
+		JOIN players p ON p.id = rs.player_id
+		WHERE rs.team_id = $1 AND rs.snapshot_date = $2::date
+		ORDER BY CASE rs.roster_status WHEN '26' THEN 1 WHEN 'IL' THEN 2 WHEN '40' THEN 3 ELSE 4 END,
+		         p.last_name, p.first_name
+	`, teamID, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e RosterSnapshotEntry
+		if err := rows.Scan(&e.PlayerID, &e.FirstName, &e.LastName, &e.Position, &e.MLBTeam,
+			&e.RosterStatus, &e.StatusIL, &e.Points); err != nil {
+			return nil, err
+		}
+		if e.RosterStatus == "26" {
+			s.ActivePoints += e.Points
+		} else {
+			s.BenchPoints += e.Points
+		}
+		s.Players = append(s.Players, e)
+	}
+	return s, rows.Err()
+}
+
+// GetTeamSnapshotPoints totals the fantasy points a team's locked 26-man rosters earned between
+// two dates (inclusive). Dates that were never locked contribute nothing.
+func GetTeamSnapshotPoints(db *pgxpool.Pool, teamID, startDate, endDate string) (float64, error) {
+	var total float64
+	err := db.QueryRow(context.Background(), `
+		SELECT COALESCE(SUM(dps.fantasy_points), 0)
+		FROM roster_snapshots rs
+		JOIN daily_player_stats dps ON dps.player_id = rs.player_id AND dps.game_date = rs.snapshot_date
+		WHERE rs.team_id = $1 AND rs.roster_status = '26'
+		  AND rs.snapshot_date BETWEEN $2::date AND $3::date
+	`, teamID, startDate, endDate).Scan(&total)
+	return total, err
+}
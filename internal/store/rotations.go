@@ -44,7 +44,7 @@ func GetPitchersForTeam(db *pgxpool.Pool, teamID string) ([]RosterPlayer, error)
 	rows, err := db.Query(context.Background(), `
 		SELECT id, first_name, last_name, position
 		FROM players
-		WHERE team_id = $1 AND position ILIKE '%P%' AND status_26_man = TRUE
+		WHERE team_id = $1 AND (position ILIKE '%P%' OR is_two_way) AND status_26_man = TRUE
 		ORDER BY last_name
 	`, teamID)
 	if err != nil {
@@ -521,7 +521,7 @@ func populateBankedStartPoints(db *pgxpool.Pool, bankedStartIDs []string) {
 		dateStr := bankedDate.Format("2006-01-02")
 		err = db.QueryRow(ctx,
 			`SELECT COALESCE(SUM(dps.fantasy_points), 0) FROM daily_player_stats dps
-			 JOIN players p ON p.mlb_id::text = dps.mlb_id
+			 JOIN players p ON p.mlb_id::text = dps.mlb_id AND dps.league_id = p.league_id
 			 WHERE p.id = $1 AND dps.game_date = $2`,
 			pitcherID, dateStr).Scan(&pts)
 		if err != nil {
@@ -532,7 +532,7 @@ func populateBankedStartPoints(db *pgxpool.Pool, bankedStartIDs []string) {
 		var count int
 		db.QueryRow(ctx,
 			`SELECT COUNT(*) FROM daily_player_stats dps
-			 JOIN players p ON p.mlb_id::text = dps.mlb_id
+			 JOIN players p ON p.mlb_id::text = dps.mlb_id AND dps.league_id = p.league_id
 			 WHERE p.id = $1 AND dps.game_date = $2`,
 			pitcherID, dateStr).Scan(&count)
 		if count > 0 {
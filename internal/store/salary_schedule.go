@@ -0,0 +1,137 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// --- Salary Schedules ---
+
+// bidYearMultipliers reward shorter free-agent deals when bids are converted to points.
+var bidYearMultipliers = map[int]float64{1: 2.0, 2: 1.8, 3: 1.6, 4: 1.4, 5: 1.2}
+
+// scheduleRamp is the year-over-year step used for front- and back-loaded schedules
+// (capped at the league's max variance).
+const scheduleRamp = 0.10
+
+// BuildSalarySchedule turns a contract shape into per-year salaries worth years * aav in total.
+// shape is 'flat', 'front', 'back' or 'custom' (custom uses the given salaries as-is).
+func BuildSalarySchedule(shape string, years int, aav float64, custom []float64, s LeagueSettings) ([]float64, error) {
+	if years < 1 {
+		return nil, fmt.Errorf("contract must be at least 1 year")
+	}
+
+	var schedule []float64
+	switch shape {
+	case "", "flat":
+		for i := 0; i < years; i++ {
+			schedule = append(schedule, aav)
+		}
+	case "front", "back":
+		ramp := scheduleRamp
+		if s.MaxSalaryVariance > 0 && s.MaxSalaryVariance < ramp {
+			ramp = s.MaxSalaryVariance
+		}
+		if shape == "front" {
+			ramp = -ramp
+		}
+		weights := make([]float64, years)
+		var weightSum float64
+		for i := range weights {
+			weights[i] = math.Pow(1+ramp, float64(i))
+			weightSum += weights[i]
+		}
+		total := aav * float64(years)
+		var assigned float64
+		for i := 0; i < years; i++ {
+			// Round to $10K; the final year absorbs the rounding so the total is unchanged
+			salary := math.Round(total*weights[i]/weightSum/10000) * 10000
+			if i == years-1 {
+				salary = total - assigned
+			}
+			assigned += salary
+			schedule = append(schedule, salary)
+		}
+	case "custom":
+		if len(custom) != years {
+			return nil, fmt.Errorf("enter a salary for each of the %d years", years)
+		}
+		schedule = append(schedule, custom...)
+	default:
+		return nil, fmt.Errorf("unknown salary schedule %q", shape)
+	}
+
+	return schedule, ValidateSalarySchedule(schedule, s)
+}
+
+// ValidateSalarySchedule enforces the league's minimum salary and max year-over-year variance.
+func ValidateSalarySchedule(schedule []float64, s LeagueSettings) error {
+	for i, salary := range schedule {
+		if salary < s.MinSalary {
+			return fmt.Errorf("year %d salary $%.0f is below the league minimum of $%.0f", i+1, salary, s.MinSalary)
+		}
+		if i == 0 || s.MaxSalaryVariance <= 0 {
+			continue
+		}
+		prev := schedule[i-1]
+		if math.Abs(salary-prev)/prev > s.MaxSalaryVariance+1e-9 {
+			return fmt.Errorf("year %d salary changes %.0f%% from year %d (league max is %.0f%%)",
+				i+1, math.Abs(salary-prev)/prev*100, i, s.MaxSalaryVariance*100)
+		}
+	}
+	return nil
+}
+
+func ScheduleTotal(schedule []float64) float64 {
+	var total float64
+	for _, salary := range schedule {
+		total += salary
+	}
+	return total
+}
+
+// SchedulePresentValue discounts each year after the first at the league's discount rate.
+func SchedulePresentValue(schedule []float64, rate float64) float64 {
+	var pv float64
+	for i, salary := range schedule {
+		pv += salary / math.Pow(1+rate, float64(i))
+	}
+	return pv
+}
+
+// ScheduleBidPoints values a free-agent bid: total value x the length multiplier, scaled by
+// how the schedule's present value compares with a flat deal of the same total. Flat bids
+// score exactly as before (years * AAV * multiplier / 1M); front-loading scores higher.
+func ScheduleBidPoints(schedule []float64, rate float64) float64 {
+	years := len(schedule)
+	total := ScheduleTotal(schedule)
+	if years == 0 || total <= 0 {
+		return 0
+	}
+	flat := make([]float64, years)
+	for i := range flat {
+		flat[i] = total / float64(years)
+	}
+	pvRatio := SchedulePresentValue(schedule, rate) / SchedulePresentValue(flat, rate)
+	return total * bidYearMultipliers[years] * pvRatio / 1000000
+}
+
+// IsFlatSchedule reports whether every year pays the same salary.
+func IsFlatSchedule(schedule []float64) bool {
+	for _, salary := range schedule {
+		if salary != schedule[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// DescribeSchedule renders a schedule as "$1.2M / $1.1M / $1.0M" for summaries.
+func DescribeSchedule(schedule []float64) string {
+	parts := make([]string, len(schedule))
+	for i, salary := range schedule {
+		parts[i] = fmt.Sprintf("$%.2fM", salary/1000000)
+	}
+	return strings.Join(parts, " / ")
+}
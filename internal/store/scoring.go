@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- League Scoring Rules ---
+
+// LeagueScoringCategory is a scoring category as one league scores it in one season.
+type LeagueScoringCategory struct {
+	ScoringCategory
+	DefaultPoints float64 `json:"default_points"`
+	DefaultActive bool    `json:"default_active"`
+	Override      bool    `json:"override"` // league has its own row for this category
+}
+
+// ScoringRuleInput is one category's edited value from the admin editor.
+type ScoringRuleInput struct {
+	StatKey  string
+	Points   float64
+	IsActive bool
+}
+
+// GetLeagueScoringCategories returns every global category for a stat type with the league's
+// override for that season applied. An empty leagueID returns the global defaults.
+func GetLeagueScoringCategories(db *pgxpool.Pool, leagueID string, season int, statType string) ([]LeagueScoringCategory, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT sc.id, sc.stat_type, sc.stat_key, sc.display_name,
+		       COALESCE(lsr.points, sc.points), COALESCE(lsr.is_active, sc.is_active),
+		       sc.points, sc.is_active, lsr.id IS NOT NULL
+		FROM scoring_categories sc
+		LEFT JOIN league_scoring_rules lsr ON lsr.stat_type = sc.stat_type AND lsr.stat_key = sc.stat_key
+		     AND lsr.league_id::TEXT = $2 AND lsr.season = $3
+		WHERE sc.stat_type = $1
+		ORDER BY COALESCE(lsr.points, sc.points) DESC, sc.display_name
+	`, statType, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cats []LeagueScoringCategory
+	for rows.Next() {
+		var c LeagueScoringCategory
+		if err := rows.Scan(&c.ID, &c.StatType, &c.StatKey, &c.DisplayName, &c.Points, &c.IsActive,
+			&c.DefaultPoints, &c.DefaultActive, &c.Override); err != nil {
+			continue
+		}
+		cats = append(cats, c)
+	}
+	return cats, nil
+}
+
+// GetLeagueScoringMap returns the stat_key → points lookup a league uses in a season.
+func GetLeagueScoringMap(db *pgxpool.Pool, leagueID string, season int, statType string) (map[string]float64, error) {
+	cats, err := GetLeagueScoringCategories(db, leagueID, season, statType)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]float64)
+	for _, c := range cats {
+		if c.IsActive {
+			m[c.StatKey] = c.Points
+		}
+	}
+	return m, nil
+}
+
+// SaveLeagueScoringRules stores a league's scoring for a season and stat type. Categories that
+// match the global default are stored as no override so later default changes still apply.
+func SaveLeagueScoringRules(db *pgxpool.Pool, leagueID string, season int, statType string, rules []ScoringRuleInput, userID string) error {
+	ctx := context.Background()
+	defaults, err := GetScoringCategories(db, statType)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]ScoringCategory)
+	for _, d := range defaults {
+		byKey[d.StatKey] = d
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, r := range rules {
+		d, ok := byKey[r.StatKey]
+		if !ok {
+			return fmt.Errorf("unknown %s category %q", statType, r.StatKey)
+		}
+		if d.Points == r.Points && d.IsActive == r.IsActive {
+			_, err = tx.Exec(ctx, `
+				DELETE FROM league_scoring_rules WHERE league_id = $1 AND season = $2 AND stat_type = $3 AND stat_key = $4
+			`, leagueID, season, statType, r.StatKey)
+		} else {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO league_scoring_rules (league_id, season, stat_type, stat_key, points, is_active, updated_by)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				ON CONFLICT (league_id, season, stat_type, stat_key) DO UPDATE SET
+					points = EXCLUDED.points, is_active = EXCLUDED.is_active,
+					updated_by = EXCLUDED.updated_by, updated_at = NOW()
+			`, leagueID, season, statType, r.StatKey, r.Points, r.IsActive, userID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ResetLeagueScoringRules drops a league's overrides for a season so it scores with the defaults.
+func ResetLeagueScoringRules(db *pgxpool.Pool, leagueID string, season int) error {
+	_, err := db.Exec(context.Background(), `DELETE FROM league_scoring_rules WHERE league_id = $1 AND season = $2`, leagueID, season)
+	return err
+}
+
+// CalculateFantasyPoints computes total points from raw stats and scoring weights.
+func CalculateFantasyPoints(raw map[string]float64, scoringMap map[string]float64) float64 {
+	total := 0.0
+	for key, value := range raw {
+		if weight, ok := scoringMap[key]; ok {
+			total += value * weight
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// LineFantasyPoints scores a stat line at its level. Minor-league lines only score when the league
+// has MiLB scoring on for the season.
+func LineFantasyPoints(raw map[string]float64, scoringMap map[string]float64, level string, scoresMiLB bool) float64 {
+	if level != "" && level != "MLB" && !scoresMiLB {
+		return 0
+	}
+	return CalculateFantasyPoints(raw, scoringMap)
+}
+
+// RecomputeLeagueFantasyPoints rescores a league's stored stat lines for a season with its current
+// rules and clears cached banked-start points so rotations pick up the new values.
+// Returns the number of stat lines whose points changed.
+func RecomputeLeagueFantasyPoints(db *pgxpool.Pool, leagueID string, season int) (int, error) {
+	ctx := context.Background()
+	maps := make(map[string]map[string]float64)
+	for _, statType := range []string{"pitching", "hitting"} {
+		m, err := GetLeagueScoringMap(db, leagueID, season, statType)
+		if err != nil {
+			return 0, err
+		}
+		maps[statType] = m
+	}
+	scoresMiLB := GetLeagueSettings(db, leagueID, season).MiLBScoring
+
+	rows, err := db.Query(ctx, `
+		SELECT id, stat_type, level, raw_stats, fantasy_points FROM daily_player_stats
+		WHERE league_id = $1 AND game_date >= make_date($2, 1, 1) AND game_date < make_date($2 + 1, 1, 1)
+	`, leagueID, season)
+	if err != nil {
+		return 0, err
+	}
+	type rescored struct {
+		id  string
+		pts float64
+	}
+	var changed []rescored
+	for rows.Next() {
+		var id, statType, level string
+		var rawJSON []byte
+		var current float64
+		if err := rows.Scan(&id, &statType, &level, &rawJSON, &current); err != nil {
+			continue
+		}
+		var raw map[string]float64
+		json.Unmarshal(rawJSON, &raw)
+		if pts := LineFantasyPoints(raw, maps[statType], level, scoresMiLB); pts != current {
+			changed = append(changed, rescored{id, pts})
+		}
+	}
+	rows.Close()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, r := range changed {
+		if _, err := tx.Exec(ctx, `UPDATE daily_player_stats SET fantasy_points = $1 WHERE id = $2`, r.pts, r.id); err != nil {
+			return 0, err
+		}
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE banked_starts SET fantasy_points = NULL
+		WHERE league_id = $1 AND banked_date >= make_date($2, 1, 1) AND banked_date < make_date($2 + 1, 1, 1)
+	`, leagueID, season)
+	if err != nil {
+		return 0, err
+	}
+	return len(changed), tx.Commit(ctx)
+}
@@ -0,0 +1,402 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Season Rollover Wizard ---
+
+// SeasonRolloverOptions are the commissioner's choices for rolling a league into a new season.
+type SeasonRolloverOptions struct {
+	LeagueID       string  `json:"league_id"`
+	FromYear       int     `json:"from_year"`
+	IsbpAllocation float64 `json:"isbp_allocation"`
+	MilbAllocation float64 `json:"milb_allocation"`
+	AllocationMode string  `json:"allocation_mode"` // 'add' or 'reset'
+	Overwrite      bool    `json:"overwrite"`       // replace next-season settings/dates that already exist
+}
+
+// SettingChange is one league_settings column as it will look in the new season.
+type SettingChange struct {
+	Field    string `json:"field"`
+	Current  string `json:"current"`  // next season's existing value ('' if no row yet)
+	Proposed string `json:"proposed"` // value carried forward from the current season
+	Action   string `json:"action"`   // 'copy', 'keep', 'same'
+}
+
+// DateChange is one league_dates row shifted into the new season.
+type DateChange struct {
+	DateType string `json:"date_type"`
+	FromDate string `json:"from_date"`
+	Current  string `json:"current"`
+	Proposed string `json:"proposed"`
+	Action   string `json:"action"` // 'copy', 'keep', 'same'
+}
+
+// AllocationChange is one team balance adjustment from the annual allocation.
+type AllocationChange struct {
+	TeamID      string  `json:"team_id"`
+	TeamName    string  `json:"team_name"`
+	BalanceType string  `json:"balance_type"`
+	Current     float64 `json:"current"`
+	Amount      float64 `json:"amount"`
+	After       float64 `json:"after"`
+}
+
+// SeasonRolloverRecord is a completed rollover (season_rollovers row).
+type SeasonRolloverRecord struct {
+	ID             string    `json:"id"`
+	FromYear       int       `json:"from_year"`
+	ToYear         int       `json:"to_year"`
+	IsbpAllocation float64   `json:"isbp_allocation"`
+	MilbAllocation float64   `json:"milb_allocation"`
+	AllocationMode string    `json:"allocation_mode"`
+	SettingsCloned bool      `json:"settings_cloned"`
+	DatesCloned    int       `json:"dates_cloned"`
+	PlayersReset   int       `json:"players_reset"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SeasonRolloverPlan is the full diff the wizard shows before anything is committed.
+type SeasonRolloverPlan struct {
+	Options      SeasonRolloverOptions `json:"options"`
+	ToYear       int                   `json:"to_year"`
+	Settings     []SettingChange       `json:"settings"`
+	Dates        []DateChange          `json:"dates"`
+	Allocations  []AllocationChange    `json:"allocations"`
+	OptionResets int                   `json:"option_resets"`
+	Applied      *SeasonRolloverRecord `json:"applied"` // set once the rollover has run; allocations won't repeat
+}
+
+// GetSeasonRollover returns the recorded rollover into toYear, or nil if it hasn't run.
+func GetSeasonRollover(db *pgxpool.Pool, leagueID string, toYear int) *SeasonRolloverRecord {
+	var r SeasonRolloverRecord
+	err := db.QueryRow(context.Background(), `
+		SELECT id, from_year, to_year, COALESCE(isbp_allocation, 0), COALESCE(milb_allocation, 0), COALESCE(allocation_mode, 'add'),
+		       COALESCE(settings_cloned, FALSE), COALESCE(dates_cloned, 0), COALESCE(players_reset, 0), COALESCE(created_by, ''), created_at
+		FROM season_rollovers WHERE league_id = $1 AND to_year = $2
+	`, leagueID, toYear).Scan(&r.ID, &r.FromYear, &r.ToYear, &r.IsbpAllocation, &r.MilbAllocation, &r.AllocationMode,
+		&r.SettingsCloned, &r.DatesCloned, &r.PlayersReset, &r.CreatedBy, &r.CreatedAt)
+	if err != nil {
+		return nil
+	}
+	return &r
+}
+
+// GetSeasonAllocationDefaults reads the allocation amounts configured on the new season's settings,
+// falling back to the current season's.
+func GetSeasonAllocationDefaults(db *pgxpool.Pool, leagueID string, fromYear int) (isbp, milb float64, mode string) {
+	mode = "add"
+	db.QueryRow(context.Background(), `
+		SELECT COALESCE(season_isbp_allocation, 0), COALESCE(season_milb_allocation, 0), COALESCE(season_allocation_mode, 'add')
+		FROM league_settings WHERE league_id = $1 AND year IN ($2, $3)
+		ORDER BY year DESC LIMIT 1
+	`, leagueID, fromYear, fromYear+1).Scan(&isbp, &milb, &mode)
+	return isbp, milb, mode
+}
+
+// leagueSettingsColumns lists the per-season columns of league_settings (everything but the keys).
+func leagueSettingsColumns(ctx context.Context, q interface {
+	Query(context.Context, string, ...any) (pgx.Rows, error)
+}) ([]string, error) {
+	rows, err := q.Query(ctx, `
+		SELECT column_name FROM information_schema.columns
+		WHERE table_name = 'league_settings' AND column_name NOT IN ('id', 'league_id', 'year')
+		ORDER BY ordinal_position
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var cols []string
+	for rows.Next() {
+		var col string
+		if err := rows.Scan(&col); err == nil {
+			cols = append(cols, col)
+		}
+	}
+	return cols, nil
+}
+
+func leagueSettingsRow(ctx context.Context, db *pgxpool.Pool, leagueID string, year int) map[string]interface{} {
+	var raw []byte
+	err := db.QueryRow(ctx, `SELECT to_jsonb(ls) FROM league_settings ls WHERE league_id = $1 AND year = $2`, leagueID, year).Scan(&raw)
+	if err != nil {
+		return nil
+	}
+	// Keep numbers as written (250000000, not 2.5e+08) so the diff reads naturally
+	row := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	dec.Decode(&row)
+	return row
+}
+
+func settingValue(row map[string]interface{}, col string) string {
+	if row == nil || row[col] == nil {
+		return ""
+	}
+	return fmt.Sprint(row[col])
+}
+
+// PreviewSeasonRollover builds the diff for rolling a league from o.FromYear into the next season.
+func PreviewSeasonRollover(db *pgxpool.Pool, o SeasonRolloverOptions) (*SeasonRolloverPlan, error) {
+	ctx := context.Background()
+	toYear := o.FromYear + 1
+	plan := &SeasonRolloverPlan{Options: o, ToYear: toYear, Applied: GetSeasonRollover(db, o.LeagueID, toYear)}
+
+	// 1. Settings
+	cols, err := leagueSettingsColumns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	fromRow := leagueSettingsRow(ctx, db, o.LeagueID, o.FromYear)
+	toRow := leagueSettingsRow(ctx, db, o.LeagueID, toYear)
+	if fromRow != nil {
+		for _, col := range cols {
+			ch := SettingChange{Field: col, Current: settingValue(toRow, col), Proposed: settingValue(fromRow, col)}
+			switch {
+			case toRow != nil && ch.Current == ch.Proposed:
+				ch.Action = "same"
+			case toRow != nil && !o.Overwrite:
+				ch.Action = "keep"
+			default:
+				ch.Action = "copy"
+			}
+			plan.Settings = append(plan.Settings, ch)
+		}
+	}
+
+	// 2. Key dates, shifted by a year
+	rows, err := db.Query(ctx, `
+		SELECT f.date_type, f.event_date, (f.event_date + INTERVAL '1 year')::date, t.event_date
+		FROM league_dates f
+		LEFT JOIN league_dates t ON t.league_id = f.league_id AND t.year = $3 AND t.date_type = f.date_type
+		WHERE f.league_id = $1 AND f.year = $2
+		ORDER BY f.event_date
+	`, o.LeagueID, o.FromYear, toYear)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var d DateChange
+		var from, proposed time.Time
+		var current *time.Time
+		if err := rows.Scan(&d.DateType, &from, &proposed, &current); err != nil {
+			continue
+		}
+		d.FromDate = from.Format("2006-01-02")
+		d.Proposed = proposed.Format("2006-01-02")
+		switch {
+		case current != nil && current.Format("2006-01-02") == d.Proposed:
+			d.Current, d.Action = d.Proposed, "same"
+		case current != nil && !o.Overwrite:
+			d.Current, d.Action = current.Format("2006-01-02"), "keep"
+		default:
+			if current != nil {
+				d.Current = current.Format("2006-01-02")
+			}
+			d.Action = "copy"
+		}
+		plan.Dates = append(plan.Dates, d)
+	}
+	rows.Close()
+
+	// 3. Allocations and counters (only applied the first time)
+	if plan.Applied == nil {
+		plan.Allocations, err = previewAllocations(ctx, db, o, false)
+		if err != nil {
+			return nil, err
+		}
+		db.QueryRow(ctx, `SELECT COUNT(*) FROM players WHERE league_id = $1 AND options_this_season > 0`, o.LeagueID).Scan(&plan.OptionResets)
+	}
+
+	return plan, nil
+}
+
+// previewAllocations computes each team's allocation from its current balances. When applying,
+// pass the transaction with lock set so the balances can't change underneath a reset.
+func previewAllocations(ctx context.Context, q interface {
+	Query(context.Context, string, ...any) (pgx.Rows, error)
+}, o SeasonRolloverOptions, lock bool) ([]AllocationChange, error) {
+	query := `
+		SELECT id, name, COALESCE(isbp_balance, 0), COALESCE(milb_balance, 0)
+		FROM teams WHERE league_id = $1 ORDER BY name`
+	if lock {
+		query += " FOR UPDATE"
+	}
+	rows, err := q.Query(ctx, query, o.LeagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []AllocationChange
+	for rows.Next() {
+		var teamID, name string
+		var isbp, milb float64
+		if err := rows.Scan(&teamID, &name, &isbp, &milb); err != nil {
+			continue
+		}
+		for _, b := range []struct {
+			balanceType       string
+			current, allotted float64
+		}{{"isbp", isbp, o.IsbpAllocation}, {"milb", milb, o.MilbAllocation}} {
+			if b.allotted == 0 && o.AllocationMode != "reset" {
+				continue
+			}
+			ch := AllocationChange{TeamID: teamID, TeamName: name, BalanceType: b.balanceType, Current: b.current, Amount: b.allotted}
+			if o.AllocationMode == "reset" {
+				ch.Amount = b.allotted - b.current
+			}
+			ch.After = ch.Current + ch.Amount
+			if ch.Amount != 0 {
+				changes = append(changes, ch)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// ApplySeasonRollover commits the plan. Settings and dates are cloned with conflict handling so a
+// rerun only fills gaps (or overwrites when asked); allocations and counter resets happen once,
+// guarded by the season_rollovers row. Returns the plan that was applied.
+func ApplySeasonRollover(db *pgxpool.Pool, o SeasonRolloverOptions, createdBy string) (*SeasonRolloverPlan, error) {
+	plan, err := PreviewSeasonRollover(db, o)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	toYear := plan.ToYear
+	var rolloverID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO season_rollovers (league_id, from_year, to_year, isbp_allocation, milb_allocation, allocation_mode, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (league_id, to_year) DO NOTHING
+		RETURNING id
+	`, o.LeagueID, o.FromYear, toYear, o.IsbpAllocation, o.MilbAllocation, o.AllocationMode, createdBy).Scan(&rolloverID)
+	firstRun := err == nil
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !firstRun {
+		tx.QueryRow(ctx, `SELECT id FROM season_rollovers WHERE league_id = $1 AND to_year = $2`, o.LeagueID, toYear).Scan(&rolloverID)
+	}
+
+	// 1. Settings
+	settingsCloned := false
+	if len(plan.Settings) > 0 {
+		cols, err := leagueSettingsColumns(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		colList := strings.Join(cols, ", ")
+		tag, err := tx.Exec(ctx, fmt.Sprintf(`
+			INSERT INTO league_settings (league_id, year, %s)
+			SELECT league_id, $3, %s FROM league_settings WHERE league_id = $1 AND year = $2
+			ON CONFLICT (league_id, year) DO NOTHING
+		`, colList, colList), o.LeagueID, o.FromYear, toYear)
+		if err != nil {
+			return nil, err
+		}
+		settingsCloned = tag.RowsAffected() > 0
+		if !settingsCloned && o.Overwrite {
+			sets := make([]string, len(cols))
+			for i, col := range cols {
+				sets[i] = fmt.Sprintf("%s = src.%s", col, col)
+			}
+			_, err = tx.Exec(ctx, fmt.Sprintf(`
+				UPDATE league_settings dst SET %s
+				FROM league_settings src
+				WHERE src.league_id = $1 AND src.year = $2 AND dst.league_id = $1 AND dst.year = $3
+			`, strings.Join(sets, ", ")), o.LeagueID, o.FromYear, toYear)
+			if err != nil {
+				return nil, err
+			}
+			settingsCloned = true
+		}
+	}
+	// Remember this season's allocation for next year's wizard
+	_, err = tx.Exec(ctx, `
+		INSERT INTO league_settings (league_id, year, season_isbp_allocation, season_milb_allocation, season_allocation_mode)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (league_id, year) DO UPDATE SET
+			season_isbp_allocation = EXCLUDED.season_isbp_allocation,
+			season_milb_allocation = EXCLUDED.season_milb_allocation,
+			season_allocation_mode = EXCLUDED.season_allocation_mode
+	`, o.LeagueID, toYear, o.IsbpAllocation, o.MilbAllocation, o.AllocationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Key dates
+	conflict := "DO NOTHING"
+	if o.Overwrite {
+		conflict = "DO UPDATE SET event_date = EXCLUDED.event_date"
+	}
+	tag, err := tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO league_dates (league_id, year, date_type, event_date)
+		SELECT league_id, $3, date_type, (event_date + INTERVAL '1 year')::date
+		FROM league_dates WHERE league_id = $1 AND year = $2
+		ON CONFLICT (league_id, year, date_type) %s
+	`, conflict), o.LeagueID, o.FromYear, toYear)
+	if err != nil {
+		return nil, err
+	}
+	datesCloned := int(tag.RowsAffected())
+
+	// 3. Allocations and per-season counters, only on the first run
+	playersReset := 0
+	if firstRun {
+		// Recompute against locked balances; the preview above was read outside the transaction
+		plan.Allocations, err = previewAllocations(ctx, tx, o, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range plan.Allocations {
+			_, err = PostBalanceEntry(ctx, tx, BalanceEntry{TeamID: a.TeamID, BalanceType: a.BalanceType, Amount: a.Amount,
+				EntryType: "allocation", ReferenceType: "season_rollover", ReferenceID: rolloverID,
+				Description: fmt.Sprintf("%d season allocation", toYear), CreatedBy: createdBy})
+			if err != nil {
+				return nil, err
+			}
+		}
+		tag, err = tx.Exec(ctx, `UPDATE players SET options_this_season = 0 WHERE league_id = $1 AND options_this_season > 0`, o.LeagueID)
+		if err != nil {
+			return nil, err
+		}
+		playersReset = int(tag.RowsAffected())
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE season_rollovers SET
+			settings_cloned = settings_cloned OR $2,
+			dates_cloned = dates_cloned + $3,
+			players_reset = players_reset + $4
+		WHERE id = $1
+	`, rolloverID, settingsCloned, datesCloned, playersReset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
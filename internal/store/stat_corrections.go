@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Stat Corrections ---
+
+// StatCorrection is one stored stat line rewritten, added or removed after MLB revised a box
+// score. Added lines have empty OldRaw and removed lines empty NewRaw.
+type StatCorrection struct {
+	ID          string             `json:"id"`
+	DailyStatID string             `json:"daily_stat_id"`
+	PlayerID    string             `json:"player_id"`
+	PlayerName  string             `json:"player_name"`
+	LeagueID    string             `json:"league_id"`
+	TeamID      string             `json:"team_id"`
+	TeamName    string             `json:"team_name"`
+	GamePk      int                `json:"game_pk"`
+	GameDate    string             `json:"game_date"`
+	StatType    string             `json:"stat_type"`
+	OldRaw      map[string]float64 `json:"old_raw_stats"`
+	NewRaw      map[string]float64 `json:"new_raw_stats"`
+	OldPoints   float64            `json:"old_points"`
+	NewPoints   float64            `json:"new_points"`
+	CorrectedAt time.Time          `json:"corrected_at"`
+}
+
+// GetStoredStatLines returns every stat line stored for a game date, for diffing against a fresh box score.
+func GetStoredStatLines(db *pgxpool.Pool, date string) ([]DailyPlayerStats, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT dps.id, dps.player_id, COALESCE(dps.mlb_id, ''), dps.game_pk, dps.stat_type, dps.raw_stats,
+		       dps.fantasy_points, COALESCE(dps.team_id::TEXT, ''), COALESCE(dps.league_id::TEXT, ''),
+		       p.first_name || ' ' || p.last_name, COALESCE(t.name, ''), dps.level
+		FROM daily_player_stats dps
+		JOIN players p ON p.id = dps.player_id
+		LEFT JOIN teams t ON t.id = dps.team_id
+		WHERE dps.game_date = $1
+	`, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []DailyPlayerStats
+	for rows.Next() {
+		var s DailyPlayerStats
+		var rawJSON []byte
+		if err := rows.Scan(&s.ID, &s.PlayerID, &s.MlbID, &s.GamePk, &s.StatType, &rawJSON,
+			&s.FantasyPoints, &s.TeamID, &s.LeagueID, &s.PlayerName, &s.TeamName, &s.Level); err != nil {
+			continue
+		}
+		json.Unmarshal(rawJSON, &s.RawStats)
+		s.GameDate = date
+		lines = append(lines, s)
+	}
+	return lines, nil
+}
+
+// ApplyStatCorrection rewrites a stored stat line with corrected stats and logs the change.
+// Banked starts for a corrected pitcher and date are rescored; rotation points are read live
+// from daily_player_stats and pick up the change on their own.
+func ApplyStatCorrection(db *pgxpool.Pool, c *StatCorrection) error {
+	ctx := context.Background()
+	newJSON, err := json.Marshal(c.NewRaw)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `UPDATE daily_player_stats SET raw_stats = $1, fantasy_points = $2 WHERE id = $3`,
+		newJSON, c.NewPoints, c.DailyStatID)
+	if err != nil {
+		return err
+	}
+	return commitStatCorrection(ctx, db, tx, c)
+}
+
+// RecordStatLineAdded logs a stat line MLB added to a box score after its date was processed.
+// Store the line with UpsertDailyPlayerStats first; the log picks up its ID and names.
+func RecordStatLineAdded(db *pgxpool.Pool, c *StatCorrection) error {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		SELECT dps.id, p.first_name || ' ' || p.last_name, COALESCE(t.name, '')
+		FROM daily_player_stats dps
+		JOIN players p ON p.id = dps.player_id
+		LEFT JOIN teams t ON t.id = dps.team_id
+		WHERE dps.player_id = $1 AND dps.game_pk = $2 AND dps.stat_type = $3
+	`, c.PlayerID, c.GamePk, c.StatType).Scan(&c.DailyStatID, &c.PlayerName, &c.TeamName)
+	if err != nil {
+		return err
+	}
+	return commitStatCorrection(ctx, db, tx, c)
+}
+
+// RemoveStatLine deletes a stored stat line MLB dropped from a box score and logs the removal.
+func RemoveStatLine(db *pgxpool.Pool, c *StatCorrection) error {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM daily_player_stats WHERE id = $1`, c.DailyStatID); err != nil {
+		return err
+	}
+	c.DailyStatID = ""
+	return commitStatCorrection(ctx, db, tx, c)
+}
+
+// commitStatCorrection logs a correction, clears banked-start points for a corrected pitcher
+// and date, commits, and rescores those banked starts.
+func commitStatCorrection(ctx context.Context, db *pgxpool.Pool, tx pgx.Tx, c *StatCorrection) error {
+	oldJSON, err := json.Marshal(c.OldRaw)
+	if err != nil {
+		return err
+	}
+	newJSON, err := json.Marshal(c.NewRaw)
+	if err != nil {
+		return err
+	}
+
+	var dailyStatID, leagueID, teamID interface{}
+	if c.DailyStatID != "" {
+		dailyStatID = c.DailyStatID
+	}
+	if c.LeagueID != "" {
+		leagueID = c.LeagueID
+	}
+	if c.TeamID != "" {
+		teamID = c.TeamID
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO stat_corrections (daily_stat_id, player_id, league_id, team_id, game_pk, game_date, stat_type,
+			old_raw_stats, new_raw_stats, old_points, new_points)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, corrected_at
+	`, dailyStatID, c.PlayerID, leagueID, teamID, c.GamePk, c.GameDate, c.StatType,
+		oldJSON, newJSON, c.OldPoints, c.NewPoints).Scan(&c.ID, &c.CorrectedAt)
+	if err != nil {
+		return err
+	}
+
+	var bankedIDs []string
+	if c.StatType == "pitching" {
+		rows, err := tx.Query(ctx, `
+			UPDATE banked_starts SET fantasy_points = NULL
+			WHERE pitcher_id = $1 AND banked_date = $2
+			RETURNING id::TEXT
+		`, c.PlayerID, c.GameDate)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id string
+			if rows.Scan(&id) == nil {
+				bankedIDs = append(bankedIDs, id)
+			}
+		}
+		rows.Close()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	populateBankedStartPoints(db, bankedIDs)
+	return nil
+}
+
+// GetStatCorrections returns logged corrections, newest first. An empty leagueID returns all leagues.
+func GetStatCorrections(db *pgxpool.Pool, leagueID string, limit int) ([]StatCorrection, error) {
+	rows, err := db.Query(context.Background(), `
+		SELECT sc.id, COALESCE(sc.daily_stat_id::TEXT, ''), sc.player_id, p.first_name || ' ' || p.last_name,
+		       COALESCE(sc.league_id::TEXT, ''), COALESCE(sc.team_id::TEXT, ''), COALESCE(t.name, ''),
+		       sc.game_pk, sc.game_date::TEXT, sc.stat_type, sc.old_raw_stats, sc.new_raw_stats,
+		       sc.old_points, sc.new_points, sc.corrected_at
+		FROM stat_corrections sc
+		JOIN players p ON p.id = sc.player_id
+		LEFT JOIN teams t ON t.id = sc.team_id
+		WHERE ($1 = '' OR sc.league_id::TEXT = $1)
+		ORDER BY sc.corrected_at DESC, sc.game_date DESC
+		LIMIT $2
+	`, leagueID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []StatCorrection
+	for rows.Next() {
+		var c StatCorrection
+		var oldJSON, newJSON []byte
+		if err := rows.Scan(&c.ID, &c.DailyStatID, &c.PlayerID, &c.PlayerName, &c.LeagueID, &c.TeamID, &c.TeamName,
+			&c.GamePk, &c.GameDate, &c.StatType, &oldJSON, &newJSON, &c.OldPoints, &c.NewPoints, &c.CorrectedAt); err != nil {
+			continue
+		}
+		json.Unmarshal(oldJSON, &c.OldRaw)
+		json.Unmarshal(newJSON, &c.NewRaw)
+		list = append(list, c)
+	}
+	return list, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -30,6 +31,7 @@ type DailyPlayerStats struct {
 	TeamID        string            `json:"team_id"`
 	LeagueID      string            `json:"league_id"`
 	Opponent      string            `json:"opponent"`
+	Level         string            `json:"level"` // MLB, AAA, AA, A+, A
 	PlayerName    string            `json:"player_name"`
 	Position      string            `json:"position"`
 	TeamName      string            `json:"team_name"`
@@ -40,6 +42,7 @@ type StatsLeaderEntry struct {
 	PlayerID      string  `json:"player_id"`
 	PlayerName    string  `json:"player_name"`
 	Position      string  `json:"position"`
+	IsTwoWay      bool    `json:"is_two_way"`
 	TeamName      string  `json:"team_name"`
 	LeagueName    string  `json:"league_name"`
 	GamesPlayed   int     `json:"games_played"`
@@ -57,6 +60,7 @@ type HittingLeaderEntry struct {
 	PlayerID    string  `json:"player_id"`
 	PlayerName  string  `json:"player_name"`
 	Position    string  `json:"position"`
+	IsTwoWay    bool    `json:"is_two_way"`
 	TeamName    string  `json:"team_name"`
 	LeagueName  string  `json:"league_name"`
 	GamesPlayed int     `json:"games_played"`
@@ -135,20 +139,105 @@ func UpsertDailyPlayerStats(db *pgxpool.Pool, s *DailyPlayerStats) error {
 		leagueID = s.LeagueID
 	}
 
+	level := s.Level
+	if level == "" {
+		level = "MLB"
+	}
+
 	_, err = db.Exec(ctx, `
-		INSERT INTO daily_player_stats (player_id, mlb_id, game_pk, game_date, stat_type, raw_stats, fantasy_points, team_id, league_id, opponent)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO daily_player_stats (player_id, mlb_id, game_pk, game_date, stat_type, raw_stats, fantasy_points, team_id, league_id, opponent, level)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (player_id, game_pk, stat_type) DO UPDATE SET
 			raw_stats = EXCLUDED.raw_stats,
 			fantasy_points = EXCLUDED.fantasy_points,
 			team_id = EXCLUDED.team_id,
 			league_id = EXCLUDED.league_id,
-			opponent = EXCLUDED.opponent
-	`, s.PlayerID, s.MlbID, s.GamePk, s.GameDate, s.StatType, rawJSON, s.FantasyPoints, teamID, leagueID, s.Opponent)
+			opponent = EXCLUDED.opponent,
+			level = EXCLUDED.level
+	`, s.PlayerID, s.MlbID, s.GamePk, s.GameDate, s.StatType, rawJSON, s.FantasyPoints, teamID, leagueID, s.Opponent, level)
 	return err
 }
 
-// GetPitchingLeaderboard returns aggregated fantasy points leaders for pitchers.
+// BackfillStatLineCopies gives every league's copy of a player the stat lines stored against
+// another league's copy of the same MLB ID, scored with each league's rules for the season.
+// Lines take the team that rostered the copy on the game date, or none when there is no roster
+// snapshot for that day. A season of 0 backfills every season.
+// Returns the number of stat lines created.
+func BackfillStatLineCopies(db *pgxpool.Pool, season int) (int, error) {
+	ctx := context.Background()
+	rows, err := db.Query(ctx, `
+		SELECT DISTINCT ON (p.id, dps.game_pk, dps.stat_type)
+		       p.id, COALESCE(rs.team_id::TEXT, ''), COALESCE(p.league_id::TEXT, ''),
+		       dps.mlb_id, dps.game_pk, dps.game_date, dps.stat_type, dps.raw_stats, COALESCE(dps.opponent, ''), dps.level
+		FROM daily_player_stats dps
+		JOIN players p ON p.mlb_id::text = dps.mlb_id AND p.id <> dps.player_id
+		LEFT JOIN roster_snapshots rs ON rs.player_id = p.id AND rs.snapshot_date = dps.game_date
+		WHERE ($1 = 0 OR EXTRACT(YEAR FROM dps.game_date) = $1)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM daily_player_stats d2
+		      WHERE d2.player_id = p.id AND d2.game_pk = dps.game_pk AND d2.stat_type = dps.stat_type)
+		ORDER BY p.id, dps.game_pk, dps.stat_type
+	`, season)
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []DailyPlayerStats
+	for rows.Next() {
+		var s DailyPlayerStats
+		var gameDate time.Time
+		var rawJSON []byte
+		if err := rows.Scan(&s.PlayerID, &s.TeamID, &s.LeagueID, &s.MlbID, &s.GamePk, &gameDate,
+			&s.StatType, &rawJSON, &s.Opponent, &s.Level); err != nil {
+			continue
+		}
+		s.GameDate = gameDate.Format("2006-01-02")
+		json.Unmarshal(rawJSON, &s.RawStats)
+		missing = append(missing, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	maps := make(map[string]map[string]float64)
+	milb := make(map[string]bool)
+	created := 0
+	for i := range missing {
+		s := &missing[i]
+		year, _ := strconv.Atoi(s.GameDate[:4])
+		key := fmt.Sprintf("%s|%d|%s", s.LeagueID, year, s.StatType)
+		m, ok := maps[key]
+		if !ok {
+			m, err = GetLeagueScoringMap(db, s.LeagueID, year, s.StatType)
+			if err != nil {
+				return created, err
+			}
+			maps[key] = m
+		}
+		milbKey := fmt.Sprintf("%s|%d", s.LeagueID, year)
+		scoresMiLB, ok := milb[milbKey]
+		if !ok {
+			scoresMiLB = GetLeagueSettings(db, s.LeagueID, year).MiLBScoring
+			milb[milbKey] = scoresMiLB
+		}
+		s.FantasyPoints = LineFantasyPoints(s.RawStats, m, s.Level, scoresMiLB)
+		if err := UpsertDailyPlayerStats(db, s); err != nil {
+			fmt.Printf("ERROR [BackfillStatLineCopies]: %s game %d: %v\n", s.PlayerID, s.GamePk, err)
+			continue
+		}
+		created++
+	}
+	return created, nil
+}
+
+// scoredLevelSQL keeps MLB lines, plus minor-league lines in leagues scoring MiLB that season.
+const scoredLevelSQL = `(dps.level = 'MLB' OR EXISTS (
+			SELECT 1 FROM league_settings ls
+			WHERE ls.league_id = p.league_id AND ls.year = EXTRACT(YEAR FROM dps.game_date)::int AND ls.milb_scoring))`
+
+// GetPitchingLeaderboard returns aggregated fantasy points leaders for pitchers. Minor-league
+// lines count in leagues with MiLB scoring on.
 func GetPitchingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate string, limit int) ([]StatsLeaderEntry, error) {
 	ctx := context.Background()
 
@@ -157,6 +246,7 @@ func GetPitchingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate strin
 			dps.player_id,
 			p.first_name || ' ' || p.last_name AS player_name,
 			p.position,
+			p.is_two_way,
 			COALESCE(t.name, 'Free Agent') AS team_name,
 			l.name AS league_name,
 			COUNT(*) AS games_played,
@@ -173,6 +263,7 @@ func GetPitchingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate strin
 		LEFT JOIN teams t ON p.team_id = t.id
 		JOIN leagues l ON p.league_id = l.id
 		WHERE dps.stat_type = 'pitching'
+		  AND ` + scoredLevelSQL + `
 		  AND dps.game_date >= $1
 		  AND dps.game_date <= $2
 	`
@@ -187,7 +278,7 @@ func GetPitchingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate strin
 
 	_ = argCount
 	query += `
-		GROUP BY dps.player_id, p.first_name, p.last_name, p.position, t.name, l.name
+		GROUP BY dps.player_id, p.first_name, p.last_name, p.position, p.is_two_way, t.name, l.name
 		ORDER BY total_points DESC
 		LIMIT $` + fmt.Sprintf("%d", len(args)+1)
 	args = append(args, limit)
@@ -202,7 +293,7 @@ func GetPitchingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate strin
 	for rows.Next() {
 		var e StatsLeaderEntry
 		if err := rows.Scan(
-			&e.PlayerID, &e.PlayerName, &e.Position, &e.TeamName, &e.LeagueName,
+			&e.PlayerID, &e.PlayerName, &e.Position, &e.IsTwoWay, &e.TeamName, &e.LeagueName,
 			&e.GamesPlayed, &e.TotalPoints, &e.AvgPoints,
 			&e.TotalIP, &e.TotalK, &e.TotalER, &e.TotalQS, &e.TotalSV, &e.TotalHLD,
 		); err != nil {
@@ -213,7 +304,8 @@ func GetPitchingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate strin
 	return leaders, nil
 }
 
-// GetHittingLeaderboard returns aggregated fantasy points leaders for hitters.
+// GetHittingLeaderboard returns aggregated fantasy points leaders for hitters. Minor-league
+// lines count in leagues with MiLB scoring on.
 func GetHittingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate string, limit int) ([]HittingLeaderEntry, error) {
 	ctx := context.Background()
 
@@ -222,6 +314,7 @@ func GetHittingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate string
 			dps.player_id,
 			p.first_name || ' ' || p.last_name AS player_name,
 			p.position,
+			p.is_two_way,
 			COALESCE(t.name, 'Free Agent') AS team_name,
 			l.name AS league_name,
 			COUNT(*) AS games_played,
@@ -240,6 +333,7 @@ func GetHittingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate string
 		LEFT JOIN teams t ON p.team_id = t.id
 		JOIN leagues l ON p.league_id = l.id
 		WHERE dps.stat_type = 'hitting'
+		  AND ` + scoredLevelSQL + `
 		  AND dps.game_date >= $1
 		  AND dps.game_date <= $2
 	`
@@ -254,7 +348,7 @@ func GetHittingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate string
 
 	_ = argCount
 	query += `
-		GROUP BY dps.player_id, p.first_name, p.last_name, p.position, t.name, l.name
+		GROUP BY dps.player_id, p.first_name, p.last_name, p.position, p.is_two_way, t.name, l.name
 		ORDER BY total_points DESC
 		LIMIT $` + fmt.Sprintf("%d", len(args)+1)
 	args = append(args, limit)
@@ -269,7 +363,7 @@ func GetHittingLeaderboard(db *pgxpool.Pool, leagueID, startDate, endDate string
 	for rows.Next() {
 		var e HittingLeaderEntry
 		if err := rows.Scan(
-			&e.PlayerID, &e.PlayerName, &e.Position, &e.TeamName, &e.LeagueName,
+			&e.PlayerID, &e.PlayerName, &e.Position, &e.IsTwoWay, &e.TeamName, &e.LeagueName,
 			&e.GamesPlayed, &e.TotalPoints, &e.AvgPoints,
 			&e.TotalH, &e.TotalHR, &e.TotalRBI, &e.TotalR, &e.TotalBB, &e.TotalSB, &e.TotalK, &e.TotalCS,
 		); err != nil {
@@ -286,7 +380,7 @@ func GetPlayerGameLog(db *pgxpool.Pool, playerID, statType string, limit int) ([
 
 	rows, err := db.Query(ctx, `
 		SELECT dps.id, dps.player_id, COALESCE(dps.mlb_id, ''), dps.game_pk, dps.game_date,
-		       dps.stat_type, dps.raw_stats, dps.fantasy_points, COALESCE(dps.opponent, '')
+		       dps.stat_type, dps.raw_stats, dps.fantasy_points, COALESCE(dps.opponent, ''), dps.level
 		FROM daily_player_stats dps
 		WHERE dps.player_id = $1 AND dps.stat_type = $2
 		ORDER BY dps.game_date DESC
@@ -303,7 +397,7 @@ func GetPlayerGameLog(db *pgxpool.Pool, playerID, statType string, limit int) ([
 		var rawJSON []byte
 		var gameDate time.Time
 		if err := rows.Scan(&s.ID, &s.PlayerID, &s.MlbID, &s.GamePk, &gameDate,
-			&s.StatType, &rawJSON, &s.FantasyPoints, &s.Opponent); err != nil {
+			&s.StatType, &rawJSON, &s.FantasyPoints, &s.Opponent, &s.Level); err != nil {
 			continue
 		}
 		s.GameDate = gameDate.Format("2006-01-02")
@@ -393,7 +487,7 @@ func GetPitcherPointsForDates(db *pgxpool.Pool, playerIDs []string, dates []stri
 	query := fmt.Sprintf(`
 		SELECT p.id, dps.game_date, SUM(dps.fantasy_points) AS total_points
 		FROM daily_player_stats dps
-		JOIN players p ON p.mlb_id::text = dps.mlb_id
+		JOIN players p ON p.mlb_id::text = dps.mlb_id AND dps.league_id = p.league_id
 		WHERE p.id IN (%s) AND dps.game_date IN (%s)
 		GROUP BY p.id, dps.game_date
 	`, pidPlaceholders, datePlaceholders)
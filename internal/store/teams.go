@@ -40,15 +40,20 @@ type RosterPlayer struct {
 	IsIFA               bool            `json:"is_international_free_agent"`
 	DFAOnly             bool            `json:"dfa_only"`
 	IsMinorLeaguer      bool            `json:"is_minor_leaguer"`
+	IsTwoWay            bool            `json:"is_two_way"`
 	BidEndTime          *time.Time       `json:"bid_end_time"`
 	PendingBidAmount    float64          `json:"pending_bid_amount"`
 	PendingBidTeamName  string           `json:"pending_bid_team_name"`
+	QOAttached          bool             `json:"qo_attached"` // declined a qualifying offer; signing owes compensation
+	ProjectedPoints     float64          `json:"projected_points"` // filled by ApplyProjectedPoints
+	SurplusValue        float64          `json:"surplus_value"`    // filled by ApplyValuations; present value of contract surplus
 }
 
 type SalaryYearSummary struct {
 	Year           int     `json:"year"`
 	ActivePayroll  float64 `json:"active_payroll"`
 	DeadCap        float64 `json:"dead_cap"`
+	RetainedSalary float64 `json:"retained_salary"` // paid for players traded away (retained_salaries)
 	TotalPayroll   float64 `json:"total_payroll"`
 	LuxuryTaxLimit float64 `json:"luxury_tax_limit"`
 	TaxSpace       float64 `json:"tax_space"`
@@ -102,7 +107,7 @@ func GetTeamWithRoster(db *pgxpool.Pool, teamID string) (*TeamDetail, error) {
 		SELECT id, first_name, last_name, position, mlb_team,
 		       status_40_man, status_26_man, COALESCE(status_il, ''), option_years_used, options_this_season,
 		       COALESCE(rule_5_eligibility_year, 0), COALESCE(on_trade_block, FALSE),
-		       COALESCE(is_minor_leaguer, FALSE), COALESCE(contract_option_years, '[]'::jsonb),
+		       COALESCE(is_minor_leaguer, FALSE), is_two_way, COALESCE(contract_option_years, '[]'::jsonb),
 		       COALESCE(contract_2026, ''), COALESCE(contract_2027, ''), COALESCE(contract_2028, ''),
 		       COALESCE(contract_2029, ''), COALESCE(contract_2030, ''), COALESCE(contract_2031, ''),
 		       COALESCE(contract_2032, ''), COALESCE(contract_2033, ''), COALESCE(contract_2034, ''),
@@ -126,7 +131,7 @@ func GetTeamWithRoster(db *pgxpool.Pool, teamID string) (*TeamDetail, error) {
 			dest := []interface{}{
 				&p.ID, &p.FirstName, &p.LastName, &p.Position, &p.MLBTeam,
 				&p.Status40Man, &p.Status26Man, &p.StatusIL, &p.OptionYears, &p.OptionsThisSeason,
-				&p.Rule5Year, &p.OnTradeBlock, &p.IsMinorLeaguer, &optionYearsRaw,
+				&p.Rule5Year, &p.OnTradeBlock, &p.IsMinorLeaguer, &p.IsTwoWay, &optionYearsRaw,
 			}
 			for i := range contracts {
 				dest = append(dest, &contracts[i])
@@ -194,9 +199,10 @@ func CalculateYearlySummary(db *pgxpool.Pool, teamID, leagueID string, year int)
 	}
 
 	db.QueryRow(ctx, "SELECT COALESCE(SUM(amount), 0) FROM dead_cap_penalties WHERE team_id = $1 AND year = $2", teamID, year).Scan(&s.DeadCap)
+	db.QueryRow(ctx, "SELECT COALESCE(SUM(amount), 0) FROM retained_salaries WHERE paying_team_id = $1 AND year = $2 AND status = 'active'", teamID, year).Scan(&s.RetainedSalary)
 	db.QueryRow(ctx, "SELECT COALESCE(luxury_tax_limit, 0) FROM league_settings WHERE league_id = $1 AND year = $2", leagueID, year).Scan(&s.LuxuryTaxLimit)
 
-	s.TotalPayroll = s.ActivePayroll + s.DeadCap
+	s.TotalPayroll = s.ActivePayroll + s.DeadCap + s.RetainedSalary
 	if s.LuxuryTaxLimit > 0 {
 		s.TaxSpace = s.LuxuryTaxLimit - s.TotalPayroll
 	}
@@ -339,25 +345,55 @@ func ClaimTeam(db *pgxpool.Pool, teamID, userID, username string) error {
 }
 
 func GetTeamRosterCounts(db *pgxpool.Pool, teamID string) (count26 int, count40 int, err error) {
+	leagueID, _ := GetTeamLeagueID(db, teamID)
+	settings := GetLeagueSettings(db, leagueID, time.Now().Year())
+
+	// Two-way players may take more than one 26-man slot, per league settings
 	err = db.QueryRow(context.Background(), `
 		SELECT
-			COUNT(*) FILTER (WHERE status_26_man = TRUE AND COALESCE(status_il, '') != '60-Day IL'),
+			COALESCE(SUM(CASE WHEN is_two_way THEN $2 ELSE 1 END)
+				FILTER (WHERE status_26_man = TRUE AND COALESCE(status_il, '') != '60-Day IL'), 0)::int,
 			COUNT(*) FILTER (WHERE status_40_man = TRUE AND COALESCE(status_il, '') != '60-Day IL')
 		FROM players
 		WHERE team_id = $1
-	`, teamID).Scan(&count26, &count40)
+	`, teamID, settings.TwoWayRosterSlots).Scan(&count26, &count40)
 	return
 }
 
-// GetTeam26ManSPCount returns the number of SP on the 26-man roster.
+// GetTeam26ManSPCount returns the number of players on the 26-man roster counting toward the
+// SP limit. Two-way players count according to the league's two-way SP rule.
 func GetTeam26ManSPCount(db *pgxpool.Pool, teamID string) (int, error) {
-	var count int
-	err := db.QueryRow(context.Background(), `
-		SELECT COUNT(*) FROM players
-		WHERE team_id = $1 AND status_26_man = TRUE AND position = 'SP'
-			AND COALESCE(status_il, '') != '60-Day IL'
-	`, teamID).Scan(&count)
-	return count, err
+	ctx := context.Background()
+	rows, err := db.Query(ctx, `
+		SELECT p.position, p.is_two_way, t.league_id
+		FROM players p
+		JOIN teams t ON t.id = p.team_id
+		WHERE p.team_id = $1 AND p.status_26_man = TRUE
+			AND (p.position = 'SP' OR p.is_two_way)
+			AND COALESCE(p.status_il, '') != '60-Day IL'
+	`, teamID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var settings *LeagueSettings
+	count := 0
+	for rows.Next() {
+		var position, leagueID string
+		var isTwoWay bool
+		if err := rows.Scan(&position, &isTwoWay, &leagueID); err != nil {
+			return 0, err
+		}
+		if settings == nil {
+			s := GetLeagueSettings(db, leagueID, time.Now().Year())
+			settings = &s
+		}
+		if settings.CountsTowardSPLimit(position, isTwoWay) {
+			count++
+		}
+	}
+	return count, rows.Err()
 }
 
 // GetTeamLeagueID returns the league_id for a team.
@@ -412,11 +448,39 @@ func GetTeamsWithBalances(db *pgxpool.Pool, leagueID string) ([]TeamBalance, err
 	return teams, nil
 }
 
-func SetTeamBalance(db *pgxpool.Pool, teamID string, isbpBalance, milbBalance float64) error {
-	_, err := db.Exec(context.Background(),
-		`UPDATE teams SET isbp_balance = $1, milb_balance = $2 WHERE id = $3`,
-		isbpBalance, milbBalance, teamID)
-	return err
+// SetTeamBalance sets a team's balances, posting the differences to the balance ledger
+// as commissioner adjustments.
+func SetTeamBalance(db *pgxpool.Pool, teamID string, isbpBalance, milbBalance float64, createdBy, note string) error {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var currentISBP, currentMiLB float64
+	err = tx.QueryRow(ctx, `SELECT COALESCE(isbp_balance, 0), COALESCE(milb_balance, 0) FROM teams WHERE id = $1 FOR UPDATE`, teamID).Scan(&currentISBP, &currentMiLB)
+	if err != nil {
+		return err
+	}
+	if note == "" {
+		note = "Balance set by commissioner"
+	}
+
+	for _, adj := range []struct {
+		balanceType string
+		delta       float64
+	}{{"isbp", isbpBalance - currentISBP}, {"milb", milbBalance - currentMiLB}} {
+		if adj.delta == 0 {
+			continue
+		}
+		_, err = PostBalanceEntry(ctx, tx, BalanceEntry{TeamID: teamID, BalanceType: adj.balanceType, Amount: adj.delta,
+			EntryType: "commissioner", Description: note, CreatedBy: createdBy})
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
 }
 
 // --- Team Owner Management ---
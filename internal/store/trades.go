@@ -3,7 +3,6 @@ package store
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -11,10 +10,12 @@ import (
 )
 
 type TradeItem struct {
-	PlayerID     string `json:"player_id"`
-	PlayerName   string `json:"player_name"`
-	SenderTeamID string `json:"sender_team_id"`
-	RetainSalary bool   `json:"retain_salary"`
+	PlayerID          string `json:"player_id"`
+	PlayerName        string `json:"player_name"`
+	SenderTeamID      string `json:"sender_team_id"`
+	RetainSalary      bool   `json:"retain_salary"`
+	RetainPct         int    `json:"retain_pct"`
+	RetainThroughYear int    `json:"retain_through_year"`
 }
 
 type TradeProposal struct {
@@ -63,7 +64,7 @@ func IsTradeWindowOpen(db *pgxpool.Pool, leagueID string) (bool, string) {
 	return true, ""
 }
 
-func CreateTradeProposal(db *pgxpool.Pool, proposerID, receiverID string, offeredPlayers, requestedPlayers, retainedPlayers []string, isbpOffered, isbpRequested int, parentTradeID string) error {
+func CreateTradeProposal(db *pgxpool.Pool, proposerID, receiverID string, offeredPlayers, requestedPlayers []string, retention map[string]TradeRetention, isbpOffered, isbpRequested int, parentTradeID string) error {
 	ctx := context.Background()
 
 	// ISBP balance validation at proposal time
@@ -115,18 +116,12 @@ func CreateTradeProposal(db *pgxpool.Pool, proposerID, receiverID string, offere
 		}
 	}
 
-	// Build retained set for quick lookup
-	retainedSet := make(map[string]bool)
-	for _, pID := range retainedPlayers {
-		retainedSet[pID] = true
-	}
-
 	// 2. Add Offered Players
 	for _, pID := range offeredPlayers {
 		_, err = tx.Exec(ctx, `
-			INSERT INTO trade_items (trade_id, sender_team_id, player_id, retain_salary)
-			VALUES ($1, $2, $3, $4)
-		`, tradeID, proposerID, pID, retainedSet[pID])
+			INSERT INTO trade_items (trade_id, sender_team_id, player_id, retain_salary, retain_pct, retain_through_year)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0))
+		`, tradeID, proposerID, pID, retention[pID].Pct > 0, retention[pID].Pct, retention[pID].ThroughYear)
 		if err != nil {
 			return err
 		}
@@ -135,9 +130,9 @@ func CreateTradeProposal(db *pgxpool.Pool, proposerID, receiverID string, offere
 	// 3. Add Requested Players
 	for _, pID := range requestedPlayers {
 		_, err = tx.Exec(ctx, `
-			INSERT INTO trade_items (trade_id, sender_team_id, player_id, retain_salary)
-			VALUES ($1, $2, $3, $4)
-		`, tradeID, receiverID, pID, retainedSet[pID])
+			INSERT INTO trade_items (trade_id, sender_team_id, player_id, retain_salary, retain_pct, retain_through_year)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0))
+		`, tradeID, receiverID, pID, retention[pID].Pct > 0, retention[pID].Pct, retention[pID].ThroughYear)
 		if err != nil {
 			return err
 		}
@@ -171,7 +166,8 @@ func GetPendingTrades(db *pgxpool.Pool, teamIDs []string) ([]TradeProposal, erro
 
 		// Fetch items for this trade
 		itemRows, err := db.Query(context.Background(), `
-			SELECT ti.player_id, p.first_name || ' ' || p.last_name, ti.sender_team_id, COALESCE(ti.retain_salary, false)
+			SELECT ti.player_id, p.first_name || ' ' || p.last_name, ti.sender_team_id, COALESCE(ti.retain_salary, false),
+			       COALESCE(ti.retain_pct, 0), COALESCE(ti.retain_through_year, 0)
 			FROM trade_items ti
 			JOIN players p ON ti.player_id = p.id
 			WHERE ti.trade_id = $1
@@ -179,7 +175,7 @@ func GetPendingTrades(db *pgxpool.Pool, teamIDs []string) ([]TradeProposal, erro
 		if err == nil {
 			for itemRows.Next() {
 				var item TradeItem
-				if err := itemRows.Scan(&item.PlayerID, &item.PlayerName, &item.SenderTeamID, &item.RetainSalary); err == nil {
+				if err := itemRows.Scan(&item.PlayerID, &item.PlayerName, &item.SenderTeamID, &item.RetainSalary, &item.RetainPct, &item.RetainThroughYear); err == nil {
 					t.Items = append(t.Items, item)
 				}
 			}
@@ -211,7 +207,8 @@ func GetTradeByID(db *pgxpool.Pool, tradeID string) (*TradeProposal, error) {
 
 	// Fetch items
 	rows, err := db.Query(ctx, `
-		SELECT ti.player_id, p.first_name || ' ' || p.last_name, ti.sender_team_id, COALESCE(ti.retain_salary, false)
+		SELECT ti.player_id, p.first_name || ' ' || p.last_name, ti.sender_team_id, COALESCE(ti.retain_salary, false),
+		       COALESCE(ti.retain_pct, 0), COALESCE(ti.retain_through_year, 0)
 		FROM trade_items ti
 		JOIN players p ON ti.player_id = p.id
 		WHERE ti.trade_id = $1
@@ -223,7 +220,7 @@ func GetTradeByID(db *pgxpool.Pool, tradeID string) (*TradeProposal, error) {
 
 	for rows.Next() {
 		var item TradeItem
-		if err := rows.Scan(&item.PlayerID, &item.PlayerName, &item.SenderTeamID, &item.RetainSalary); err == nil {
+		if err := rows.Scan(&item.PlayerID, &item.PlayerName, &item.SenderTeamID, &item.RetainSalary, &item.RetainPct, &item.RetainThroughYear); err == nil {
 			t.Items = append(t.Items, item)
 		}
 	}
@@ -279,7 +276,7 @@ func AcceptTrade(db *pgxpool.Pool, tradeID, acceptorUserID string) error {
 
 	// 3. Process Players (Ownership Transfer & Retention)
 	rows, err := tx.Query(ctx, `
-		SELECT ti.player_id, ti.sender_team_id, COALESCE(ti.retain_salary, false),
+		SELECT ti.player_id, ti.sender_team_id, COALESCE(ti.retain_pct, 0), COALESCE(ti.retain_through_year, 0),
 		       p.first_name || ' ' || p.last_name
 		FROM trade_items ti
 		JOIN players p ON ti.player_id = p.id
@@ -291,25 +288,25 @@ func AcceptTrade(db *pgxpool.Pool, tradeID, acceptorUserID string) error {
 	defer rows.Close()
 
 	type TradeMove struct {
-		PlayerID     string
-		SenderID     string
-		TargetID     string
-		PlayerName   string
-		RetainSalary bool
+		PlayerID   string
+		SenderID   string
+		TargetID   string
+		PlayerName string
+		Retention  TradeRetention
 	}
 	var moves []TradeMove
 
 	for rows.Next() {
 		var pid, sender, name string
-		var retain bool
-		if err := rows.Scan(&pid, &sender, &retain, &name); err != nil {
+		var r TradeRetention
+		if err := rows.Scan(&pid, &sender, &r.Pct, &r.ThroughYear, &name); err != nil {
 			continue
 		}
 		target := receiverID
 		if sender == receiverID {
 			target = proposerID
 		}
-		moves = append(moves, TradeMove{pid, sender, target, name, retain})
+		moves = append(moves, TradeMove{pid, sender, target, name, r})
 	}
 	rows.Close()
 
@@ -336,69 +333,22 @@ func AcceptTrade(db *pgxpool.Pool, tradeID, acceptorUserID string) error {
 			return err
 		}
 
-		var contractStr string
-		err = tx.QueryRow(ctx, fmt.Sprintf("SELECT COALESCE(contract_%d, '') FROM players WHERE id = $1", currentYear), m.PlayerID).Scan(&contractStr)
-		if err != nil || contractStr == "" {
-			continue
-		}
-		// Clean string "$1,500,000" -> 1500000
-		cleanStr := strings.ReplaceAll(strings.ReplaceAll(contractStr, "$", ""), ",", "")
-		salary, _ := strconv.ParseFloat(cleanStr, 64)
-		if salary <= 0 {
-			continue
-		}
-
-		totalDeadCap := 0.0
-		remaining := salary
-
-		// 1. Mandatory date-based retention
-		if retentionPct > 0 {
-			dateDeadCap := salary * retentionPct
-			totalDeadCap += dateDeadCap
-			remaining -= dateDeadCap
-		}
-
-		// 2. Optional 50% retention (on remainder)
-		if m.RetainSalary && remaining > 0 {
-			extraRetention := remaining * 0.50
-			totalDeadCap += extraRetention
-			remaining -= extraRetention
-		}
-
-		if totalDeadCap > 0 {
-			// Update Player Contract
-			_, err = tx.Exec(ctx, fmt.Sprintf("UPDATE players SET contract_%d = $1 WHERE id = $2", currentYear), fmt.Sprintf("%.0f", remaining), m.PlayerID)
-			if err != nil {
-				return err
-			}
-
-			// Insert Dead Cap Record
-			note := ""
-			if retentionPct > 0 && m.RetainSalary {
-				note = fmt.Sprintf("Pro-Rated (%.0f%%) + 50%% Retained", retentionPct*100)
-			} else if m.RetainSalary {
-				note = "50% Salary Retained"
-			} else {
-				note = fmt.Sprintf("Trade Retention (%.0f%%)", retentionPct*100)
-			}
-			_, err = tx.Exec(ctx, `
-				INSERT INTO dead_cap_penalties (team_id, player_id, amount, year, note)
-				VALUES ($1, $2, $3, $4, $5)
-			`, m.SenderID, m.PlayerID, totalDeadCap, currentYear, note)
-			if err != nil {
-				return err
-			}
+		// Record the sender's share in the retained-salary ledger
+		if err = retainTradedSalary(ctx, tx, tradeID, m.PlayerID, m.SenderID, m.TargetID, retentionPct, m.Retention, currentYear); err != nil {
+			return err
 		}
 	}
 
 	// 4. Transfer ISBP
 	if isbpOffered > 0 {
-		_, err = tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance - $1 WHERE id = $2`, isbpOffered, proposerID)
-		_, err = tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance + $1 WHERE id = $2`, isbpOffered, receiverID)
+		if err = TransferBalance(ctx, tx, proposerID, receiverID, "isbp", float64(isbpOffered), "trade", "trade", tradeID, "ISBP sent in trade"); err != nil {
+			return err
+		}
 	}
 	if isbpRequested > 0 {
-		_, err = tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance - $1 WHERE id = $2`, isbpRequested, receiverID)
-		_, err = tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance + $1 WHERE id = $2`, isbpRequested, proposerID)
+		if err = TransferBalance(ctx, tx, receiverID, proposerID, "isbp", float64(isbpRequested), "trade", "trade", tradeID, "ISBP sent in trade"); err != nil {
+			return err
+		}
 	}
 
 	// 5. Update Status & Log
@@ -512,22 +462,33 @@ func ReverseTrade(db *pgxpool.Pool, tradeID string) error {
 
 	// 3. Reverse ISBP transfers
 	if isbpOffered > 0 {
-		tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance + $1 WHERE id = $2`, isbpOffered, proposerID)
-		tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance - $1 WHERE id = $2`, isbpOffered, receiverID)
+		if err = TransferBalance(ctx, tx, receiverID, proposerID, "isbp", float64(isbpOffered), "trade_reversal", "trade", tradeID, "Trade reversed by Commissioner"); err != nil {
+			return err
+		}
 	}
 	if isbpRequested > 0 {
-		tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance + $1 WHERE id = $2`, isbpRequested, receiverID)
-		tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance - $1 WHERE id = $2`, isbpRequested, proposerID)
+		if err = TransferBalance(ctx, tx, proposerID, receiverID, "isbp", float64(isbpRequested), "trade_reversal", "trade", tradeID, "Trade reversed by Commissioner"); err != nil {
+			return err
+		}
 	}
 
-	// 4. Remove trade-specific dead cap penalties
-	var tradeCreatedAt time.Time
-	tx.QueryRow(ctx, `SELECT created_at FROM trades WHERE id = $1`, tradeID).Scan(&tradeCreatedAt)
-	tx.Exec(ctx, `
-		DELETE FROM dead_cap_penalties
-		WHERE (note ILIKE '%Trade Retention%' OR note ILIKE '%Pro-Rated%' OR note ILIKE '%Retained%')
-		AND created_at >= $1
-	`, tradeCreatedAt)
+	// 4. Hand retained salary back to the players' contracts
+	reversed, err := reverseRetainedSalaries(ctx, tx, tradeID)
+	if err != nil {
+		return err
+	}
+	if reversed == 0 {
+		// Trades accepted before the ledger recorded retention as dead cap on the sending team
+		var tradeCreatedAt time.Time
+		tx.QueryRow(ctx, `SELECT created_at FROM trades WHERE id = $1`, tradeID).Scan(&tradeCreatedAt)
+		tx.Exec(ctx, `
+			DELETE FROM dead_cap_penalties
+			WHERE (note ILIKE '%Trade Retention%' OR note ILIKE '%Pro-Rated%' OR note ILIKE '%Retained%')
+			AND created_at >= $1
+			AND team_id IN ($2, $3)
+			AND player_id IN (SELECT player_id FROM trade_items WHERE trade_id = $4)
+		`, tradeCreatedAt, proposerID, receiverID, tradeID)
+	}
 
 	// 5. Set trade status to REVERSED
 	_, err = tx.Exec(ctx, `UPDATE trades SET status = 'REVERSED' WHERE id = $1`, tradeID)
@@ -0,0 +1,483 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// --- Player Valuation ---
+
+// ValuationYear is one remaining contract year: the points a player is expected to score, what
+// that production is worth at the league's rates, what he's paid, and the difference.
+type ValuationYear struct {
+	Year        int     `json:"year"`
+	Age         int     `json:"age"` // 0 when the birth date is unknown
+	Points      float64 `json:"points"`
+	Value       float64 `json:"value"`
+	Salary      float64 `json:"salary"`
+	SalaryLabel string  `json:"salary_label"` // contract cell as stored, e.g. "$5000000", "TC", "ARB 2"
+	Surplus     float64 `json:"surplus"`
+	TeamOption  bool    `json:"team_option"`
+	// OptionDeclined is set when the buyout costs less than the option year's deficit; the year
+	// is then valued as the buyout and the contract ends there.
+	OptionDeclined bool `json:"option_declined"`
+}
+
+// PlayerValuation is a player's production value against his contract. MarketValue is this
+// season's production value, which is what a free agent is worth; Years is empty for players
+// without a contract.
+type PlayerValuation struct {
+	PlayerID          string          `json:"player_id"`
+	Name              string          `json:"name"`
+	Position          string          `json:"position"`
+	PositionGroup     string          `json:"position_group"`
+	TeamID            string          `json:"team_id"`
+	TeamName          string          `json:"team_name"`
+	LeagueID          string          `json:"league_id"`
+	Age               int             `json:"age"`
+	ActualPoints      float64         `json:"actual_points"` // last 365 days
+	ProjectedPoints   float64         `json:"projected_points"`
+	BasePoints        float64         `json:"base_points"`
+	ReplacementPoints float64         `json:"replacement_points"`
+	MarketValue       float64         `json:"market_value"`
+	Years             []ValuationYear `json:"years"`
+	TotalValue        float64         `json:"total_value"`
+	TotalSalary       float64         `json:"total_salary"`
+	TotalSurplus      float64         `json:"total_surplus"`
+	SurplusPV         float64         `json:"surplus_present_value"` // discounted at the league's contract rate
+}
+
+// PackageValuation totals the valuations of one side of a trade.
+type PackageValuation struct {
+	Players      []PlayerValuation `json:"players"`
+	TotalValue   float64           `json:"total_value"`
+	TotalSalary  float64           `json:"total_salary"`
+	TotalSurplus float64           `json:"total_surplus"`
+	SurplusPV    float64           `json:"surplus_present_value"`
+}
+
+// valuationRosterShare is how many of each position a 26-man roster typically carries. Times the
+// number of teams, it sets the rank of the replacement-level player at that position.
+var valuationRosterShare = map[string]float64{
+	"C": 2, "1B": 1.5, "2B": 1.5, "SS": 1.5, "3B": 1.5, "OF": 5, "SP": 6, "RP": 7,
+}
+
+// Weights blending projected and actual points when both are available
+const (
+	valuationProjectedWeight = 0.6
+	valuationActualWeight    = 0.4
+)
+
+// valuationPositionGroup buckets a position string the way roster pages do: first listed
+// position, outfield and utility spots as OF, generic pitchers as SP.
+func valuationPositionGroup(position string, isTwoWay bool) string {
+	if isTwoWay {
+		return "SP"
+	}
+	pos := strings.ToUpper(strings.TrimSpace(position))
+	if idx := strings.Index(pos, ","); idx != -1 {
+		pos = strings.TrimSpace(pos[:idx])
+	}
+	switch pos {
+	case "C", "1B", "2B", "SS", "3B", "OF", "SP", "RP":
+		return pos
+	case "P", "RHP", "LHP", "TWP":
+		return "SP"
+	}
+	return "OF"
+}
+
+// ageCurve is relative production by age: flat through the peak (26-29), climbing before it and
+// declining after.
+func ageCurve(age int) float64 {
+	switch {
+	case age <= 0:
+		return 1
+	case age < 26:
+		return math.Max(0.7, 1-0.04*float64(26-age))
+	case age <= 29:
+		return 1
+	default:
+		return math.Max(0.3, 1-0.05*float64(age-29))
+	}
+}
+
+// ValuationModel holds one league's rates and replacement levels for a season. Building one scores
+// the whole league, so handlers build it once per request and reuse it for every team shown.
+type ValuationModel struct {
+	db          *pgxpool.Pool
+	leagueID    string
+	season      int
+	settings    LeagueSettings
+	formula     ArbitrationFormula
+	actual      map[string]float64
+	projected   map[string]float64
+	replacement map[string]float64
+}
+
+// valuationSeason is the first season still to be played under current contracts: next year
+// once the league's contract rollover for this year has run.
+func valuationSeason(db *pgxpool.Pool, leagueID string) int {
+	season := time.Now().Year()
+	if IsRolloverComplete(db, leagueID, season) {
+		season++
+	}
+	return season
+}
+
+// NewValuationModel builds the valuation model for a league.
+func NewValuationModel(db *pgxpool.Pool, leagueID string) (*ValuationModel, error) {
+	ctx := context.Background()
+	season := valuationSeason(db, leagueID)
+	m := &ValuationModel{
+		db:          db,
+		leagueID:    leagueID,
+		season:      season,
+		settings:    GetLeagueSettings(db, leagueID, season),
+		formula:     GetArbitrationFormula(db, leagueID, season),
+		actual:      make(map[string]float64),
+		replacement: make(map[string]float64),
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT player_id, SUM(fantasy_points) FROM daily_player_stats
+		WHERE league_id = $1 AND game_date > CURRENT_DATE - 365
+		GROUP BY player_id
+	`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id string
+		var pts float64
+		if err := rows.Scan(&id, &pts); err == nil {
+			m.actual[id] = pts
+		}
+	}
+	rows.Close()
+
+	m.projected, err = GetProjectedPoints(db, leagueID, GetProjectionSeason(db, leagueID, time.Now().Year()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replacement level: the points of the Nth-best player at each position, N = teams x roster share
+	var teams int
+	db.QueryRow(ctx, `SELECT COUNT(*) FROM teams WHERE league_id = $1`, leagueID).Scan(&teams)
+	rows, err = db.Query(ctx, `
+		SELECT id, COALESCE(position, ''), is_two_way FROM players WHERE league_id = $1
+	`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	byGroup := make(map[string][]float64)
+	for rows.Next() {
+		var id, position string
+		var isTwoWay bool
+		if err := rows.Scan(&id, &position, &isTwoWay); err != nil {
+			continue
+		}
+		if pts := m.basePoints(id); pts > 0 {
+			group := valuationPositionGroup(position, isTwoWay)
+			byGroup[group] = append(byGroup[group], pts)
+		}
+	}
+	rows.Close()
+
+	for group, share := range valuationRosterShare {
+		pts := byGroup[group]
+		sort.Sort(sort.Reverse(sort.Float64Slice(pts)))
+		rank := int(math.Round(share * float64(teams)))
+		if rank < 1 {
+			rank = 1
+		}
+		if rank <= len(pts) {
+			m.replacement[group] = pts[rank-1]
+		}
+	}
+	return m, nil
+}
+
+// basePoints blends a player's projection with his last 365 days, or uses whichever exists.
+func (m *ValuationModel) basePoints(playerID string) float64 {
+	proj, actual := m.projected[playerID], m.actual[playerID]
+	switch {
+	case proj != 0 && actual != 0:
+		return valuationProjectedWeight*proj + valuationActualWeight*actual
+	case proj != 0:
+		return proj
+	default:
+		return actual
+	}
+}
+
+// productionValue prices a season's points: a replacement-level player is worth the league
+// minimum, and each point above replacement adds the league's arbitration dollars per point.
+func (m *ValuationModel) productionValue(points, replacement float64) float64 {
+	floor := m.settings.MinSalary
+	if replacement > 0 && points < replacement {
+		return math.Max(0, floor*points/replacement)
+	}
+	return floor + (points-replacement)*m.formula.DollarsPerPoint
+}
+
+// contractSalary prices one contract cell. Team control costs the minimum, arbitration years
+// use the league's formula on the prior year's points, and a minor league deal costs nothing
+// against payroll. ok is false once the contract has ended, and for any other label, which
+// can't be priced.
+func (m *ValuationModel) contractSalary(cell string, priorPoints float64) (salary float64, ok bool) {
+	upper := strings.ToUpper(strings.TrimSpace(cell))
+	switch {
+	case upper == "" || upper == "UFA":
+		return 0, false
+	case upper == "TC":
+		return m.settings.MinSalary, true
+	case strings.HasPrefix(upper, "ARB"):
+		return m.formula.PlayerFigure(upper, priorPoints), true
+	case upper == "MILB":
+		return 0, true
+	}
+	amount := strings.NewReplacer("$", "", ",", "").Replace(upper)
+	amount = strings.TrimSpace(strings.Split(amount, "(")[0])
+	salary, err := strconv.ParseFloat(amount, 64)
+	return salary, err == nil
+}
+
+type valuationPlayer struct {
+	ID, Name, Position, TeamID, TeamName, LeagueID string
+	IsTwoWay                                       bool
+	BirthDate                                      *time.Time
+	Contracts                                      map[int]string
+	OptionBuyouts                                  map[int]float64 // pending team/mutual option clauses by year
+}
+
+func (m *ValuationModel) value(p valuationPlayer) PlayerValuation {
+	v := PlayerValuation{
+		PlayerID: p.ID, Name: p.Name, Position: p.Position, TeamID: p.TeamID, TeamName: p.TeamName, LeagueID: p.LeagueID,
+		PositionGroup:   valuationPositionGroup(p.Position, p.IsTwoWay),
+		ActualPoints:    m.actual[p.ID],
+		ProjectedPoints: m.projected[p.ID],
+		BasePoints:      m.basePoints(p.ID),
+		Years:           []ValuationYear{},
+	}
+	v.ReplacementPoints = m.replacement[v.PositionGroup]
+
+	ageIn := func(year int) int {
+		if p.BirthDate == nil {
+			return 0
+		}
+		// Age on July 1, mid-season
+		age := year - p.BirthDate.Year()
+		if p.BirthDate.Month() > time.July || (p.BirthDate.Month() == time.July && p.BirthDate.Day() > 1) {
+			age--
+		}
+		return age
+	}
+	v.Age = ageIn(m.season)
+	v.MarketValue = m.productionValue(v.BasePoints, v.ReplacementPoints)
+
+	prior := v.ActualPoints
+	for year := m.season; year <= 2040; year++ {
+		cell := ""
+		if p.TeamID != "" {
+			cell = p.Contracts[year]
+		}
+		points := v.BasePoints
+		if age := ageIn(year); age > 0 {
+			points = v.BasePoints * ageCurve(age) / ageCurve(v.Age)
+		}
+		salary, ok := m.contractSalary(cell, prior)
+		if !ok {
+			break
+		}
+		y := ValuationYear{
+			Year: year, Age: ageIn(year), Points: math.Round(points*10) / 10,
+			Value: math.Round(m.productionValue(points, v.ReplacementPoints)), Salary: salary, SalaryLabel: cell,
+		}
+		y.Surplus = y.Value - y.Salary
+		if isTeamOptionCell(cell) {
+			// The team picks up the option only when the year is worth more than the buyout
+			y.TeamOption = true
+			if buyout := declinedOptionBuyout(p.OptionBuyouts[year], salary); y.Surplus < -buyout {
+				y.Points, y.Value, y.Salary, y.Surplus = 0, 0, buyout, -buyout
+				y.OptionDeclined = true
+			}
+		}
+		v.TotalValue += y.Value
+		v.TotalSalary += y.Salary
+		v.TotalSurplus += y.Surplus
+		v.SurplusPV += y.Surplus / math.Pow(1+m.settings.DiscountRate, float64(len(v.Years)))
+		v.Years = append(v.Years, y)
+		if y.OptionDeclined {
+			break
+		}
+		prior = points
+	}
+	v.MarketValue = math.Round(v.MarketValue)
+	v.SurplusPV = math.Round(v.SurplusPV)
+	return v
+}
+
+// GetPlayerValuations values players from one league: production (projected and actual points,
+// adjusted for age and priced above the position's replacement level) against each remaining
+// contract year. Players are returned in the order requested; unknown IDs are skipped.
+func GetPlayerValuations(db *pgxpool.Pool, leagueID string, playerIDs []string) ([]PlayerValuation, error) {
+	if len(playerIDs) == 0 {
+		return []PlayerValuation{}, nil
+	}
+	model, err := NewValuationModel(db, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return model.PlayerValuations(playerIDs)
+}
+
+// PlayerValuations values players from the model's league, as GetPlayerValuations does.
+func (m *ValuationModel) PlayerValuations(playerIDs []string) ([]PlayerValuation, error) {
+	if len(playerIDs) == 0 {
+		return []PlayerValuation{}, nil
+	}
+	ctx := context.Background()
+	buyouts := make(map[string]map[int]float64)
+	optRows, err := m.db.Query(ctx, `
+		SELECT DISTINCT ON (player_id, year) player_id::TEXT, year, COALESCE(buyout, 0)
+		FROM contract_options
+		WHERE player_id::TEXT = ANY($1) AND option_type IN ('team', 'mutual') AND status = 'pending'
+		ORDER BY player_id, year, created_at DESC
+	`, playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	for optRows.Next() {
+		var playerID string
+		var year int
+		var buyout float64
+		if err := optRows.Scan(&playerID, &year, &buyout); err != nil {
+			optRows.Close()
+			return nil, err
+		}
+		if buyouts[playerID] == nil {
+			buyouts[playerID] = make(map[int]float64)
+		}
+		buyouts[playerID][year] = buyout
+	}
+	optRows.Close()
+
+	rows, err := m.db.Query(ctx, `
+		SELECT p.id, p.first_name || ' ' || p.last_name, COALESCE(p.position, ''), p.is_two_way,
+		       COALESCE(p.team_id::TEXT, ''), COALESCE(t.name, ''), p.league_id, mp.birth_date,
+		       COALESCE(p.contract_2026, ''), COALESCE(p.contract_2027, ''), COALESCE(p.contract_2028, ''),
+		       COALESCE(p.contract_2029, ''), COALESCE(p.contract_2030, ''), COALESCE(p.contract_2031, ''),
+		       COALESCE(p.contract_2032, ''), COALESCE(p.contract_2033, ''), COALESCE(p.contract_2034, ''),
+		       COALESCE(p.contract_2035, ''), COALESCE(p.contract_2036, ''), COALESCE(p.contract_2037, ''),
+		       COALESCE(p.contract_2038, ''), COALESCE(p.contract_2039, ''), COALESCE(p.contract_2040, '')
+		FROM players p
+		LEFT JOIN teams t ON t.id = p.team_id
+		LEFT JOIN mlb_players mp ON mp.id = p.mlb_player_id
+		WHERE p.league_id = $1 AND p.id::TEXT = ANY($2)
+	`, m.leagueID, playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[string]PlayerValuation)
+	for rows.Next() {
+		var p valuationPlayer
+		contracts := make([]string, 15)
+		dest := []interface{}{&p.ID, &p.Name, &p.Position, &p.IsTwoWay, &p.TeamID, &p.TeamName, &p.LeagueID, &p.BirthDate}
+		for i := range contracts {
+			dest = append(dest, &contracts[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		p.Contracts = make(map[int]string)
+		for i, c := range contracts {
+			p.Contracts[2026+i] = c
+		}
+		p.OptionBuyouts = buyouts[p.ID]
+		byID[p.ID] = m.value(p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	valuations := []PlayerValuation{}
+	for _, id := range playerIDs {
+		if v, ok := byID[id]; ok {
+			valuations = append(valuations, v)
+		}
+	}
+	return valuations, nil
+}
+
+// GetPlayerValuation values a single player in the player's own league.
+func GetPlayerValuation(db *pgxpool.Pool, playerID string) (*PlayerValuation, error) {
+	var leagueID string
+	if err := db.QueryRow(context.Background(), `SELECT league_id FROM players WHERE id = $1`, playerID).Scan(&leagueID); err != nil {
+		return nil, fmt.Errorf("player not found")
+	}
+	valuations, err := GetPlayerValuations(db, leagueID, []string{playerID})
+	if err != nil {
+		return nil, err
+	}
+	if len(valuations) == 0 {
+		return nil, fmt.Errorf("player not found")
+	}
+	return &valuations[0], nil
+}
+
+// ComparePackages values two sides of a trade in one league.
+func ComparePackages(db *pgxpool.Pool, leagueID string, sideA, sideB []string) (PackageValuation, PackageValuation, error) {
+	all, err := GetPlayerValuations(db, leagueID, append(append([]string{}, sideA...), sideB...))
+	if err != nil {
+		return PackageValuation{}, PackageValuation{}, err
+	}
+	inA := make(map[string]bool)
+	for _, id := range sideA {
+		inA[id] = true
+	}
+	a := PackageValuation{Players: []PlayerValuation{}}
+	b := PackageValuation{Players: []PlayerValuation{}}
+	for _, v := range all {
+		pkg := &b
+		if inA[v.PlayerID] {
+			pkg = &a
+		}
+		pkg.Players = append(pkg.Players, v)
+		pkg.TotalValue += v.TotalValue
+		pkg.TotalSalary += v.TotalSalary
+		pkg.TotalSurplus += v.TotalSurplus
+		pkg.SurplusPV += v.SurplusPV
+	}
+	return a, b, nil
+}
+
+// ApplyValuations fills SurplusValue on players from the model's league with their discounted
+// contract surplus.
+func ApplyValuations(m *ValuationModel, players []RosterPlayer) error {
+	ids := make([]string, len(players))
+	for i, p := range players {
+		ids[i] = p.ID
+	}
+	valuations, err := m.PlayerValuations(ids)
+	if err != nil {
+		return err
+	}
+	surplus := make(map[string]float64, len(valuations))
+	for _, v := range valuations {
+		surplus[v.PlayerID] = v.SurplusPV
+	}
+	for i := range players {
+		players[i].SurplusValue = surplus[players[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// liveAlertEvent is something that happened in a game that a league's alert rules may care about.
+type liveAlertEvent struct {
+	Type       string // alert rule event type; "stat_line" feeds stat_threshold rules
+	Key        string // unique within the type, e.g. gamePk_atBatIndex
+	GamePk     int
+	MLBID      int
+	PlayerName string
+	StatType   string
+	Raw        map[string]float64
+	Detail     string
+}
+
+// StartAlertMonitor polls live games during game hours and posts Slack alerts for each league's
+// alert rules (home runs, saves, quality starts, stolen bases, no-hitters, injury exits, stat
+// thresholds). Runs noon-4 AM ET during season (Mar 25-Oct); posted alerts are recorded in
+// alert_events so a restart picks up where it left off.
+func StartAlertMonitor(ctx context.Context, db *pgxpool.Pool) {
+	go func() {
+		// Games already scanned after going Final, by date; dates no longer scanned are dropped
+		finished := make(map[string]map[int]bool)
+
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Println("Alert monitor stopped")
+				return
+			case <-ticker.C:
+				loc, err := time.LoadLocation("America/New_York")
+				if err != nil {
+					loc = time.FixedZone("EST", -5*60*60)
+				}
+				et := time.Now().In(loc)
+
+				month := et.Month()
+				day := et.Day()
+				hour := et.Hour()
+				if month < 3 || month > 10 || (month == 3 && day < 25) || (hour >= 4 && hour < 12) {
+					continue
+				}
+
+				rules, err := store.GetActiveAlertRules(db)
+				if err != nil {
+					fmt.Printf("ERROR [AlertMonitor]: load rules: %v\n", err)
+					continue
+				}
+				if len(rules) == 0 {
+					continue
+				}
+
+				dates := []string{et.Format("2006-01-02")}
+				if hour < 4 {
+					dates = append([]string{et.AddDate(0, 0, -1).Format("2006-01-02")}, dates...)
+				}
+				scanned := make(map[string]map[int]bool, len(dates))
+				for _, date := range dates {
+					if finished[date] == nil {
+						finished[date] = make(map[int]bool)
+					}
+					scanned[date] = finished[date]
+					checkAlerts(ctx, db, date, rules, scanned[date])
+				}
+				finished = scanned
+			}
+		}
+	}()
+}
+
+func checkAlerts(ctx context.Context, db *pgxpool.Pool, date string, rules []store.AlertRule, finished map[int]bool) {
+	schedule, err := mlbapi.Default().Schedule(ctx, mlbapi.ScheduleQuery{Date: date})
+	if err != nil {
+		fmt.Printf("ERROR [AlertMonitor]: schedule %s: %v\n", date, err)
+		return
+	}
+
+	for _, game := range schedule.Games() {
+		state := game.Status.AbstractGameState
+		if (state != "Live" && state != "Final") || finished[game.GamePk] {
+			continue
+		}
+
+		feed, err := mlbapi.Default().LiveFeed(ctx, game.GamePk)
+		if err != nil {
+			fmt.Printf("ERROR [AlertMonitor]: game %d feed: %v\n", game.GamePk, err)
+			continue
+		}
+
+		for _, ev := range gameAlertEvents(game.GamePk, feed) {
+			for _, rule := range rules {
+				if alertRuleMatches(rule, ev) {
+					sendAlert(db, rule, ev)
+				}
+			}
+		}
+
+		if feed.GameData.Status.AbstractGameState == "Final" {
+			finished[game.GamePk] = true
+		}
+	}
+}
+
+// gameAlertEvents extracts every alertable event from a game's live feed.
+func gameAlertEvents(gamePk int, feed *mlbapi.LiveFeed) []liveAlertEvent {
+	var events []liveAlertEvent
+	box := &feed.LiveData.Boxscore
+	final := feed.GameData.Status.AbstractGameState == "Final"
+
+	names := make(map[int]string)
+	for _, side := range []mlbapi.BoxScoreSide{box.Teams.Away, box.Teams.Home} {
+		for _, p := range side.Players {
+			names[p.Person.ID] = p.Person.FullName
+		}
+	}
+
+	// Play-by-play: home runs, stolen bases, injury exits
+	lastPitcher := 0
+	for _, play := range feed.LiveData.Plays.AllPlays {
+		if play.About.IsComplete && play.Result.Event == "Home Run" {
+			events = append(events, liveAlertEvent{
+				Type:       "home_run",
+				Key:        fmt.Sprintf("%d_%d", gamePk, play.About.AtBatIndex),
+				GamePk:     gamePk,
+				MLBID:      play.MatchUp.Batter.ID,
+				PlayerName: play.MatchUp.Batter.FullName,
+				Detail:     play.Result.Description,
+			})
+		}
+
+		for _, r := range play.Runners {
+			if !strings.HasPrefix(r.Details.EventType, "stolen_base") {
+				continue
+			}
+			events = append(events, liveAlertEvent{
+				Type:       "stolen_base",
+				Key:        fmt.Sprintf("%d_%d_%d_%d", gamePk, play.About.AtBatIndex, r.Details.PlayIndex, r.Details.Runner.ID),
+				GamePk:     gamePk,
+				MLBID:      r.Details.Runner.ID,
+				PlayerName: r.Details.Runner.FullName,
+				Detail:     r.Details.Event,
+			})
+		}
+
+		// An injury delay followed by a pitching change means the pitcher left hurt
+		injury := ""
+		for _, pe := range play.PlayEvents {
+			if pe.Details.EventType == "injury" {
+				injury = pe.Details.Description
+				continue
+			}
+			if injury == "" || pe.Details.EventType != "pitching_substitution" {
+				continue
+			}
+			exiting := pe.ReplacedPlayer.ID
+			if exiting == 0 {
+				exiting = lastPitcher
+			}
+			if exiting != 0 && exiting != pe.Player.ID {
+				events = append(events, liveAlertEvent{
+					Type:       "injury_exit",
+					Key:        fmt.Sprintf("%d_%d", gamePk, exiting),
+					GamePk:     gamePk,
+					MLBID:      exiting,
+					PlayerName: names[exiting],
+					Detail:     injury,
+				})
+			}
+			injury = ""
+		}
+		lastPitcher = play.MatchUp.Pitcher.ID
+	}
+
+	// Box score: stat lines, saves, quality starts, no-hitters in progress
+	sides := []mlbapi.BoxScoreSide{box.Teams.Away, box.Teams.Home}
+	for i, side := range sides {
+		opponent := sides[1-i]
+
+		hitsAllowed := 0.0
+		for _, p := range opponent.Players {
+			hitsAllowed += toFloat(p.Stats.Batting.Hits)
+		}
+		teamIP := 0.0
+		starter := 0
+
+		for _, p := range side.Players {
+			mlbID := p.Person.ID
+			batting := p.Stats.Batting
+			if toFloat(batting.AtBats) > 0 || toFloat(batting.PlateAppearances) > 0 {
+				events = append(events, liveAlertEvent{
+					Type: "stat_line", Key: fmt.Sprintf("%d_%d_hitting", gamePk, mlbID), GamePk: gamePk,
+					MLBID: mlbID, PlayerName: p.Person.FullName, StatType: "hitting", Raw: extractHittingRawStats(batting),
+				})
+			}
+			if p.Stats.Pitching.InningsPitched == "" {
+				continue
+			}
+
+			raw := extractPitchingRawStats(p.Stats.Pitching)
+			teamIP += raw["ip"]
+			if raw["gs"] > 0 {
+				starter = mlbID
+			}
+			line := liveAlertEvent{
+				Key: fmt.Sprintf("%d_%d", gamePk, mlbID), GamePk: gamePk, MLBID: mlbID,
+				PlayerName: p.Person.FullName, StatType: "pitching", Raw: raw,
+			}
+
+			stat := line
+			stat.Type = "stat_line"
+			stat.Key += "_pitching"
+			events = append(events, stat)
+
+			if raw["sv"] > 0 {
+				save := line
+				save.Type = "save"
+				events = append(events, save)
+			}
+			// QS can only be judged once the start is over
+			if final && raw["qs"] > 0 && raw["gs"] > 0 {
+				qs := line
+				qs.Type = "quality_start"
+				events = append(events, qs)
+			}
+		}
+
+		if !final && starter != 0 && hitsAllowed == 0 && teamIP > 0 {
+			events = append(events, liveAlertEvent{
+				Type:       "no_hitter",
+				Key:        fmt.Sprintf("%d_%d", gamePk, side.Team.ID),
+				GamePk:     gamePk,
+				MLBID:      starter,
+				PlayerName: names[starter],
+				Raw:        map[string]float64{"ip": teamIP},
+				Detail:     fmt.Sprintf("%s vs %s", side.Team.Abbreviation, opponent.Team.Abbreviation),
+			})
+		}
+	}
+
+	return events
+}
+
+// alertRuleMatches reports whether an event is the kind a rule watches for. Who qualifies is
+// checked separately against the rule's league.
+func alertRuleMatches(rule store.AlertRule, ev liveAlertEvent) bool {
+	switch rule.EventType {
+	case "stat_threshold":
+		return ev.Type == "stat_line" && ev.StatType == rule.StatType && ev.Raw[rule.StatKey] >= rule.Threshold
+	case "no_hitter":
+		// threshold is the minimum innings before alerting (6 if unset)
+		minIP := rule.Threshold
+		if minIP <= 0 {
+			minIP = 6
+		}
+		return ev.Type == "no_hitter" && ev.Raw["ip"] >= minIP
+	default:
+		return ev.Type == rule.EventType
+	}
+}
+
+// sendAlert posts an event for a rule if the player is rostered in the rule's league and in scope.
+func sendAlert(db *pgxpool.Pool, rule store.AlertRule, ev liveAlertEvent) {
+	playerID, teamID, teamName, ok := store.GetRosteredCopy(db, ev.MLBID, rule.LeagueID)
+	if !ok {
+		return
+	}
+	switch rule.Scope {
+	case "teams":
+		if !containsString(rule.TeamIDs, teamID) {
+			return
+		}
+	case "players":
+		if !containsString(rule.PlayerIDs, playerID) {
+			return
+		}
+	}
+
+	msg := formatAlert(rule, ev, teamName)
+	eventKey := ev.Key
+	if rule.EventType == "stat_threshold" {
+		eventKey += "_" + rule.StatKey
+	}
+
+	claimed, err := store.ClaimAlertEvent(db, rule.ID, eventKey, ev.GamePk, ev.MLBID, msg)
+	if err != nil {
+		fmt.Printf("ERROR [AlertMonitor]: claim %s %s: %v\n", rule.ID, eventKey, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if rule.ChannelID != "" {
+		err = notification.SendSlackToChannel(db, rule.LeagueID, rule.ChannelID, msg)
+	} else {
+		err = notification.SendSlackNotification(db, rule.LeagueID, rule.NotifyType, msg)
+	}
+	if err != nil {
+		fmt.Printf("ERROR [AlertMonitor]: slack for rule %s: %v\n", rule.ID, err)
+		store.ReleaseAlertEvent(db, rule.ID, eventKey)
+	}
+}
+
+func formatAlert(rule store.AlertRule, ev liveAlertEvent, teamName string) string {
+	switch ev.Type {
+	case "home_run":
+		return fmt.Sprintf("⚾ *HOME RUN!* %s (rostered by *%s*) just hit a home run!", ev.PlayerName, teamName)
+	case "stolen_base":
+		return fmt.Sprintf("💨 *STOLEN BASE* %s (*%s*): %s", ev.PlayerName, teamName, ev.Detail)
+	case "save":
+		return fmt.Sprintf("🔒 *SAVE* %s (*%s*) closed it out: %s", ev.PlayerName, teamName, store.StatLineSummary("pitching", ev.Raw))
+	case "quality_start":
+		return fmt.Sprintf("✅ *QUALITY START* %s (*%s*): %s", ev.PlayerName, teamName, store.StatLineSummary("pitching", ev.Raw))
+	case "no_hitter":
+		outs := int(math.Round(ev.Raw["ip"] * 3))
+		return fmt.Sprintf("🚨 *NO-HITTER IN PROGRESS* %s (*%s*) has a no-hitter going through %d.%d innings (%s)",
+			ev.PlayerName, teamName, outs/3, outs%3, ev.Detail)
+	case "injury_exit":
+		return fmt.Sprintf("🚑 *INJURY EXIT* %s (*%s*) left the game: %s", ev.PlayerName, teamName, ev.Detail)
+	default:
+		return fmt.Sprintf("📈 *%s* %s (*%s*): %s", rule.Name, ev.PlayerName, teamName, store.StatLineSummary(ev.StatType, ev.Raw))
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
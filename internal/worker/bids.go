@@ -2,9 +2,11 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -29,7 +31,7 @@ func finalizeBids(db *pgxpool.Pool) {
 
 	rows, err := db.Query(ctx, `
 		SELECT p.id, p.first_name, p.last_name, p.pending_bid_team_id, p.pending_bid_years, p.pending_bid_aav,
-		       COALESCE(p.bid_type, 'standard'), t.name, p.league_id::TEXT
+		       COALESCE(p.bid_type, 'standard'), t.name, p.league_id::TEXT, p.pending_bid_schedule
 		FROM players p
 		JOIN teams t ON t.id = p.pending_bid_team_id
 		WHERE p.fa_status = 'pending_bid' AND p.bid_end_time <= NOW()
@@ -44,15 +46,27 @@ func finalizeBids(db *pgxpool.Pool) {
 		var pID, fName, lName, teamID, bidType, teamName, leagueID string
 		var years int
 		var aav float64
-		if err := rows.Scan(&pID, &fName, &lName, &teamID, &years, &aav, &bidType, &teamName, &leagueID); err != nil {
+		var scheduleJSON []byte
+		if err := rows.Scan(&pID, &fName, &lName, &teamID, &years, &aav, &bidType, &teamName, &leagueID, &scheduleJSON); err != nil {
 			continue
 		}
 
+		// Per-year salaries of the winning bid; bids placed before schedules existed are flat
+		var schedule []float64
+		json.Unmarshal(scheduleJSON, &schedule)
+		if len(schedule) != years {
+			schedule = nil
+			for i := 0; i < years; i++ {
+				schedule = append(schedule, aav)
+			}
+		}
+
 		tx, err := db.Begin(ctx)
 		if err != nil {
 			continue
 		}
 
+		var qoNote string
 		if bidType == "ifa" {
 			// IFA signing: deduct from ISBP, no contract written, non-40-man minors
 			_, err = tx.Exec(ctx, `
@@ -72,7 +86,9 @@ func finalizeBids(db *pgxpool.Pool) {
 				continue
 			}
 
-			_, err = tx.Exec(ctx, `UPDATE teams SET isbp_balance = isbp_balance - $1 WHERE id = $2`, aav, teamID)
+			_, err = store.PostBalanceEntry(ctx, tx, store.BalanceEntry{TeamID: teamID, BalanceType: "isbp", Amount: -aav,
+				EntryType: "signing", ReferenceType: "player", ReferenceID: pID,
+				Description: fmt.Sprintf("IFA signing: %s %s", fName, lName)})
 			if err != nil {
 				tx.Rollback(ctx)
 				fmt.Printf("❌ Worker: Failed to deduct ISBP for %s %s: %v\n", fName, lName, err)
@@ -97,7 +113,9 @@ func finalizeBids(db *pgxpool.Pool) {
 				continue
 			}
 
-			_, err = tx.Exec(ctx, `UPDATE teams SET milb_balance = milb_balance - $1 WHERE id = $2`, aav, teamID)
+			_, err = store.PostBalanceEntry(ctx, tx, store.BalanceEntry{TeamID: teamID, BalanceType: "milb", Amount: -aav,
+				EntryType: "signing", ReferenceType: "player", ReferenceID: pID,
+				Description: fmt.Sprintf("MiLB signing: %s %s", fName, lName)})
 			if err != nil {
 				tx.Rollback(ctx)
 				fmt.Printf("❌ Worker: Failed to deduct MiLB balance for %s %s: %v\n", fName, lName, err)
@@ -105,14 +123,13 @@ func finalizeBids(db *pgxpool.Pool) {
 			}
 			fmt.Printf("💰 Worker: Deducted $%.0f MiLB from Team %s for MiLB signing of %s %s\n", aav, teamID, fName, lName)
 		} else {
-			// Standard signing: write contract for all years, mark DFA-only
+			// Standard signing: write each year of the salary schedule, mark DFA-only
 			currentYear := time.Now().Year()
-			aavStr := fmt.Sprintf("%.0f", aav)
-			setClauses := `team_id = $1, fa_status = 'rostered', status_40_man = TRUE, status_il = NULL, dfa_only = TRUE, pending_bid_amount = NULL, pending_bid_team_id = NULL`
-			for i := 0; i < years; i++ {
+			setClauses := `team_id = $1, fa_status = 'rostered', status_40_man = TRUE, status_il = NULL, dfa_only = TRUE, pending_bid_amount = NULL, pending_bid_team_id = NULL, pending_bid_schedule = NULL`
+			for i, salary := range schedule {
 				yr := currentYear + i
 				if yr >= 2026 && yr <= 2040 {
-					setClauses += fmt.Sprintf(", contract_%d = '%s'", yr, aavStr)
+					setClauses += fmt.Sprintf(", contract_%d = '%.0f'", yr, salary)
 				}
 			}
 			_, err = tx.Exec(ctx, fmt.Sprintf(`UPDATE players SET %s WHERE id = $2`, setClauses), teamID, pID)
@@ -121,6 +138,14 @@ func finalizeBids(db *pgxpool.Pool) {
 				tx.Rollback(ctx)
 				continue
 			}
+
+			// Player declined a qualifying offer: the original team is owed compensation
+			qoNote, err = store.ApplyQOCompensation(ctx, tx, pID, teamID)
+			if err != nil {
+				tx.Rollback(ctx)
+				fmt.Printf("❌ Worker: Failed to apply QO compensation for %s %s: %v\n", fName, lName, err)
+				continue
+			}
 		}
 
 		// Build summary with signing type
@@ -132,7 +157,11 @@ func finalizeBids(db *pgxpool.Pool) {
 		case "milb":
 			summary = fmt.Sprintf("%s signed %s as MiLB Free Agent ($%.0f)", teamName, playerName, aav)
 		default:
-			summary = fmt.Sprintf("%s signed %s as Free Agent (%dyr/$%.0f)", teamName, playerName, years, aav)
+			summary = fmt.Sprintf("%s signed %s as Free Agent (%dyr/$%.0f)%s", teamName, playerName, years, aav, qoNote)
+			if !store.IsFlatSchedule(schedule) {
+				summary = fmt.Sprintf("%s signed %s as Free Agent (%dyr/$%.0f total: %s)%s", teamName, playerName, years,
+					store.ScheduleTotal(schedule), store.DescribeSchedule(schedule), qoNote)
+			}
 		}
 
 		_, err = tx.Exec(ctx, `
@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// duesReminderInterval is how long to wait between reminders for the same overdue invoice.
+const duesReminderInterval = 7 * 24 * time.Hour
+
+// StartDuesReminderWorker emails owners of overdue IRL dues invoices once a day at 10 AM ET,
+// repeating weekly until the invoice is paid.
+func StartDuesReminderWorker(ctx context.Context, db *pgxpool.Pool) {
+	ticker := time.NewTicker(1 * time.Hour)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Println("Dues reminder worker stopped")
+				return
+			case <-ticker.C:
+				sendDuesRemindersIfDue(ctx, db)
+			}
+		}
+	}()
+}
+
+func sendDuesRemindersIfDue(ctx context.Context, db *pgxpool.Pool) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("ET", -5*3600)
+	}
+	now := time.Now().In(loc)
+	if now.Hour() != 10 {
+		return
+	}
+
+	key := fmt.Sprintf("dues_reminders_%s", now.Format("2006-01-02"))
+	if hasRunThisYear(db, ctx, key) {
+		return
+	}
+	markAsRun(db, ctx, key)
+
+	invoices, err := store.GetOverdueDuesInvoices(db, duesReminderInterval)
+	if err != nil {
+		fmt.Printf("Dues Reminder Worker Error: %v\n", err)
+		return
+	}
+	sent := 0
+	for i := range invoices {
+		if SendDuesReminder(db, &invoices[i]) {
+			sent++
+		}
+	}
+	if sent > 0 {
+		fmt.Printf("Dues Reminder Worker: Sent %d overdue dues reminders\n", sent)
+	}
+}
+
+// SendDuesReminder emails the team's owners their outstanding balance and records the reminder.
+// Returns false if the team has no owner email on file. Exported so commissioners can send one
+// immediately from the dues page.
+func SendDuesReminder(db *pgxpool.Pool, inv *store.DuesInvoice) bool {
+	emails, _ := store.GetTeamOwnerEmails(db, inv.TeamID)
+	if len(emails) == 0 {
+		return false
+	}
+
+	due := "now"
+	if inv.DueDate != nil {
+		due = inv.DueDate.Format("January 2, 2006")
+	}
+	body := fmt.Sprintf("<h2>League Dues Reminder</h2>"+
+		"<p><strong>%s</strong> has an outstanding %d dues balance of <strong>$%.2f</strong> (invoiced $%.2f, paid $%.2f), due %s.</p>"+
+		"<p>Please send payment to the league treasurer. If you've already paid, reply so the commissioner can record it.</p>"+
+		"<p><a href=\"https://frontofficedynastysports.com/league/irl-financials?league_id=%s&year=%d\">View IRL Financials</a></p>",
+		inv.TeamName, inv.Year, inv.Outstanding, inv.Total, inv.Paid, due, inv.LeagueID, inv.Year)
+	for _, email := range emails {
+		notification.SendEmail(email, fmt.Sprintf("Dues Reminder: $%.2f outstanding for %s", inv.Outstanding, inv.TeamName), body)
+	}
+	store.MarkDuesReminded(db, inv.ID)
+	return true
+}
@@ -0,0 +1,199 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// liveScoringInterval is how often in-progress games are re-scored from the live feed.
+const liveScoringInterval = 2 * time.Minute
+
+// StartLiveScoringWorker scores in-progress MLB games from the live feed into live_player_stats.
+// Ticks every 2 minutes; runs 11 AM-4 AM ET during season (Mar 25-Oct). Lines stay provisional
+// until the nightly stats pass stores the final box score and clears the date. Each day's roster
+// snapshot is locked on the first tick after the day's earliest scheduled game, whatever the hour
+// or month, so early international and day games are covered.
+func StartLiveScoringWorker(ctx context.Context, db *pgxpool.Pool) {
+	go func() {
+		// Games already scored after going Final; their live lines won't change again.
+		// Reset when the earliest scored date rolls over so the map only holds the current slate.
+		finalized := make(map[int]bool)
+		var finalizedFrom string
+		var lock firstPitchLock
+		ticker := time.NewTicker(liveScoringInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Println("Live scoring worker stopped")
+				return
+			case <-ticker.C:
+				loc, err := time.LoadLocation("America/New_York")
+				if err != nil {
+					loc = time.FixedZone("EST", -5*60*60)
+				}
+				et := time.Now().In(loc)
+
+				lock.check(ctx, db, et.Format("2006-01-02"))
+
+				month := et.Month()
+				day := et.Day()
+				hour := et.Hour()
+				if month < 3 || month > 10 || (month == 3 && day < 25) || (hour >= 4 && hour < 11) {
+					continue
+				}
+
+				// Late games run past midnight ET; keep scoring yesterday's slate until 4 AM
+				dates := []string{et.Format("2006-01-02")}
+				if hour < 4 {
+					dates = append([]string{et.AddDate(0, 0, -1).Format("2006-01-02")}, dates...)
+				}
+				if dates[0] != finalizedFrom {
+					finalized = make(map[int]bool)
+					finalizedFrom = dates[0]
+				}
+				for _, date := range dates {
+					if err := ProcessLiveScoring(ctx, db, date, finalized); err != nil {
+						fmt.Printf("ERROR [LiveScoring]: %s: %v\n", date, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// ProcessLiveScoring re-scores every started game on a date that nightly processing hasn't finalized.
+// finalized tracks games already scored in their Final state and may be nil.
+func ProcessLiveScoring(ctx context.Context, db *pgxpool.Pool, date string, finalized map[int]bool) error {
+	if store.IsDateProcessed(db, date, "pitching") && store.IsDateProcessed(db, date, "hitting") {
+		return nil
+	}
+
+	schedule, err := mlbapi.Default().Schedule(ctx, mlbapi.ScheduleQuery{Date: date})
+	if err != nil {
+		return err
+	}
+
+	season := time.Now().Year()
+	if d, err := time.Parse("2006-01-02", date); err == nil {
+		season = d.Year()
+	}
+	scoring := newLeagueScoring(db, season)
+
+	lockRosterSnapshotAtFirstPitch(db, date, schedule.Games())
+
+	for _, game := range schedule.Games() {
+		state := game.Status.AbstractGameState
+		if state != "Live" && state != "Final" {
+			continue
+		}
+		if finalized[game.GamePk] {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		feed, err := mlbapi.Default().LiveFeed(ctx, game.GamePk)
+		if err != nil {
+			fmt.Printf("ERROR [LiveScoring]: game %d feed: %v\n", game.GamePk, err)
+			continue
+		}
+
+		state = feed.GameData.Status.AbstractGameState
+		inning := "Final"
+		if state != "Final" {
+			ls := feed.LiveData.Linescore
+			inning = ls.InningState + " " + ls.CurrentInningOrdinal
+		}
+
+		var lines []store.DailyPlayerStats
+		for _, statType := range []string{"pitching", "hitting"} {
+			lines = append(lines, gameStatLines(db, &feed.LiveData.Boxscore, game.GamePk, date, statType, "MLB", scoring)...)
+		}
+		if err := store.UpsertLiveStatLines(db, lines, state, inning); err != nil {
+			fmt.Printf("ERROR [LiveScoring]: game %d upsert: %v\n", game.GamePk, err)
+			continue
+		}
+
+		if state == "Final" && finalized != nil {
+			finalized[game.GamePk] = true
+		}
+	}
+	return nil
+}
+
+// firstPitchLock watches one day's schedule so its rosters lock at first pitch. The schedule is
+// refetched hourly to follow time changes, and on every tick once first pitch has passed.
+type firstPitchLock struct {
+	date       string
+	firstPitch *time.Time
+	fetchedAt  time.Time
+	locked     bool
+}
+
+func (l *firstPitchLock) check(ctx context.Context, db *pgxpool.Pool, date string) {
+	if date != l.date {
+		*l = firstPitchLock{date: date}
+	}
+	if l.locked {
+		return
+	}
+	due := l.firstPitch != nil && !time.Now().Before(*l.firstPitch)
+	if !due && time.Since(l.fetchedAt) < time.Hour {
+		return
+	}
+
+	schedule, err := mlbapi.Default().Schedule(ctx, mlbapi.ScheduleQuery{Date: date})
+	if err != nil {
+		fmt.Printf("ERROR [LiveScoring]: %s schedule for roster lock: %v\n", date, err)
+		return
+	}
+	l.fetchedAt = time.Now()
+	l.firstPitch = earliestGameTime(schedule.Games())
+	l.locked = lockRosterSnapshotAtFirstPitch(db, date, schedule.Games())
+}
+
+// earliestGameTime returns the earliest scheduled start among games, or nil if there are none.
+func earliestGameTime(games []mlbapi.ScheduleGame) *time.Time {
+	var first *time.Time
+	for _, game := range games {
+		if t, err := time.Parse(time.RFC3339, game.GameDate); err == nil && (first == nil || t.Before(*first)) {
+			first = &t
+		}
+	}
+	return first
+}
+
+// lockRosterSnapshotAtFirstPitch locks the date's roster snapshot once its earliest game has started.
+// Reports whether the date is locked.
+func lockRosterSnapshotAtFirstPitch(db *pgxpool.Pool, date string, games []mlbapi.ScheduleGame) bool {
+	if store.IsRosterSnapshotLocked(db, date) {
+		return true
+	}
+	firstPitch := earliestGameTime(games)
+	started := false
+	for _, game := range games {
+		if state := game.Status.AbstractGameState; state == "Live" || state == "Final" {
+			started = true
+		}
+	}
+	if firstPitch == nil || (!started && time.Now().Before(*firstPitch)) {
+		return false
+	}
+	n, err := store.LockRosterSnapshot(db, date, firstPitch)
+	if err != nil {
+		fmt.Printf("ERROR [LiveScoring]: lock rosters %s: %v\n", date, err)
+		return false
+	}
+	fmt.Printf("Live scoring: locked %s rosters at first pitch (%d players)\n", date, n)
+	return true
+}
@@ -4,19 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // StartMinorLeaguerWorker checks career stats once per month and tags players with limited MLB experience.
 // Ticks daily, uses system_counters keyed by year-month to run actual work once per month.
+// On first start it backfills birth dates so valuations have ages before the first monthly check.
 func StartMinorLeaguerWorker(ctx context.Context, db *pgxpool.Pool) {
 	go func() {
+		// One-time backfill: birth dates were added after players were loaded (migration 056)
+		if !hasRunThisYear(db, ctx, "birth_dates_backfill") {
+			filled, err := BackfillBirthDates(ctx, db)
+			if err != nil {
+				fmt.Printf("ERROR [MinorLeaguerWorker]: birth date backfill: %v\n", err)
+			} else {
+				fmt.Printf("Minor leaguer worker: birth date backfill filled %d players\n", filled)
+				markAsRun(db, ctx, "birth_dates_backfill")
+			}
+		}
+
 		ticker := time.NewTicker(24 * time.Hour)
 		defer ticker.Stop()
 		for {
@@ -38,40 +48,27 @@ func StartMinorLeaguerWorker(ctx context.Context, db *pgxpool.Pool) {
 	}()
 }
 
-// ProcessMinorLeaguerCheck fetches career stats from MLB API and updates is_minor_leaguer.
+// ProcessMinorLeaguerCheck fetches career stats from MLB API and updates is_minor_leaguer
+// (and birth dates, which come back on the same call).
 // Players without mlb_id are automatically marked as minor leaguers.
 // Exported so it can be triggered from the admin refresh endpoint.
 func ProcessMinorLeaguerCheck(ctx context.Context, db *pgxpool.Pool) {
-	dbCtx := context.Background()
-
 	// Step 1: Mark all players without mlb_id as minor leaguers (rostered or not)
 	// If the MLB ID populator couldn't find them, they're almost certainly minor leaguers
-	res, err := db.Exec(dbCtx, `UPDATE players SET is_minor_leaguer = TRUE
-		WHERE (mlb_id IS NULL OR mlb_id = 0)
-		AND is_minor_leaguer = FALSE`)
+	marked, err := store.MarkUnmatchedAsMinorLeaguers(db)
 	if err != nil {
 		fmt.Printf("ERROR [MinorLeaguerWorker]: bulk mark no-mlb-id: %v\n", err)
 	} else {
-		fmt.Printf("Minor leaguer worker: marked %d players without MLB ID as minor leaguers\n", res.RowsAffected())
+		fmt.Printf("Minor leaguer worker: marked %d players without MLB ID as minor leaguers\n", marked)
 	}
 
-	// Step 2: Check career stats for players with mlb_id
-	rows, err := db.Query(dbCtx, `SELECT DISTINCT mlb_id FROM players WHERE mlb_id IS NOT NULL AND mlb_id > 0`)
+	// Step 2: Check career stats once per canonical MLB ID
+	mlbIDs, err := store.GetKnownMLBIDs(db)
 	if err != nil {
 		fmt.Printf("ERROR [MinorLeaguerWorker]: query mlb_ids: %v\n", err)
 		return
 	}
 
-	var mlbIDs []int
-	for rows.Next() {
-		var id int
-		if err := rows.Scan(&id); err != nil {
-			continue
-		}
-		mlbIDs = append(mlbIDs, id)
-	}
-	rows.Close()
-
 	fmt.Printf("Minor leaguer worker: checking %d unique MLB IDs\n", len(mlbIDs))
 
 	// Process in batches of 50
@@ -91,77 +88,74 @@ func ProcessMinorLeaguerCheck(ctx context.Context, db *pgxpool.Pool) {
 		}
 		batch := mlbIDs[i:end]
 
-		results, err := fetchCareerStats(batch)
+		results, birthDates, err := fetchCareerStats(ctx, batch)
 		if err != nil {
 			fmt.Printf("ERROR [MinorLeaguerWorker]: batch %d-%d API call: %v\n", i, end, err)
-			time.Sleep(1 * time.Second)
 			continue
 		}
 
 		for mlbID, isMinor := range results {
-			_, err := db.Exec(dbCtx,
-				`UPDATE players SET is_minor_leaguer = $1 WHERE mlb_id = $2`,
-				isMinor, mlbID)
-			if err != nil {
+			if err := store.SetMinorLeaguerByMLBID(db, mlbID, isMinor); err != nil {
 				fmt.Printf("ERROR [MinorLeaguerWorker]: update mlb_id %d: %v\n", mlbID, err)
 				continue
 			}
 			updated++
 		}
-
-		// Rate limit between batches
-		if end < len(mlbIDs) {
-			time.Sleep(1 * time.Second)
+		for mlbID, birthDate := range birthDates {
+			if err := store.SetBirthDateByMLBID(db, mlbID, birthDate); err != nil {
+				fmt.Printf("ERROR [MinorLeaguerWorker]: birth date mlb_id %d: %v\n", mlbID, err)
+			}
 		}
 	}
 
 	fmt.Printf("Minor leaguer worker: updated %d MLB IDs\n", updated)
 }
 
-// fetchCareerStats calls MLB Stats API for a batch of player IDs and returns minor leaguer status.
-// A player is a "minor leaguer" if career IP <= 50 AND career AB <= 130.
-func fetchCareerStats(mlbIDs []int) (map[int]bool, error) {
-	ids := make([]string, len(mlbIDs))
-	for i, id := range mlbIDs {
-		ids[i] = strconv.Itoa(id)
+// BackfillBirthDates fetches birth dates for canonical players that don't have one, 50 at a time.
+// Returns how many were filled; a failed batch fails the backfill so it is retried on the next start.
+func BackfillBirthDates(ctx context.Context, db *pgxpool.Pool) (int, error) {
+	mlbIDs, err := store.GetMLBIDsMissingBirthDate(db)
+	if err != nil {
+		return 0, err
 	}
 
-	url := fmt.Sprintf(
-		"https://statsapi.mlb.com/api/v1/people?personIds=%s&hydrate=stats(group=[hitting,pitching],type=[career])",
-		strings.Join(ids, ","),
-	)
+	batchSize := 50
+	filled := 0
+	for i := 0; i < len(mlbIDs); i += batchSize {
+		if err := ctx.Err(); err != nil {
+			return filled, err
+		}
+		end := i + batchSize
+		if end > len(mlbIDs) {
+			end = len(mlbIDs)
+		}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+		_, birthDates, err := fetchCareerStats(ctx, mlbIDs[i:end])
+		if err != nil {
+			return filled, fmt.Errorf("batch %d-%d: %w", i, end, err)
+		}
+		for mlbID, birthDate := range birthDates {
+			if err := store.SetBirthDateByMLBID(db, mlbID, birthDate); err != nil {
+				return filled, fmt.Errorf("mlb_id %d: %w", mlbID, err)
+			}
+			filled++
+		}
 	}
-	defer resp.Body.Close()
+	return filled, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// fetchCareerStats calls MLB Stats API for a batch of player IDs and returns minor leaguer status
+// and known birth dates. A player is a "minor leaguer" if career IP <= 50 AND career AB <= 130.
+func fetchCareerStats(ctx context.Context, mlbIDs []int) (map[int]bool, map[int]string, error) {
+	people, err := mlbapi.Default().CareerStats(ctx, mlbIDs)
 	if err != nil {
-		return nil, err
-	}
-
-	var apiResp struct {
-		People []struct {
-			ID    int `json:"id"`
-			Stats []struct {
-				Group struct {
-					DisplayName string `json:"displayName"`
-				} `json:"group"`
-				Splits []struct {
-					Stat json.RawMessage `json:"stat"`
-				} `json:"splits"`
-			} `json:"stats"`
-		} `json:"people"`
-	}
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("parse API response: %w", err)
+		return nil, nil, err
 	}
 
 	results := make(map[int]bool)
+	birthDates := make(map[int]string)
 
-	for _, person := range apiResp.People {
+	for _, person := range people {
 		var careerIP float64
 		var careerAB float64
 		hasPitching := false
@@ -199,7 +193,10 @@ func fetchCareerStats(mlbIDs []int) (map[int]bool, error) {
 		_ = hasHitting
 		isMinor := careerIP <= 50 && careerAB <= 130
 		results[person.ID] = isMinor
+		if person.BirthDate != "" {
+			birthDates[person.ID] = person.BirthDate
+		}
 	}
 
-	return results, nil
+	return results, birthDates, nil
 }
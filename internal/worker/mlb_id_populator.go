@@ -2,15 +2,12 @@ package worker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"strings"
-	"time"
 	"unicode"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/text/unicode/norm"
 )
@@ -23,40 +20,17 @@ type mlbIDResult struct {
 	Errors    int
 }
 
-type unmatchedPlayer struct {
-	FirstName string
-	LastName  string
-}
-
 // ProcessMLBIDPopulation searches the MLB API to fill in mlb_id for rostered players missing it.
+// Matches are written to the canonical mlb_players record, which updates every league's copy.
 // Exported so it can be triggered from the admin handler.
 func ProcessMLBIDPopulation(ctx context.Context, db *pgxpool.Pool) {
-	dbCtx := context.Background()
-
-	// Get unique (first_name, last_name) combos for rostered players without mlb_id
-	rows, err := db.Query(dbCtx, `
-		SELECT DISTINCT first_name, last_name
-		FROM players
-		WHERE (mlb_id IS NULL OR mlb_id = 0)
-		AND team_id IS NOT NULL
-		AND team_id <> '00000000-0000-0000-0000-000000000000'
-		ORDER BY last_name, first_name
-	`)
+	// Canonical records without an MLB ID that are rostered in any league
+	players, err := store.GetUnmatchedRosteredMLBPlayers(db)
 	if err != nil {
 		fmt.Printf("ERROR [MLBIDPopulator]: query players: %v\n", err)
 		return
 	}
 
-	var players []unmatchedPlayer
-	for rows.Next() {
-		var p unmatchedPlayer
-		if err := rows.Scan(&p.FirstName, &p.LastName); err != nil {
-			continue
-		}
-		players = append(players, p)
-	}
-	rows.Close()
-
 	fmt.Printf("MLB ID Populator: searching for %d unique rostered players\n", len(players))
 
 	result := mlbIDResult{}
@@ -69,14 +43,11 @@ func ProcessMLBIDPopulation(ctx context.Context, db *pgxpool.Pool) {
 		default:
 		}
 
-		mlbID, status := searchMLBPlayer(p.FirstName, p.LastName)
+		mlbID, status := searchMLBPlayer(ctx, p.FirstName, p.LastName)
 
 		switch status {
 		case "matched":
-			_, err := db.Exec(dbCtx,
-				`UPDATE players SET mlb_id = $1 WHERE first_name = $2 AND last_name = $3 AND (mlb_id IS NULL OR mlb_id = 0)`,
-				mlbID, p.FirstName, p.LastName)
-			if err != nil {
+			if _, err := store.SetMLBPlayerMLBID(db, p.ID, mlbID); err != nil {
 				fmt.Printf("ERROR [MLBIDPopulator]: update %s %s: %v\n", p.FirstName, p.LastName, err)
 				result.Errors++
 			} else {
@@ -95,9 +66,6 @@ func ProcessMLBIDPopulation(ctx context.Context, db *pgxpool.Pool) {
 			fmt.Printf("MLB ID Populator: %d/%d processed (matched=%d, ambiguous=%d, not_found=%d)\n",
 				i+1, len(players), result.Matched, result.Ambiguous, result.NotFound)
 		}
-
-		// Rate limit: 1 request per second
-		time.Sleep(1 * time.Second)
 	}
 
 	fmt.Printf("MLB ID Populator: DONE — matched=%d, ambiguous=%d, not_found=%d, errors=%d, total=%d\n",
@@ -106,42 +74,21 @@ func ProcessMLBIDPopulation(ctx context.Context, db *pgxpool.Pool) {
 
 // searchMLBPlayer queries the MLB Stats API people search for a player by name.
 // Returns (mlbID, status) where status is "matched", "ambiguous", "not_found", or "error".
-func searchMLBPlayer(firstName, lastName string) (int, string) {
+func searchMLBPlayer(ctx context.Context, firstName, lastName string) (int, string) {
 	searchName := fmt.Sprintf("%s %s", firstName, lastName)
-	apiURL := fmt.Sprintf("https://statsapi.mlb.com/api/v1/people/search?names=%s&hydrate=currentTeam",
-		url.QueryEscape(searchName))
-
-	resp, err := http.Get(apiURL)
+	people, err := mlbapi.Default().SearchPeople(ctx, searchName)
 	if err != nil {
 		fmt.Printf("ERROR [MLBIDPopulator]: API call for %s: %v\n", searchName, err)
 		return 0, "error"
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, "error"
-	}
-
-	var apiResp struct {
-		People []struct {
-			ID        int    `json:"id"`
-			FirstName string `json:"firstName"`
-			LastName  string `json:"lastName"`
-			FullName  string `json:"fullFMLName"`
-		} `json:"people"`
-	}
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return 0, "error"
-	}
 
-	if len(apiResp.People) == 0 {
+	if len(people) == 0 {
 		return 0, "not_found"
 	}
 
 	// Filter to exact name matches (case-insensitive, accent-insensitive)
 	var exactMatches []int
-	for _, person := range apiResp.People {
+	for _, person := range people {
 		if strings.EqualFold(stripAccents(person.FirstName), stripAccents(firstName)) &&
 			strings.EqualFold(stripAccents(person.LastName), stripAccents(lastName)) {
 			exactMatches = append(exactMatches, person.ID)
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StartPayrollSnapshotWorker records every team's payroll once a night at ~11:30 PM Pacific,
+// after the day's transactions have settled, so financials pages can chart payroll over time.
+func StartPayrollSnapshotWorker(ctx context.Context, db *pgxpool.Pool) {
+	ticker := time.NewTicker(15 * time.Minute)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Println("Payroll snapshot worker stopped")
+				return
+			case <-ticker.C:
+				snapshotPayrollsIfDue(ctx, db)
+			}
+		}
+	}()
+}
+
+func snapshotPayrollsIfDue(ctx context.Context, db *pgxpool.Pool) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		loc = time.FixedZone("PT", -8*3600)
+	}
+	now := time.Now().In(loc)
+	if now.Hour() != 23 || now.Minute() < 30 {
+		return
+	}
+	key := fmt.Sprintf("payroll_snapshot_%s", now.Format("2006-01-02"))
+	if hasRunThisYear(db, ctx, key) {
+		return
+	}
+	written, err := store.SnapshotPayrolls(db, now)
+	if err != nil {
+		fmt.Printf("Payroll Snapshot Worker Error: %v\n", err)
+		return
+	}
+	markAsRun(db, ctx, key)
+	fmt.Printf("Payroll Snapshot Worker: Recorded %d team-year snapshots for %s\n", written, now.Format("2006-01-02"))
+}
@@ -3,14 +3,23 @@ package worker
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // StartSeasonalWorker runs hourly checks for seasonal resets.
 // - Nov 1: Reset option_years_used for all players (Feature 12)
 // - Oct 15: Clear IL statuses for all players (Feature 13)
+// - contract_rollover league date: release expiring contracts to free agency
+// - option_deadline league date: apply the default action to undecided team options and
+//   the league rule to player options / opt-outs
+// - qo_response_deadline league date: resolve outstanding qualifying offers by the league rule
+// - arbitration: open hearings for newly eligible players until arb_filing_deadline, then
+//   award the player's figure on unfiled hearings
 func StartSeasonalWorker(ctx context.Context, db *pgxpool.Pool) {
 	ticker := time.NewTicker(1 * time.Hour)
 	go func() {
@@ -68,6 +77,124 @@ func checkSeasonalTasks(db *pgxpool.Pool) {
 			fmt.Printf("Seasonal Worker: Cleared IL for %d players\n", result.RowsAffected())
 		}
 	}
+
+	for _, leagueID := range allLeagueIDs {
+		checkQualifyingOffers(db, ctx, leagueID, year, now)
+		checkContractRollover(db, ctx, leagueID, year, now)
+		checkArbitrationFiling(db, ctx, leagueID, year, now)
+	}
+}
+
+// checkQualifyingOffers answers every outstanding qualifying offer with the league's accept
+// rule once qo_response_deadline has passed (left for the commissioner under the 'commissioner' rule).
+func checkQualifyingOffers(db *pgxpool.Pool, ctx context.Context, leagueID string, season int, now time.Time) {
+	deadline, err := store.GetLeagueDateValue(db, leagueID, season, "qo_response_deadline")
+	if err != nil || !now.After(deadline) {
+		return
+	}
+	key := fmt.Sprintf("qo_resolve_%s_%d", leagueID, season)
+	if hasRunThisYear(db, ctx, key) {
+		return
+	}
+
+	summaries, err := store.ApplyQualifyingOfferRule(db, leagueID, season)
+	if err != nil {
+		fmt.Printf("Seasonal Worker Error (qualifying offers %s): %v\n", leagueNames[leagueID], err)
+		return
+	}
+	markAsRun(db, ctx, key)
+
+	if len(summaries) > 0 {
+		msg := fmt.Sprintf("*%d Qualifying Offers*\n%s", season, strings.Join(summaries, "\n"))
+		notification.SendSlackNotification(db, leagueID, "transactions", msg)
+	}
+	fmt.Printf("Seasonal Worker: %s resolved %d qualifying offers\n", leagueNames[leagueID], len(summaries))
+}
+
+// checkArbitrationFiling opens hearings (with the league-generated player figure) for newly
+// eligible players, then awards that figure to every hearing the team never filed on once the
+// league's arb_filing_deadline has passed.
+func checkArbitrationFiling(db *pgxpool.Pool, ctx context.Context, leagueID string, year int, now time.Time) {
+	key := fmt.Sprintf("arb_unfiled_%s_%d", leagueID, year)
+	if hasRunThisYear(db, ctx, key) {
+		return
+	}
+	opened, err := store.EnsureArbitrationHearings(db, leagueID, year)
+	if err != nil {
+		fmt.Printf("Seasonal Worker Error (arbitration hearings %s): %v\n", leagueNames[leagueID], err)
+	} else if opened > 0 {
+		fmt.Printf("Seasonal Worker: %s opened %d arbitration hearings\n", leagueNames[leagueID], opened)
+	}
+
+	deadline, err := store.GetLeagueDateValue(db, leagueID, year, "arb_filing_deadline")
+	if err != nil || !now.After(deadline) {
+		return
+	}
+
+	awarded, err := store.AwardUnfiledHearings(db, leagueID, year)
+	if err != nil {
+		fmt.Printf("Seasonal Worker Error (arbitration filing %s): %v\n", leagueNames[leagueID], err)
+		return
+	}
+	markAsRun(db, ctx, key)
+
+	if awarded > 0 {
+		msg := fmt.Sprintf("*%d Arbitration Filing Deadline*\n%d unfiled hearings awarded the player's figure.", year, awarded)
+		notification.SendSlackNotification(db, leagueID, "transactions", msg)
+	}
+	fmt.Printf("Seasonal Worker: %s awarded %d unfiled arbitration hearings\n", leagueNames[leagueID], awarded)
+}
+
+// checkContractRollover runs a league's end-of-season rollover once its contract_rollover
+// date arrives, then resolves leftover team options after option_deadline.
+func checkContractRollover(db *pgxpool.Pool, ctx context.Context, leagueID string, season int, now time.Time) {
+	rolloverDate, err := store.GetLeagueDateValue(db, leagueID, season, "contract_rollover")
+	if err == nil && !now.Before(rolloverDate) && !hasRunThisYear(db, ctx, store.RolloverRunKey(leagueID, season)) {
+		changes, err := store.ApplyContractRollover(db, leagueID, season)
+		if err != nil {
+			fmt.Printf("Seasonal Worker Error (contract rollover %s): %v\n", leagueNames[leagueID], err)
+			return
+		}
+
+		released, pending, qoPending := 0, 0, 0
+		for _, ch := range changes {
+			switch ch.Action {
+			case "released":
+				released++
+			case "qo_pending":
+				qoPending++
+			default:
+				pending++
+			}
+		}
+		msg := fmt.Sprintf("*%d Contract Rollover*\n%d expiring players released to free agency. %d team options awaiting decisions.",
+			season, released, pending)
+		if qoPending > 0 {
+			msg += fmt.Sprintf(" %d players still considering qualifying offers.", qoPending)
+		}
+		notification.SendSlackNotification(db, leagueID, "transactions", msg)
+		fmt.Printf("Seasonal Worker: %s rollover released %d players, %d options pending\n", leagueNames[leagueID], released, pending)
+	}
+
+	deadline, err := store.GetLeagueDateValue(db, leagueID, season, "option_deadline")
+	if err == nil && now.After(deadline) && store.IsRolloverComplete(db, leagueID, season) {
+		key := fmt.Sprintf("option_defaults_%s_%d", leagueID, season)
+		if !hasRunThisYear(db, ctx, key) {
+			changes, err := store.ApplyOptionDefaults(db, leagueID, season)
+			if err != nil {
+				fmt.Printf("Seasonal Worker Error (option defaults %s): %v\n", leagueNames[leagueID], err)
+				return
+			}
+			// Player-side options and opt-outs follow the league rule (no-op when commissioner rules)
+			decided, err := store.ApplyPlayerOptionRule(db, leagueID, season)
+			if err != nil {
+				fmt.Printf("Seasonal Worker Error (player options %s): %v\n", leagueNames[leagueID], err)
+			}
+			markAsRun(db, ctx, key)
+			fmt.Printf("Seasonal Worker: %s applied default action to %d team options, %d player decisions by rule\n",
+				leagueNames[leagueID], len(changes), decided)
+		}
+	}
 }
 
 func hasRunThisYear(db *pgxpool.Pool, ctx context.Context, key string) bool {
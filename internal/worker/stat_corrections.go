@@ -0,0 +1,234 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
+	"github.com/dwes123/fantasy-baseball-go/internal/notification"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// statCorrectionWindowDays is how far back the daily correction pass re-checks box scores.
+const statCorrectionWindowDays = 7
+
+// maxCorrectionLines caps the per-league Slack summary.
+const maxCorrectionLines = 25
+
+// ProcessStatCorrections re-fetches box scores for already-processed dates in the last `days`
+// days, diffs them against stored stat lines and upserts or deletes any MLB has revised. Each league with
+// changed fantasy points gets a summary in its stat-alerts channel.
+// Exported so it can be called from the admin handler.
+func ProcessStatCorrections(ctx context.Context, db *pgxpool.Pool, days int) ([]store.StatCorrection, error) {
+	var corrections []store.StatCorrection
+	var firstDate, lastDate string
+
+	for i := days; i >= 1; i-- {
+		date := time.Now().AddDate(0, 0, -i).Format("2006-01-02")
+		fixed, err := correctDateStats(ctx, db, date)
+		if err != nil {
+			return corrections, err
+		}
+		if firstDate == "" {
+			firstDate = date
+		}
+		lastDate = date
+		corrections = append(corrections, fixed...)
+	}
+
+	fmt.Printf("Stats worker: stat corrections %s to %s — %d lines updated\n", firstDate, lastDate, len(corrections))
+	notifyStatCorrections(db, corrections, firstDate, lastDate)
+	return corrections, nil
+}
+
+// correctDateStats diffs one date's stored stat lines against fresh box scores: revised lines are
+// rewritten, lines MLB added are stored and lines MLB removed are deleted. Each is logged.
+func correctDateStats(ctx context.Context, db *pgxpool.Pool, date string) ([]store.StatCorrection, error) {
+	lines, err := store.GetStoredStatLines(db, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	// Index stored lines by league player copy, game and stat type
+	stored := make(map[string]store.DailyPlayerStats)
+	gameLevel := make(map[int]string)
+	var gamePks []int
+	for _, l := range lines {
+		stored[fmt.Sprintf("%s|%d|%s", l.PlayerID, l.GamePk, l.StatType)] = l
+		if _, ok := gameLevel[l.GamePk]; !ok {
+			gameLevel[l.GamePk] = l.Level
+			gamePks = append(gamePks, l.GamePk)
+		}
+	}
+
+	season := time.Now().Year()
+	if d, err := time.Parse("2006-01-02", date); err == nil {
+		season = d.Year()
+	}
+	scoring := newLeagueScoring(db, season)
+
+	var corrections []store.StatCorrection
+	for _, gamePk := range gamePks {
+		select {
+		case <-ctx.Done():
+			return corrections, ctx.Err()
+		default:
+		}
+
+		boxscore, err := mlbapi.Default().BoxScore(ctx, gamePk)
+		if err != nil {
+			fmt.Printf("ERROR [StatCorrections]: game %d boxscore: %v\n", gamePk, err)
+			continue
+		}
+
+		level := gameLevel[gamePk]
+		if level == "" {
+			level = "MLB"
+		}
+		seen := make(map[string]bool)
+		for _, statType := range []string{"pitching", "hitting"} {
+			for _, fresh := range gameStatLines(db, boxscore, gamePk, date, statType, level, scoring) {
+				key := fmt.Sprintf("%s|%d|%s", fresh.PlayerID, gamePk, statType)
+				seen[key] = true
+				c := store.StatCorrection{
+					PlayerID:  fresh.PlayerID,
+					LeagueID:  fresh.LeagueID,
+					TeamID:    fresh.TeamID,
+					GamePk:    gamePk,
+					GameDate:  date,
+					StatType:  statType,
+					OldRaw:    map[string]float64{},
+					NewRaw:    fresh.RawStats,
+					NewPoints: fresh.FantasyPoints,
+				}
+
+				line, ok := stored[key]
+				if !ok {
+					// MLB added the player to the box score after the date was processed
+					if err := store.UpsertDailyPlayerStats(db, &fresh); err != nil {
+						fmt.Printf("ERROR [StatCorrections]: add %s game %d %s: %v\n", fresh.PlayerID, gamePk, statType, err)
+						continue
+					}
+					if err := store.RecordStatLineAdded(db, &c); err != nil {
+						fmt.Printf("ERROR [StatCorrections]: log add %s game %d %s: %v\n", fresh.PlayerID, gamePk, statType, err)
+						continue
+					}
+					corrections = append(corrections, c)
+					continue
+				}
+				if rawStatsEqual(line.RawStats, fresh.RawStats) {
+					continue
+				}
+				c.DailyStatID = line.ID
+				c.PlayerName = line.PlayerName
+				c.LeagueID = line.LeagueID
+				c.TeamID = line.TeamID
+				c.TeamName = line.TeamName
+				c.OldRaw = line.RawStats
+				c.OldPoints = line.FantasyPoints
+				c.NewPoints = scoring.points(line.LeagueID, statType, line.Level, fresh.RawStats)
+				if err := store.ApplyStatCorrection(db, &c); err != nil {
+					fmt.Printf("ERROR [StatCorrections]: %s game %d %s: %v\n", line.PlayerID, gamePk, statType, err)
+					continue
+				}
+				corrections = append(corrections, c)
+			}
+		}
+
+		// Lines no longer in the box score were removed by MLB
+		for key, line := range stored {
+			if line.GamePk != gamePk || seen[key] {
+				continue
+			}
+			c := store.StatCorrection{
+				DailyStatID: line.ID,
+				PlayerID:    line.PlayerID,
+				PlayerName:  line.PlayerName,
+				LeagueID:    line.LeagueID,
+				TeamID:      line.TeamID,
+				TeamName:    line.TeamName,
+				GamePk:      gamePk,
+				GameDate:    date,
+				StatType:    line.StatType,
+				OldRaw:      line.RawStats,
+				NewRaw:      map[string]float64{},
+				OldPoints:   line.FantasyPoints,
+			}
+			if err := store.RemoveStatLine(db, &c); err != nil {
+				fmt.Printf("ERROR [StatCorrections]: remove %s game %d %s: %v\n", line.PlayerID, gamePk, line.StatType, err)
+				continue
+			}
+			corrections = append(corrections, c)
+		}
+	}
+	return corrections, nil
+}
+
+// rawStatsEqual compares two raw stat maps, treating a missing key as zero.
+func rawStatsEqual(a, b map[string]float64) bool {
+	for k, v := range a {
+		if math.Abs(v-b[k]) > 0.0001 {
+			return false
+		}
+	}
+	for k, v := range b {
+		if math.Abs(v-a[k]) > 0.0001 {
+			return false
+		}
+	}
+	return true
+}
+
+// notifyStatCorrections posts one summary per league listing stat lines whose fantasy points changed.
+func notifyStatCorrections(db *pgxpool.Pool, corrections []store.StatCorrection, firstDate, lastDate string) {
+	byLeague := make(map[string][]store.StatCorrection)
+	for _, c := range corrections {
+		if c.LeagueID == "" || c.NewPoints == c.OldPoints {
+			continue
+		}
+		byLeague[c.LeagueID] = append(byLeague[c.LeagueID], c)
+	}
+
+	for leagueID, list := range byLeague {
+		sort.Slice(list, func(i, j int) bool {
+			return math.Abs(list[i].NewPoints-list[i].OldPoints) > math.Abs(list[j].NewPoints-list[j].OldPoints)
+		})
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("📝 *Stat Corrections* — MLB revised %d scoring line(s) between %s and %s:\n",
+			len(list), formatCorrectionDate(firstDate), formatCorrectionDate(lastDate)))
+		for i, c := range list {
+			if i == maxCorrectionLines {
+				sb.WriteString(fmt.Sprintf("…and %d more\n", len(list)-maxCorrectionLines))
+				break
+			}
+			team := c.TeamName
+			if team == "" {
+				team = "FA"
+			}
+			sb.WriteString(fmt.Sprintf("• %s (%s) %s %s: %.2f → %.2f (%+.2f)\n",
+				c.PlayerName, team, formatCorrectionDate(c.GameDate), c.StatType,
+				c.OldPoints, c.NewPoints, c.NewPoints-c.OldPoints))
+		}
+
+		if err := notification.SendSlackNotification(db, leagueID, "stat_alerts", sb.String()); err != nil {
+			fmt.Printf("ERROR [StatCorrections]: slack for league %s: %v\n", leagueID, err)
+		}
+	}
+}
+
+func formatCorrectionDate(date string) string {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return d.Format("Jan 2")
+}
@@ -4,22 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // StartStatsWorker polls for completed MLB games and processes pitching + hitting stats.
-// Ticks every 30 minutes; runs 5-6 AM ET during season (Mar 25-Oct).
-// Catches up any unprocessed dates in the last 7 days on each tick.
+// Ticks every 30 minutes; runs 5-6 AM ET during season (Mar 25-Oct). Yesterday's rosters are
+// locked first if the live scoring worker missed its first pitch.
+// Catches up any unprocessed dates (MLB and MiLB) in the last 7 days on each tick, then runs the
+// daily stat-correction pass over the same window once per day.
 func StartStatsWorker(ctx context.Context, db *pgxpool.Pool) {
 	go func() {
+		// One-time backfill: lines stored before ingestion fanned out to every league's copy
+		if !hasRunThisYear(db, ctx, "stats_league_copies_backfill") {
+			created, err := store.BackfillStatLineCopies(db, 0)
+			if err != nil {
+				fmt.Printf("ERROR [StatsWorker]: league copy backfill: %v\n", err)
+			} else {
+				fmt.Printf("Stats worker: league copy backfill created %d stat lines\n", created)
+				markAsRun(db, ctx, "stats_league_copies_backfill")
+			}
+		}
+
 		ticker := time.NewTicker(30 * time.Minute)
 		defer ticker.Stop()
 		for {
@@ -42,9 +54,15 @@ func StartStatsWorker(ctx context.Context, db *pgxpool.Pool) {
 					continue
 				}
 
-				// Process yesterday + catch up last 7 days (pitching + hitting)
+				// Fallback lock for a date the live scoring worker never locked at first pitch
+				lockMissedRosterSnapshot(ctx, db, et.AddDate(0, 0, -1).Format("2006-01-02"))
+
+				// Process yesterday + catch up last 7 days (MiLB, then MLB pitching + hitting)
 				for i := 1; i <= 7; i++ {
 					date := time.Now().AddDate(0, 0, -i).Format("2006-01-02")
+					if !store.IsDateProcessed(db, date, "milb") {
+						ProcessMiLBDateStats(ctx, db, date)
+					}
 					pitchingDone := store.IsDateProcessed(db, date, "pitching")
 					hittingDone := store.IsDateProcessed(db, date, "hitting")
 					if pitchingDone && hittingDone {
@@ -53,11 +71,46 @@ func StartStatsWorker(ctx context.Context, db *pgxpool.Pool) {
 					fmt.Printf("Stats worker: processing stats for %s\n", date)
 					ProcessDateStats(ctx, db, date)
 				}
+
+				// Re-check stored dates for MLB scoring changes once per day
+				correctionKey := "stat_corrections_" + et.Format("2006-01-02")
+				if !hasRunThisYear(db, ctx, correctionKey) {
+					if _, err := ProcessStatCorrections(ctx, db, statCorrectionWindowDays); err != nil {
+						fmt.Printf("ERROR [StatsWorker]: stat corrections: %v\n", err)
+					} else {
+						markAsRun(db, ctx, correctionKey)
+					}
+				}
 			}
 		}
 	}()
 }
 
+// lockMissedRosterSnapshot locks a date that had games but no first-pitch lock, with no first
+// pitch recorded so roster history flags it. Rosters are as of the lock, so moves made during
+// that day's games count; the warning is the cue for commissioners to review the date.
+func lockMissedRosterSnapshot(ctx context.Context, db *pgxpool.Pool, date string) {
+	if store.IsRosterSnapshotLocked(db, date) {
+		return
+	}
+	schedule, err := mlbapi.Default().Schedule(ctx, mlbapi.ScheduleQuery{Date: date})
+	if err != nil {
+		fmt.Printf("ERROR [StatsWorker]: %s schedule for roster lock: %v\n", date, err)
+		return
+	}
+	if len(schedule.Games()) == 0 {
+		return
+	}
+	n, err := store.LockRosterSnapshot(db, date, nil)
+	if err != nil {
+		fmt.Printf("ERROR [StatsWorker]: fallback roster lock %s: %v\n", date, err)
+		return
+	}
+	if n > 0 {
+		fmt.Printf("WARN [StatsWorker]: %s rosters missed the first-pitch lock; locked after games (%d players)\n", date, n)
+	}
+}
+
 // ProcessDateStats fetches all completed MLB games for a date and processes both pitching and hitting stats.
 // Exported so it can be called from the admin backfill handler.
 func ProcessDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
@@ -68,28 +121,25 @@ func ProcessDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
 		return
 	}
 
-	var pitchingScoringMap, hittingScoringMap map[string]float64
-	var err error
-
-	if !pitchingDone {
-		pitchingScoringMap, err = store.GetScoringCategoryMap(db, "pitching")
-		if err != nil {
-			fmt.Printf("ERROR [StatsWorker]: failed to load pitching scoring: %v\n", err)
-			store.LogStatsProcessing(db, date, "pitching", 0, 0, "error", err.Error())
-			return
-		}
+	// Each league scores with its own rules for the season; the global defaults must load
+	season := time.Now().Year()
+	if d, err := time.Parse("2006-01-02", date); err == nil {
+		season = d.Year()
 	}
+	scoring := newLeagueScoring(db, season)
 
-	if !hittingDone {
-		hittingScoringMap, err = store.GetScoringCategoryMap(db, "hitting")
-		if err != nil {
-			fmt.Printf("ERROR [StatsWorker]: failed to load hitting scoring: %v\n", err)
-			store.LogStatsProcessing(db, date, "hitting", 0, 0, "error", err.Error())
+	for _, statType := range []string{"pitching", "hitting"} {
+		if (statType == "pitching" && pitchingDone) || (statType == "hitting" && hittingDone) {
+			continue
+		}
+		if _, err := scoring.load("", statType); err != nil {
+			fmt.Printf("ERROR [StatsWorker]: failed to load %s scoring: %v\n", statType, err)
+			store.LogStatsProcessing(db, date, statType, 0, 0, "error", err.Error())
 			return
 		}
 	}
 
-	games, err := fetchSchedule(date)
+	games, err := fetchFinalGames(ctx, date, mlbapi.SportMLB)
 	if err != nil {
 		fmt.Printf("ERROR [StatsWorker]: failed to fetch schedule for %s: %v\n", date, err)
 		if !pitchingDone {
@@ -112,14 +162,14 @@ func ProcessDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
 		}
 
 		// Fetch box score once per game
-		boxscore, err := fetchBoxScore(game.GamePk)
+		boxscore, err := mlbapi.Default().BoxScore(ctx, game.GamePk)
 		if err != nil {
 			fmt.Printf("ERROR [StatsWorker]: game %d boxscore: %v\n", game.GamePk, err)
 			continue
 		}
 
 		if !pitchingDone {
-			count, err := processGamePitching(db, boxscore, game.GamePk, date, pitchingScoringMap)
+			count, err := processGamePitching(db, boxscore, game.GamePk, date, scoring)
 			if err != nil {
 				fmt.Printf("ERROR [StatsWorker]: game %d pitching: %v\n", game.GamePk, err)
 			} else {
@@ -129,7 +179,7 @@ func ProcessDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
 		}
 
 		if !hittingDone {
-			count, err := processGameHitting(db, boxscore, game.GamePk, date, hittingScoringMap)
+			count, err := processGameHitting(db, boxscore, game.GamePk, date, scoring)
 			if err != nil {
 				fmt.Printf("ERROR [StatsWorker]: game %d hitting: %v\n", game.GamePk, err)
 			} else {
@@ -138,8 +188,6 @@ func ProcessDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
 			}
 		}
 
-		// Rate limit: 1 second between box score fetches
-		time.Sleep(1 * time.Second)
 	}
 
 	if !pitchingDone {
@@ -150,76 +198,102 @@ func ProcessDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
 		store.LogStatsProcessing(db, date, "hitting", hittingGames, hittingPlayers, "completed", "")
 		fmt.Printf("Stats worker: %s hitting — %d games, %d hitters\n", date, hittingGames, hittingPlayers)
 	}
+	// Final lines are stored; drop the provisional ones the live scoring worker wrote
+	if err := store.ClearLiveStats(db, date); err != nil {
+		fmt.Printf("ERROR [StatsWorker]: clear live stats for %s: %v\n", date, err)
+	}
 }
 
-type scheduleGame struct {
-	GamePk int
-	State  string
-}
-
-func fetchSchedule(date string) ([]scheduleGame, error) {
-	url := fmt.Sprintf("https://statsapi.mlb.com/api/v1/schedule?sportId=1&date=%s", date)
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+// ProcessMiLBDateStats ingests a date's AAA, AA, High-A and Single-A box scores for players in our
+// DB. Lines are tagged with their level and only score for leagues with MiLB scoring on.
+// Logged under stat type "milb". Exported so it can be called from the admin backfill handler.
+func ProcessMiLBDateStats(ctx context.Context, db *pgxpool.Pool, date string) {
+	if store.IsDateProcessed(db, date, "milb") {
+		return
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	season := time.Now().Year()
+	if d, err := time.Parse("2006-01-02", date); err == nil {
+		season = d.Year()
 	}
+	scoring := newLeagueScoring(db, season)
 
-	var schedule struct {
-		Dates []struct {
-			Games []struct {
-				GamePk int `json:"gamePk"`
-				Status struct {
-					AbstractGameState string `json:"abstractGameState"`
-				} `json:"status"`
-			} `json:"games"`
-		} `json:"dates"`
-	}
-	if err := json.Unmarshal(body, &schedule); err != nil {
-		return nil, err
-	}
+	games, players := 0, 0
+	for _, level := range mlbapi.MiLBLevels {
+		finals, err := fetchFinalGames(ctx, date, level.SportID)
+		if err != nil {
+			fmt.Printf("ERROR [StatsWorker]: failed to fetch %s schedule for %s: %v\n", level.Name, date, err)
+			store.LogStatsProcessing(db, date, "milb", games, players, "error", err.Error())
+			return
+		}
 
-	var games []scheduleGame
-	for _, d := range schedule.Dates {
-		for _, g := range d.Games {
-			if g.Status.AbstractGameState == "Final" {
-				games = append(games, scheduleGame{GamePk: g.GamePk, State: g.Status.AbstractGameState})
+		for _, game := range finals {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			boxscore, err := mlbapi.Default().BoxScore(ctx, game.GamePk)
+			if err != nil {
+				fmt.Printf("ERROR [StatsWorker]: %s game %d boxscore: %v\n", level.Name, game.GamePk, err)
+				continue
+			}
+			for _, statType := range []string{"pitching", "hitting"} {
+				count, _ := storeGameStatLines(db, boxscore, game.GamePk, date, statType, level.Name, scoring)
+				players += count
 			}
+			games++
 		}
 	}
-	return games, nil
+
+	store.LogStatsProcessing(db, date, "milb", games, players, "completed", "")
+	fmt.Printf("Stats worker: %s MiLB — %d games, %d player lines\n", date, games, players)
 }
 
-// fetchBoxScore retrieves the box score for a single game from the MLB API.
-func fetchBoxScore(gamePk int) (*boxScoreResp, error) {
-	url := fmt.Sprintf("https://statsapi.mlb.com/api/v1/game/%d/boxscore", gamePk)
-	resp, err := http.Get(url)
+// fetchFinalGames returns the completed games for a date at one level (mlbapi.SportMLB etc.).
+func fetchFinalGames(ctx context.Context, date string, sportID int) ([]mlbapi.ScheduleGame, error) {
+	schedule, err := mlbapi.Default().Schedule(ctx, mlbapi.ScheduleQuery{SportID: sportID, Date: date})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	var games []mlbapi.ScheduleGame
+	for _, g := range schedule.Games() {
+		if g.Status.AbstractGameState == "Final" {
+			games = append(games, g)
+		}
 	}
+	return games, nil
+}
 
-	var boxscore boxScoreResp
-	if err := json.Unmarshal(body, &boxscore); err != nil {
-		return nil, err
+func processGamePitching(db *pgxpool.Pool, boxscore *mlbapi.BoxScore, gamePk int, date string, scoring *leagueScoring) (int, error) {
+	return storeGameStatLines(db, boxscore, gamePk, date, "pitching", "MLB", scoring)
+}
+
+func processGameHitting(db *pgxpool.Pool, boxscore *mlbapi.BoxScore, gamePk int, date string, scoring *leagueScoring) (int, error) {
+	return storeGameStatLines(db, boxscore, gamePk, date, "hitting", "MLB", scoring)
+}
+
+// storeGameStatLines upserts a game's stat lines of one type and returns how many MLB players were stored.
+func storeGameStatLines(db *pgxpool.Pool, boxscore *mlbapi.BoxScore, gamePk int, date, statType, level string, scoring *leagueScoring) (int, error) {
+	stored := make(map[string]bool)
+	for _, stat := range gameStatLines(db, boxscore, gamePk, date, statType, level, scoring) {
+		if err := store.UpsertDailyPlayerStats(db, &stat); err != nil {
+			fmt.Printf("ERROR [StatsWorker]: upsert %s %s game %d: %v\n", statType, stat.PlayerID, gamePk, err)
+			continue
+		}
+		stored[stat.MlbID] = true
 	}
-	return &boxscore, nil
+	return len(stored), nil
 }
 
-func processGamePitching(db *pgxpool.Pool, boxscore *boxScoreResp, gamePk int, date string, scoringMap map[string]float64) (int, error) {
-	count := 0
+// gameStatLines builds a box score's stat lines of one type ("pitching" or "hitting") at a level
+// ("MLB", "AAA", ...). Every league has its own copy of the player; each gets a line scored with
+// its league's rules. Shared by the nightly pass, the MiLB pass and live scoring.
+func gameStatLines(db *pgxpool.Pool, boxscore *mlbapi.BoxScore, gamePk int, date, statType, level string, scoring *leagueScoring) []store.DailyPlayerStats {
+	var lines []store.DailyPlayerStats
 
-	for _, side := range []boxScoreSide{boxscore.Teams.Away, boxscore.Teams.Home} {
+	for _, side := range []mlbapi.BoxScoreSide{boxscore.Teams.Away, boxscore.Teams.Home} {
 		var opponent string
 		if side.Team.Abbreviation == boxscore.Teams.Away.Team.Abbreviation {
 			opponent = boxscore.Teams.Home.Team.Abbreviation
@@ -228,111 +302,82 @@ func processGamePitching(db *pgxpool.Pool, boxscore *boxScoreResp, gamePk int, d
 		}
 
 		for _, playerEntry := range side.Players {
-			if playerEntry.Stats.Pitching.InningsPitched == "" {
-				continue // Not a pitcher in this game
+			var raw map[string]float64
+			if statType == "pitching" {
+				if playerEntry.Stats.Pitching.InningsPitched == "" {
+					continue // Not a pitcher in this game
+				}
+				raw = extractPitchingRawStats(playerEntry.Stats.Pitching)
+			} else {
+				batting := playerEntry.Stats.Batting
+				if toFloat(batting.AtBats) == 0 && toFloat(batting.PlateAppearances) == 0 {
+					continue // Did not bat in this game
+				}
+				raw = extractHittingRawStats(batting)
 			}
 
 			mlbID := playerEntry.Person.ID
-			raw := extractPitchingRawStats(playerEntry.Stats.Pitching)
-			pts := calculateFantasyPoints(raw, scoringMap)
-
-			dbCtx := context.Background()
-			var playerID, teamID, leagueID string
-			err := db.QueryRow(dbCtx, `
-				SELECT p.id, COALESCE(p.team_id::TEXT, ''), COALESCE(p.league_id::TEXT, '')
-				FROM players p
-				WHERE p.mlb_id = $1
-				LIMIT 1
-			`, mlbID).Scan(&playerID, &teamID, &leagueID)
-			if err != nil {
-				continue // Player not in our DB
-			}
-
-			stat := &store.DailyPlayerStats{
-				PlayerID:      playerID,
-				MlbID:         strconv.Itoa(mlbID),
-				GamePk:        gamePk,
-				GameDate:      date,
-				StatType:      "pitching",
-				RawStats:      raw,
-				FantasyPoints: pts,
-				TeamID:        teamID,
-				LeagueID:      leagueID,
-				Opponent:      opponent,
-			}
-
-			if err := store.UpsertDailyPlayerStats(db, stat); err != nil {
-				fmt.Printf("ERROR [StatsWorker]: upsert pitcher %s game %d: %v\n", playerID, gamePk, err)
-				continue
+			for _, pc := range lookupPlayerCopies(db, mlbID, date) {
+				lines = append(lines, store.DailyPlayerStats{
+					PlayerID:      pc.PlayerID,
+					MlbID:         strconv.Itoa(mlbID),
+					GamePk:        gamePk,
+					GameDate:      date,
+					StatType:      statType,
+					RawStats:      raw,
+					FantasyPoints: scoring.points(pc.LeagueID, statType, level, raw),
+					TeamID:        pc.TeamID,
+					LeagueID:      pc.LeagueID,
+					Opponent:      opponent,
+					Level:         level,
+				})
 			}
-			count++
 		}
 	}
 
-	return count, nil
+	return lines
 }
 
-func processGameHitting(db *pgxpool.Pool, boxscore *boxScoreResp, gamePk int, date string, scoringMap map[string]float64) (int, error) {
-	count := 0
-
-	for _, side := range []boxScoreSide{boxscore.Teams.Away, boxscore.Teams.Home} {
-		var opponent string
-		if side.Team.Abbreviation == boxscore.Teams.Away.Team.Abbreviation {
-			opponent = boxscore.Teams.Home.Team.Abbreviation
-		} else {
-			opponent = boxscore.Teams.Away.Team.Abbreviation
-		}
-
-		for _, playerEntry := range side.Players {
-			batting := playerEntry.Stats.Batting
-			ab := toFloat(batting.AtBats)
-			pa := toFloat(batting.PlateAppearances)
-			if ab == 0 && pa == 0 {
-				continue // Did not bat in this game
-			}
-
-			mlbID := playerEntry.Person.ID
-			raw := extractHittingRawStats(batting)
-			pts := calculateFantasyPoints(raw, scoringMap)
-
-			dbCtx := context.Background()
-			var playerID, teamID, leagueID string
-			err := db.QueryRow(dbCtx, `
-				SELECT p.id, COALESCE(p.team_id::TEXT, ''), COALESCE(p.league_id::TEXT, '')
-				FROM players p
-				WHERE p.mlb_id = $1
-				LIMIT 1
-			`, mlbID).Scan(&playerID, &teamID, &leagueID)
-			if err != nil {
-				continue // Player not in our DB
-			}
+// playerCopy is one league's row for a real MLB player.
+type playerCopy struct {
+	PlayerID string
+	TeamID   string
+	LeagueID string
+}
 
-			stat := &store.DailyPlayerStats{
-				PlayerID:      playerID,
-				MlbID:         strconv.Itoa(mlbID),
-				GamePk:        gamePk,
-				GameDate:      date,
-				StatType:      "hitting",
-				RawStats:      raw,
-				FantasyPoints: pts,
-				TeamID:        teamID,
-				LeagueID:      leagueID,
-				Opponent:      opponent,
-			}
+// lookupPlayerCopies is swapped out by tests that run the pipeline without a database.
+var lookupPlayerCopies = playerCopies
+
+// playerCopies returns every league's player row for an MLB ID. Empty if the player isn't in our DB.
+// Once the date's rosters are locked, each copy's team is the one it was on at first pitch.
+func playerCopies(db *pgxpool.Pool, mlbID int, date string) []playerCopy {
+	rows, err := db.Query(context.Background(), `
+		SELECT p.id,
+		       COALESCE(CASE WHEN EXISTS (SELECT 1 FROM roster_snapshot_days WHERE snapshot_date = $2::date)
+		                     THEN rs.team_id ELSE p.team_id END::TEXT, ''),
+		       COALESCE(p.league_id::TEXT, '')
+		FROM players p
+		LEFT JOIN roster_snapshots rs ON rs.player_id = p.id AND rs.snapshot_date = $2::date
+		WHERE p.mlb_id = $1
+	`, mlbID, date)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
 
-			if err := store.UpsertDailyPlayerStats(db, stat); err != nil {
-				fmt.Printf("ERROR [StatsWorker]: upsert hitter %s game %d: %v\n", playerID, gamePk, err)
-				continue
-			}
-			count++
+	var copies []playerCopy
+	for rows.Next() {
+		var pc playerCopy
+		if err := rows.Scan(&pc.PlayerID, &pc.TeamID, &pc.LeagueID); err != nil {
+			continue
 		}
+		copies = append(copies, pc)
 	}
-
-	return count, nil
+	return copies
 }
 
 // extractPitchingRawStats converts MLB API pitching stats to our flat map.
-func extractPitchingRawStats(p pitchingStats) map[string]float64 {
+func extractPitchingRawStats(p mlbapi.PitchingStats) map[string]float64 {
 	raw := make(map[string]float64)
 
 	ip := parseInningsPitched(p.InningsPitched)
@@ -382,7 +427,7 @@ func extractPitchingRawStats(p pitchingStats) map[string]float64 {
 }
 
 // extractHittingRawStats converts MLB API batting stats to our flat map.
-func extractHittingRawStats(b battingStats) map[string]float64 {
+func extractHittingRawStats(b mlbapi.BattingStats) map[string]float64 {
 	raw := make(map[string]float64)
 	raw["h"] = toFloat(b.Hits)
 	raw["hr"] = toFloat(b.HomeRuns)
@@ -395,15 +440,49 @@ func extractHittingRawStats(b battingStats) map[string]float64 {
 	return raw
 }
 
-// calculateFantasyPoints computes total points from raw stats and scoring weights.
-func calculateFantasyPoints(raw map[string]float64, scoringMap map[string]float64) float64 {
-	total := 0.0
-	for key, value := range raw {
-		if weight, ok := scoringMap[key]; ok {
-			total += value * weight
-		}
+// leagueScoring caches each league's scoring map for one season while a date is processed.
+type leagueScoring struct {
+	db     *pgxpool.Pool
+	season int
+	maps   map[string]map[string]float64
+	milb   map[string]bool
+}
+
+func newLeagueScoring(db *pgxpool.Pool, season int) *leagueScoring {
+	return &leagueScoring{db: db, season: season, maps: make(map[string]map[string]float64), milb: make(map[string]bool)}
+}
+
+// points scores a stat line for a league; minor-league lines score only if the league opted in.
+func (s *leagueScoring) points(leagueID, statType, level string, raw map[string]float64) float64 {
+	scoresMiLB, ok := s.milb[leagueID]
+	if !ok {
+		scoresMiLB = leagueID != "" && store.GetLeagueSettings(s.db, leagueID, s.season).MiLBScoring
+		s.milb[leagueID] = scoresMiLB
+	}
+	return store.LineFantasyPoints(raw, s.forLeague(leagueID, statType), level, scoresMiLB)
+}
+
+func (s *leagueScoring) load(leagueID, statType string) (map[string]float64, error) {
+	key := leagueID + "|" + statType
+	if m, ok := s.maps[key]; ok {
+		return m, nil
+	}
+	m, err := store.GetLeagueScoringMap(s.db, leagueID, s.season, statType)
+	if err != nil {
+		return nil, err
+	}
+	s.maps[key] = m
+	return m, nil
+}
+
+// forLeague returns the league's scoring map, falling back to the global defaults on error.
+func (s *leagueScoring) forLeague(leagueID, statType string) map[string]float64 {
+	m, err := s.load(leagueID, statType)
+	if err != nil {
+		fmt.Printf("ERROR [StatsWorker]: league %s %s scoring: %v\n", leagueID, statType, err)
+		m, _ = s.load("", statType)
 	}
-	return math.Round(total*100) / 100
+	return m
 }
 
 // parseInningsPitched converts MLB format (e.g. "7.1" = 7 1/3) to decimal innings.
@@ -427,68 +506,3 @@ func toFloat(v json.Number) float64 {
 	f, _ := v.Float64()
 	return f
 }
-
-// --- MLB API box score response types ---
-
-type boxScoreResp struct {
-	Teams struct {
-		Away boxScoreSide `json:"away"`
-		Home boxScoreSide `json:"home"`
-	} `json:"teams"`
-}
-
-type boxScoreSide struct {
-	Team struct {
-		Abbreviation string `json:"abbreviation"`
-	} `json:"team"`
-	Players map[string]boxScorePlayer `json:"players"`
-}
-
-type boxScorePlayer struct {
-	Person struct {
-		ID       int    `json:"id"`
-		FullName string `json:"fullName"`
-	} `json:"person"`
-	Stats struct {
-		Pitching pitchingStats `json:"pitching"`
-		Batting  battingStats  `json:"batting"`
-	} `json:"stats"`
-}
-
-type pitchingStats struct {
-	InningsPitched         string      `json:"inningsPitched"`
-	Hits                   json.Number `json:"hits"`
-	EarnedRuns             json.Number `json:"earnedRuns"`
-	BaseOnBalls            json.Number `json:"baseOnBalls"`
-	StrikeOuts             json.Number `json:"strikeOuts"`
-	HomeRuns               json.Number `json:"homeRuns"`
-	HitByPitch             json.Number `json:"hitByPitch"`
-	Balks                  json.Number `json:"balks"`
-	WildPitches            json.Number `json:"wildPitches"`
-	Pickoffs               json.Number `json:"pickoffs"`
-	GamesStarted           json.Number `json:"gamesStarted"`
-	CompleteGames          json.Number `json:"completeGames"`
-	Shutouts               json.Number `json:"shutouts"`
-	Saves                  json.Number `json:"saves"`
-	Holds                  json.Number `json:"holds"`
-	BlownSaves             json.Number `json:"blownSaves"`
-	InheritedRunners       json.Number `json:"inheritedRunners"`
-	InheritedRunnersScored json.Number `json:"inheritedRunnersScored"`
-	NumberOfPitches        json.Number `json:"numberOfPitches"`
-}
-
-type battingStats struct {
-	AtBats           json.Number `json:"atBats"`
-	Runs             json.Number `json:"runs"`
-	Hits             json.Number `json:"hits"`
-	Doubles          json.Number `json:"doubles"`
-	Triples          json.Number `json:"triples"`
-	HomeRuns         json.Number `json:"homeRuns"`
-	RBI              json.Number `json:"rbi"`
-	BaseOnBalls      json.Number `json:"baseOnBalls"`
-	StrikeOuts       json.Number `json:"strikeOuts"`
-	StolenBases      json.Number `json:"stolenBases"`
-	CaughtStealing   json.Number `json:"caughtStealing"`
-	HitByPitch       json.Number `json:"hitByPitch"`
-	PlateAppearances json.Number `json:"plateAppearances"`
-}
@@ -0,0 +1,124 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dwes123/fantasy-baseball-go/internal/mlbapi"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replayBoxScore loads the recorded NYY @ BOS box score from the mlbapi fixtures.
+func replayBoxScore(t *testing.T) *mlbapi.BoxScore {
+	t.Helper()
+	c := mlbapi.New(mlbapi.Config{Mode: mlbapi.ModeReplay, FixtureDir: "../mlbapi/testdata"})
+	b, err := c.BoxScore(context.Background(), 777245)
+	if err != nil {
+		t.Fatalf("BoxScore: %v", err)
+	}
+	return b
+}
+
+// stubPlayerCopies puts Judge in two leagues and Cole, Weaver and Bello in one.
+func stubPlayerCopies(t *testing.T) {
+	t.Helper()
+	copies := map[int][]playerCopy{
+		592450: {{PlayerID: "judge-1", TeamID: "team-1", LeagueID: "league-1"}, {PlayerID: "judge-2", TeamID: "team-2", LeagueID: "league-2"}},
+		543037: {{PlayerID: "cole-1", TeamID: "team-1", LeagueID: "league-1"}},
+		680694: {{PlayerID: "weaver-1", TeamID: "team-1", LeagueID: "league-1"}},
+		678394: {{PlayerID: "bello-1", TeamID: "team-3", LeagueID: "league-1"}},
+		677594: {{PlayerID: "rice-1", TeamID: "team-1", LeagueID: "league-1"}},
+	}
+	orig := lookupPlayerCopies
+	lookupPlayerCopies = func(_ *pgxpool.Pool, mlbID int, _ string) []playerCopy { return copies[mlbID] }
+	t.Cleanup(func() { lookupPlayerCopies = orig })
+}
+
+// testScoring returns scoring preloaded for both leagues so points() never reaches the database.
+func testScoring(milb bool) *leagueScoring {
+	s := newLeagueScoring(nil, 2025)
+	pitching := map[string]float64{"ip": 3, "k": 1, "er": -2, "qs": 3, "sv": 5}
+	s.maps["league-1|pitching"] = pitching
+	s.maps["league-1|hitting"] = map[string]float64{"h": 1, "hr": 4, "rbi": 1, "r": 1, "bb": 1, "sb": 2, "k": -0.5}
+	s.maps["league-2|hitting"] = map[string]float64{"hr": 10}
+	s.milb["league-1"] = milb
+	s.milb["league-2"] = milb
+	return s
+}
+
+func linesByPlayer(lines []store.DailyPlayerStats) map[string]store.DailyPlayerStats {
+	m := make(map[string]store.DailyPlayerStats)
+	for _, l := range lines {
+		m[l.PlayerID] = l
+	}
+	return m
+}
+
+func TestGameStatLinesPitching(t *testing.T) {
+	stubPlayerCopies(t)
+	lines := linesByPlayer(gameStatLines(nil, replayBoxScore(t), 777245, "2025-06-01", "pitching", "MLB", testScoring(false)))
+
+	if len(lines) != 3 {
+		t.Fatalf("got %d pitching lines, want 3 (Cole, Weaver, Bello)", len(lines))
+	}
+	cole := lines["cole-1"]
+	if cole.RawStats["ip"] != 7 || cole.RawStats["k"] != 9 || cole.RawStats["qs"] != 1 {
+		t.Errorf("Cole raw = %v, want 7 IP, 9 K and a quality start", cole.RawStats)
+	}
+	// 7 IP * 3 + 9 K - 1 ER * 2 + QS 3
+	if cole.FantasyPoints != 31 {
+		t.Errorf("Cole points = %v, want 31", cole.FantasyPoints)
+	}
+	if cole.Opponent != "BOS" || cole.TeamID != "team-1" || cole.GamePk != 777245 || cole.MlbID != "543037" {
+		t.Errorf("Cole line = %+v", cole)
+	}
+	if weaver := lines["weaver-1"]; weaver.RawStats["irs"] != 1 || weaver.RawStats["sv"] != 1 {
+		t.Errorf("Weaver raw = %v, want 1 stranded runner and a save", weaver.RawStats)
+	}
+	bello := lines["bello-1"]
+	if bello.Opponent != "NYY" || bello.RawStats["ip"] != 5.333 || bello.RawStats["qs"] != 0 {
+		t.Errorf("Bello line = %+v, want 5.333 IP against NYY and no quality start", bello)
+	}
+}
+
+func TestGameStatLinesHitting(t *testing.T) {
+	stubPlayerCopies(t)
+	lines := linesByPlayer(gameStatLines(nil, replayBoxScore(t), 777245, "2025-06-01", "hitting", "MLB", testScoring(false)))
+
+	if _, ok := lines["rice-1"]; ok {
+		t.Error("Rice had no plate appearances but got a hitting line")
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d hitting lines, want Judge's two league copies", len(lines))
+	}
+	// Each league's copy is scored with that league's rules
+	if got := lines["judge-1"].FantasyPoints; got != 13.5 {
+		t.Errorf("league-1 Judge points = %v, want 13.5", got)
+	}
+	if got := lines["judge-2"].FantasyPoints; got != 10 {
+		t.Errorf("league-2 Judge points = %v, want 10", got)
+	}
+	if l := lines["judge-2"]; l.LeagueID != "league-2" || l.TeamID != "team-2" || l.Level != "MLB" {
+		t.Errorf("league-2 Judge line = %+v", l)
+	}
+}
+
+func TestGameStatLinesMiLBScoring(t *testing.T) {
+	stubPlayerCopies(t)
+	box := replayBoxScore(t)
+
+	for _, milb := range []bool{false, true} {
+		lines := linesByPlayer(gameStatLines(nil, box, 777245, "2025-06-01", "hitting", "AAA", testScoring(milb)))
+		want := 0.0
+		if milb {
+			want = 13.5
+		}
+		if got := lines["judge-1"].FantasyPoints; got != want {
+			t.Errorf("MiLB scoring %v: AAA line points = %v, want %v", milb, got, want)
+		}
+		if lines["judge-1"].Level != "AAA" {
+			t.Errorf("level = %q, want AAA", lines["judge-1"].Level)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/fantrax"
+	"github.com/dwes123/fantasy-baseball-go/internal/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -55,16 +56,13 @@ func recomputeIfDue(ctx context.Context, db *pgxpool.Pool) {
 	markAsRun(db, ctx, key)
 }
 
-// RecomputeAllWaiverPriorities fetches fresh standings for each linked league
-// and writes 1..N to teams.current_waiver_priority. 1 = worst-standing team
-// (picks first); N = best team. Tiebreaker: lower totalPointsFor wins the
-// lower (better) priority number.
+// RecomputeAllWaiverPriorities writes 1..N to teams.current_waiver_priority for every league.
+// 1 = worst-standing team (picks first); N = best team. Standings come from Fantrax unless the
+// league uses native standings; when Fantrax is unlinked or unreachable the in-house matchup
+// standings are used instead, if the league has a schedule. Tiebreaker: lower points for wins
+// the lower (better) priority number.
 func RecomputeAllWaiverPriorities(ctx context.Context, db *pgxpool.Pool) error {
-	rows, err := db.Query(ctx, `
-		SELECT id::TEXT, name, fantrax_url
-		FROM leagues
-		WHERE fantrax_url IS NOT NULL AND fantrax_url <> ''
-	`)
+	rows, err := db.Query(ctx, `SELECT id::TEXT, name, COALESCE(fantrax_url, '') FROM leagues`)
 	if err != nil {
 		return err
 	}
@@ -78,26 +76,60 @@ func RecomputeAllWaiverPriorities(ctx context.Context, db *pgxpool.Pool) error {
 	}
 	rows.Close()
 
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("EST", -5*60*60)
+	}
+	today := time.Now().In(loc)
+	year := today.Year()
+
 	for _, l := range leagues {
-		// Force fresh fetch — daily recompute must not reuse yesterday's cache.
-		fantrax.Invalidate(l.url)
-		standings, err := fantrax.Fetch(l.url)
-		if err != nil {
-			fmt.Printf("Waiver Priority Worker [%s]: fetch failed: %v\n", l.name, err)
-			continue
+		settings := store.GetLeagueSettings(db, l.id, year)
+
+		// Ordered worst to best; key matches teams.<column>
+		type priorityTeam struct {
+			key, name string
+			rank      int
+			pointsFor float64
+		}
+		var order []priorityTeam
+		column, source := "fantrax_team_id", "fantrax"
+
+		if settings.StandingsSource != "native" && l.url != "" {
+			// Force fresh fetch — daily recompute must not reuse yesterday's cache.
+			fantrax.Invalidate(l.url)
+			standings, err := fantrax.Fetch(l.url)
+			if err != nil {
+				fmt.Printf("Waiver Priority Worker [%s]: fetch failed: %v\n", l.name, err)
+			}
+			for _, s := range standings {
+				order = append(order, priorityTeam{key: s.TeamID, name: s.TeamName, rank: s.Rank, pointsFor: s.TotalPointsFor})
+			}
+		}
+		if len(order) == 0 && store.HasSchedule(db, l.id, year) {
+			standings, err := store.GetNativeStandings(db, l.id, year, today.Format("2006-01-02"))
+			if err != nil {
+				fmt.Printf("Waiver Priority Worker [%s]: native standings: %v\n", l.name, err)
+			}
+			for _, s := range standings {
+				order = append(order, priorityTeam{key: s.TeamID, name: s.TeamName, rank: s.Rank, pointsFor: s.PointsFor})
+			}
+			column, source = "id", "native"
 		}
-		if len(standings) == 0 {
-			fmt.Printf("Waiver Priority Worker [%s]: empty standings\n", l.name)
+		if len(order) == 0 {
+			if l.url != "" || settings.StandingsSource == "native" {
+				fmt.Printf("Waiver Priority Worker [%s]: no standings available\n", l.name)
+			}
 			continue
 		}
 
 		// Higher rank number = worse standing = picks first. Tiebreak by lower
-		// totalPointsFor (worse offense = picks earlier among tied teams).
-		sort.SliceStable(standings, func(i, j int) bool {
-			if standings[i].Rank != standings[j].Rank {
-				return standings[i].Rank > standings[j].Rank
+		// points for (worse offense = picks earlier among tied teams).
+		sort.SliceStable(order, func(i, j int) bool {
+			if order[i].rank != order[j].rank {
+				return order[i].rank > order[j].rank
 			}
-			return standings[i].TotalPointsFor < standings[j].TotalPointsFor
+			return order[i].pointsFor < order[j].pointsFor
 		})
 
 		tx, err := db.Begin(ctx)
@@ -111,16 +143,16 @@ func RecomputeAllWaiverPriorities(ctx context.Context, db *pgxpool.Pool) error {
 			continue
 		}
 		var unmatched []string
-		for i, s := range standings {
+		for i, t := range order {
 			tag, err := tx.Exec(ctx,
-				"UPDATE teams SET current_waiver_priority = $1 WHERE league_id = $2 AND fantrax_team_id = $3",
-				i+1, l.id, s.TeamID)
+				"UPDATE teams SET current_waiver_priority = $1 WHERE league_id = $2 AND "+column+"::TEXT = $3",
+				i+1, l.id, t.key)
 			if err != nil {
-				fmt.Printf("Waiver Priority Worker [%s]: update %s: %v\n", l.name, s.TeamName, err)
+				fmt.Printf("Waiver Priority Worker [%s]: update %s: %v\n", l.name, t.name, err)
 				continue
 			}
 			if tag.RowsAffected() == 0 {
-				unmatched = append(unmatched, fmt.Sprintf("%s (%s)", s.TeamName, s.TeamID))
+				unmatched = append(unmatched, fmt.Sprintf("%s (%s)", t.name, t.key))
 			}
 		}
 		if err := tx.Commit(ctx); err != nil {
@@ -128,9 +160,9 @@ func RecomputeAllWaiverPriorities(ctx context.Context, db *pgxpool.Pool) error {
 			continue
 		}
 		if len(unmatched) > 0 {
-			fmt.Printf("Waiver Priority Worker [%s]: %d unmatched fantrax_team_ids: %v\n", l.name, len(unmatched), unmatched)
+			fmt.Printf("Waiver Priority Worker [%s]: %d unmatched %s teams: %v\n", l.name, len(unmatched), column, unmatched)
 		}
-		fmt.Printf("Waiver Priority Worker [%s]: assigned priorities 1..%d\n", l.name, len(standings))
+		fmt.Printf("Waiver Priority Worker [%s]: assigned priorities 1..%d from %s standings\n", l.name, len(order), source)
 	}
 	return nil
 }
@@ -3,12 +3,9 @@ package worker
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/dwes123/fantasy-baseball-go/internal/store"
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -95,7 +92,11 @@ func processExpiredWaivers(db *pgxpool.Pool) {
 		} else {
 			// Release: calculate dead cap and release to free agency
 			currentYear := time.Now().Year()
-			applyDFADeadCap(tx, ctx, pID, waivingTeamID, currentYear)
+			_, err = store.ApplyReleaseDeadCap(ctx, tx, pID, waivingTeamID, currentYear, "DFA Release")
+			if err != nil {
+				tx.Rollback(ctx)
+				continue
+			}
 
 			_, err = tx.Exec(ctx, `
 				UPDATE players SET
@@ -140,43 +141,3 @@ func processExpiredWaivers(db *pgxpool.Pool) {
 		}
 	}
 }
-
-// applyDFADeadCap calculates and inserts dead cap penalties for a DFA release.
-// Current year: 75% of salary. Future years: 50% of salary.
-func applyDFADeadCap(tx pgx.Tx, ctx context.Context, playerID, teamID string, currentYear int) {
-	contractCols := []int{2026, 2027, 2028, 2029, 2030, 2031, 2032, 2033, 2034, 2035, 2036, 2037, 2038, 2039, 2040}
-
-	for _, year := range contractCols {
-		if year < currentYear {
-			continue
-		}
-		var contractStr string
-		col := fmt.Sprintf("contract_%d", year)
-		err := tx.QueryRow(ctx, fmt.Sprintf("SELECT COALESCE(%s, '') FROM players WHERE id = $1", col), playerID).Scan(&contractStr)
-		if err != nil || contractStr == "" {
-			continue
-		}
-
-		// Skip non-dollar values like "TC", "ARB", "UFA"
-		if !strings.Contains(contractStr, "$") && !strings.ContainsAny(contractStr, "0123456789") {
-			continue
-		}
-
-		cleanStr := strings.ReplaceAll(strings.ReplaceAll(contractStr, "$", ""), ",", "")
-		salary, _ := strconv.ParseFloat(cleanStr, 64)
-		if salary <= 0 {
-			continue
-		}
-
-		pct := 0.50 // future years
-		if year == currentYear {
-			pct = 0.75
-		}
-		deadCap := salary * pct
-
-		tx.Exec(ctx, `
-			INSERT INTO dead_cap_penalties (team_id, player_id, amount, year, note)
-			VALUES ($1, $2, $3, $4, $5)
-		`, teamID, playerID, deadCap, year, fmt.Sprintf("DFA Release (%.0f%%)", pct*100))
-	}
-}
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- 035_contract_rollover.sql
+-- End-of-season contract rollover: expiring contracts are released to free agency
+-- once the league's 'contract_rollover' date (league_dates) arrives.
+
+-- Action applied to undecided team options once 'option_deadline' passes ('decline' or 'exercise')
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS option_default_action TEXT DEFAULT 'decline';
+
+-- Commissioner report: one row per change made by a rollover run
+CREATE TABLE IF NOT EXISTS contract_rollover_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    player_id UUID REFERENCES players(id) ON DELETE SET NULL,
+    player_name TEXT NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    team_name TEXT NOT NULL,
+    action TEXT NOT NULL,             -- 'released', 'option_pending', 'option_exercised', 'option_declined'
+    detail TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_rollover_log_league_season ON contract_rollover_log(league_id, season);
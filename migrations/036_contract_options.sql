@@ -0,0 +1,27 @@
+-- 036_contract_options.sql
+-- Structured contract options: team, player and mutual options with buyouts, plus opt-out clauses.
+-- Legacy "(TO)" contract values keep working; rows here add buyouts, deadlines and player-side decisions.
+
+CREATE TABLE IF NOT EXISTS contract_options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID REFERENCES players(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,            -- option year; for 'opt_out' the season after which the player may leave
+    option_type TEXT NOT NULL,        -- 'team', 'player', 'mutual', 'opt_out'
+    buyout NUMERIC DEFAULT 0,         -- becomes dead cap when the option is declined (0 = league default of 30%)
+    decision_deadline DATE,           -- falls back to the league's option_deadline
+    team_decision TEXT,               -- 'exercise' / 'decline'
+    player_decision TEXT,             -- 'in' / 'out'
+    decided_by TEXT,                  -- 'team', 'rule', 'commissioner'
+    status TEXT DEFAULT 'pending',    -- 'pending', 'exercised', 'declined', 'opted_out', 'stayed'
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    UNIQUE(player_id, year, option_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_options_status ON contract_options(status);
+
+-- How player-side decisions (player/mutual options, opt-outs) are made:
+-- 'commissioner' (manual ruling), 'always_in', 'always_out', or 'salary_threshold'
+-- (player stays when the salary at stake is at least player_option_threshold).
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS player_option_rule TEXT DEFAULT 'commissioner';
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS player_option_threshold NUMERIC DEFAULT 0;
@@ -0,0 +1,34 @@
+-- 037_arbitration_hearings.sql
+-- Arbitration hearings: the league generates the player's figure, the team files its own,
+-- and the commissioner rules for one side or settles at the midpoint.
+-- Deadlines live in league_dates as 'arb_filing_deadline' and 'arb_hearing_deadline'.
+
+CREATE TABLE IF NOT EXISTS arbitration_hearings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    player_id UUID REFERENCES players(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    year INTEGER NOT NULL,              -- contract year being arbitrated
+    service_class TEXT NOT NULL,        -- 'ARB 1', 'ARB 2', 'ARB 3'
+    prior_points NUMERIC DEFAULT 0,     -- fantasy points from daily_player_stats for year - 1
+    player_figure NUMERIC NOT NULL,     -- league-generated from the formula below
+    team_figure NUMERIC,
+    ruling TEXT,                        -- 'player', 'team', 'midpoint'
+    awarded_amount NUMERIC,
+    status TEXT DEFAULT 'open',         -- 'open', 'filed', 'decided', 'declined'
+    decided_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    filed_at TIMESTAMPTZ,
+    decided_at TIMESTAMPTZ,
+    UNIQUE(player_id, year)
+);
+
+CREATE INDEX IF NOT EXISTS idx_arbitration_hearings_league_year ON arbitration_hearings(league_id, year);
+
+-- Player figure formula: MAX(min salary, base + prior-season points * dollars_per_point * class multiplier)
+ALTER TABLE league_settings
+    ADD COLUMN IF NOT EXISTS arb_base_salary NUMERIC DEFAULT 760000,
+    ADD COLUMN IF NOT EXISTS arb_dollars_per_point NUMERIC DEFAULT 10000,
+    ADD COLUMN IF NOT EXISTS arb_class1_multiplier NUMERIC DEFAULT 0.40,
+    ADD COLUMN IF NOT EXISTS arb_class2_multiplier NUMERIC DEFAULT 0.60,
+    ADD COLUMN IF NOT EXISTS arb_class3_multiplier NUMERIC DEFAULT 0.80;
@@ -0,0 +1,37 @@
+-- 038_qualifying_offers.sql
+-- Qualifying offers: at season end a team may tender a one-year offer at a league-computed amount
+-- to a player whose contract is expiring. Accepting players are signed for next season; declining
+-- players reach free agency QO-attached and the signing team owes the original team compensation.
+-- Deadlines live in league_dates as 'qo_tender_deadline' and 'qo_response_deadline'.
+
+CREATE TABLE IF NOT EXISTS qualifying_offers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    player_id UUID REFERENCES players(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,   -- tendering (original) team
+    season INTEGER NOT NULL,                                -- season that just ended; the offer covers season + 1
+    amount NUMERIC NOT NULL,
+    status TEXT DEFAULT 'tendered',                         -- 'tendered', 'accepted', 'declined'
+    decided_by TEXT,                                        -- 'rule', 'commissioner'
+    signed_team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    compensation_type TEXT,                                 -- 'isbp', 'milb' (NULL until the player signs elsewhere)
+    compensation_amount NUMERIC,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    compensated_at TIMESTAMPTZ,
+    UNIQUE(player_id, season)
+);
+
+CREATE INDEX IF NOT EXISTS idx_qualifying_offers_league_season ON qualifying_offers(league_id, season);
+
+-- qo_top_n: the offer amount is the average of the league's top N salaries for the season
+-- qo_accept_rule: 'commissioner', 'always_accept', 'always_decline', or 'salary'
+--   (player accepts when the offer is at least the salary of the expiring season)
+-- qo_compensation_type: 'none', 'isbp' or 'milb' balance moved when a QO-attached player signs elsewhere
+ALTER TABLE league_settings
+    ADD COLUMN IF NOT EXISTS qo_enabled BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS qo_top_n INTEGER DEFAULT 125,
+    ADD COLUMN IF NOT EXISTS qo_accept_rule TEXT DEFAULT 'salary',
+    ADD COLUMN IF NOT EXISTS qo_compensation_type TEXT DEFAULT 'isbp',
+    ADD COLUMN IF NOT EXISTS qo_compensation_amount NUMERIC DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS qo_signing_team_pays BOOLEAN DEFAULT TRUE;
@@ -0,0 +1,13 @@
+-- 039_salary_schedules.sql
+-- Per-year salary schedules for free-agent bids and extensions (flat, front-loaded, back-loaded or custom).
+-- Bids are compared on total and present value rather than a single AAV.
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS pending_bid_schedule JSONB; -- [year1, year2, ...] salaries of the leading bid
+
+-- contract_min_salary: no contract year may pay less
+-- contract_max_yoy_variance: largest allowed change between consecutive years (0.25 = 25%)
+-- contract_discount_rate: annual rate used to discount later years when valuing bids
+ALTER TABLE league_settings
+    ADD COLUMN IF NOT EXISTS contract_min_salary NUMERIC DEFAULT 760000,
+    ADD COLUMN IF NOT EXISTS contract_max_yoy_variance NUMERIC DEFAULT 0.25,
+    ADD COLUMN IF NOT EXISTS contract_discount_rate NUMERIC DEFAULT 0.05;
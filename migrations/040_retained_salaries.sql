@@ -0,0 +1,30 @@
+-- 040_retained_salaries.sql
+-- Retained-salary ledger: when a traded player's salary is partly kept by the sending team,
+-- each retained year is recorded here (linked to the trade) instead of as a dead_cap_penalties row.
+-- The player's contract column carries only the receiving team's share; the paying team's share
+-- stays on its payroll through later releases, re-trades and extensions.
+
+ALTER TABLE trade_items
+    ADD COLUMN IF NOT EXISTS retain_pct INTEGER DEFAULT 0,         -- elective retention (e.g. 25, 50) of the remaining salary
+    ADD COLUMN IF NOT EXISTS retain_through_year INTEGER;          -- last contract year retained (NULL = current year only)
+
+-- Proposals made before the ledger used a flat 50% current-year retention
+UPDATE trade_items SET retain_pct = 50 WHERE retain_salary = true AND COALESCE(retain_pct, 0) = 0;
+
+CREATE TABLE IF NOT EXISTS retained_salaries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trade_id UUID REFERENCES trades(id) ON DELETE CASCADE,
+    player_id UUID REFERENCES players(id) ON DELETE CASCADE,
+    paying_team_id UUID REFERENCES teams(id) ON DELETE CASCADE,    -- team that keeps the salary
+    receiving_team_id UUID REFERENCES teams(id) ON DELETE SET NULL, -- team the player was traded to
+    year INTEGER NOT NULL,
+    percentage NUMERIC NOT NULL,                                   -- share of the pre-trade salary (25 = 25%)
+    amount NUMERIC NOT NULL,
+    note TEXT,
+    status TEXT DEFAULT 'active',                                  -- 'active', 'reversed', 'voided'
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(trade_id, player_id, year)
+);
+
+CREATE INDEX IF NOT EXISTS idx_retained_salaries_paying ON retained_salaries(paying_team_id, year);
+CREATE INDEX IF NOT EXISTS idx_retained_salaries_player ON retained_salaries(player_id);
@@ -0,0 +1,36 @@
+-- 041_balance_ledger.sql
+-- Double-entry ledger for team ISBP and MiLB balances. Every change to teams.isbp_balance or
+-- teams.milb_balance posts an entry here with the running balance after it; transfers between
+-- teams post one debit and one credit sharing the same reference. Entries with no counterparty
+-- are against the league (signings, allocations, commissioner adjustments).
+
+CREATE TABLE IF NOT EXISTS balance_ledger (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    balance_type TEXT NOT NULL,                     -- 'isbp', 'milb'
+    amount NUMERIC NOT NULL,                        -- signed: credits positive, debits negative
+    balance_after NUMERIC NOT NULL,
+    entry_type TEXT NOT NULL,                       -- 'opening', 'signing', 'trade', 'trade_reversal',
+                                                    -- 'qo_compensation', 'commissioner', 'allocation'
+    reference_type TEXT,                            -- 'trade', 'player', 'qualifying_offer', ...
+    reference_id TEXT,
+    counterparty_team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    description TEXT,
+    created_by TEXT,                                -- username, 'system' or 'agent'
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_ledger_team ON balance_ledger(team_id, balance_type, created_at);
+CREATE INDEX IF NOT EXISTS idx_balance_ledger_league ON balance_ledger(league_id, created_at);
+
+-- Opening entries so the ledger starts in agreement with the stored balances
+INSERT INTO balance_ledger (team_id, league_id, balance_type, amount, balance_after, entry_type, description, created_by)
+SELECT t.id, t.league_id, 'isbp', COALESCE(t.isbp_balance, 0), COALESCE(t.isbp_balance, 0), 'opening', 'Opening balance', 'system'
+FROM teams t
+WHERE NOT EXISTS (SELECT 1 FROM balance_ledger bl WHERE bl.team_id = t.id AND bl.balance_type = 'isbp');
+
+INSERT INTO balance_ledger (team_id, league_id, balance_type, amount, balance_after, entry_type, description, created_by)
+SELECT t.id, t.league_id, 'milb', COALESCE(t.milb_balance, 0), COALESCE(t.milb_balance, 0), 'opening', 'Opening balance', 'system'
+FROM teams t
+WHERE NOT EXISTS (SELECT 1 FROM balance_ledger bl WHERE bl.team_id = t.id AND bl.balance_type = 'milb');
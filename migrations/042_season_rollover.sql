@@ -0,0 +1,27 @@
+-- 042_season_rollover.sql
+-- Commissioner season-rollover wizard: clones a league's settings and key dates into the next
+-- season, posts the annual ISBP / MiLB allocations and resets per-season counters.
+-- One row per league and new season makes the wizard idempotent: allocations and resets are
+-- applied only when the row is first created; rerunning only fills in missing settings/dates.
+
+CREATE TABLE IF NOT EXISTS season_rollovers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    from_year INTEGER NOT NULL,
+    to_year INTEGER NOT NULL,
+    isbp_allocation NUMERIC DEFAULT 0,
+    milb_allocation NUMERIC DEFAULT 0,
+    allocation_mode TEXT DEFAULT 'add',     -- 'add' (credit on top of balance) or 'reset' (set balance to the allocation)
+    settings_cloned BOOLEAN DEFAULT FALSE,
+    dates_cloned INTEGER DEFAULT 0,
+    players_reset INTEGER DEFAULT 0,
+    created_by TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(league_id, to_year)
+);
+
+-- Per-season allocation amounts (cloned forward with the rest of the settings)
+ALTER TABLE league_settings
+    ADD COLUMN IF NOT EXISTS season_isbp_allocation NUMERIC DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS season_milb_allocation NUMERIC DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS season_allocation_mode TEXT DEFAULT 'add';
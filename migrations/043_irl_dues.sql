@@ -0,0 +1,45 @@
+-- 043_irl_dues.sql
+-- Real-life league dues: one invoice per team and season, built from the IRL buy-in and
+-- luxury-tax amounts plus any manual fees the commissioner adds, with payments recorded
+-- against it. Outstanding balance = SUM(line items) - SUM(payments).
+
+CREATE TABLE IF NOT EXISTS dues_invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    owner_name TEXT,
+    due_date DATE,
+    notes TEXT,
+    last_reminded_at TIMESTAMPTZ,
+    reminder_count INTEGER DEFAULT 0,
+    created_by TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(team_id, year)
+);
+
+CREATE TABLE IF NOT EXISTS dues_line_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID REFERENCES dues_invoices(id) ON DELETE CASCADE,
+    item_type TEXT NOT NULL,        -- 'buy_in', 'luxury_tax' (regenerated from IRL financials), 'fee' (manual)
+    description TEXT,
+    amount NUMERIC NOT NULL,        -- real-life USD
+    created_by TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS dues_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID REFERENCES dues_invoices(id) ON DELETE CASCADE,
+    amount NUMERIC NOT NULL,        -- real-life USD
+    method TEXT,                    -- 'venmo', 'paypal', 'zelle', 'cash', 'check', 'other'
+    note TEXT,
+    paid_on DATE DEFAULT CURRENT_DATE,
+    recorded_by TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dues_invoices_league ON dues_invoices(league_id, year);
+CREATE INDEX IF NOT EXISTS idx_dues_line_items_invoice ON dues_line_items(invoice_id);
+CREATE INDEX IF NOT EXISTS idx_dues_payments_invoice ON dues_payments(invoice_id);
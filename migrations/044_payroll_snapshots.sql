@@ -0,0 +1,23 @@
+-- 044_payroll_snapshots.sql
+-- Nightly per-team, per-contract-year payroll snapshots. CalculateYearlySummary is computed live
+-- from the contract columns, so this is the only record of how payroll and tax space moved
+-- through a season (e.g. what a team's payroll was on trade-deadline day).
+
+CREATE TABLE IF NOT EXISTS payroll_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    snapshot_date DATE NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    league_id UUID REFERENCES leagues(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,                  -- contract year the figures are for
+    active_payroll NUMERIC DEFAULT 0,
+    dead_cap NUMERIC DEFAULT 0,
+    retained_salary NUMERIC DEFAULT 0,
+    total_payroll NUMERIC DEFAULT 0,
+    luxury_tax_limit NUMERIC DEFAULT 0,
+    tax_space NUMERIC DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(snapshot_date, team_id, year)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payroll_snapshots_team ON payroll_snapshots(team_id, year, snapshot_date);
+CREATE INDEX IF NOT EXISTS idx_payroll_snapshots_league ON payroll_snapshots(league_id, year, snapshot_date);
@@ -0,0 +1,19 @@
+-- 045_payroll_scenarios.sql
+-- Owner payroll planner: saved what-if scenarios (option decisions, arbitration estimates,
+-- hypothetical signings, releases, trade pieces) evaluated against the team's live contracts.
+-- Scenarios are private to their creator unless shared with the team's co-owners.
+
+CREATE TABLE IF NOT EXISTS payroll_scenarios (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    notes TEXT,
+    start_year INTEGER NOT NULL,             -- first season the scenario plans for
+    moves JSONB NOT NULL DEFAULT '[]'::jsonb, -- [{ "type": "release", "player_id": "uuid", "from_year": 2027 }, ...]
+    shared BOOLEAN DEFAULT FALSE,            -- visible to the team's co-owners
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payroll_scenarios_team ON payroll_scenarios(team_id);
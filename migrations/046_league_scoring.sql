@@ -0,0 +1,21 @@
+-- 046_league_scoring.sql
+-- Per-league, per-season fantasy scoring. Rows override the global scoring_categories defaults
+-- for one league and season; categories without an override keep the global points.
+-- daily_player_stats rows already carry the player's league_id, so fantasy_points is scored
+-- with that league's rules and recomputed when they change.
+
+CREATE TABLE IF NOT EXISTS league_scoring_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    stat_type TEXT NOT NULL,              -- 'pitching' or 'hitting'
+    stat_key TEXT NOT NULL,               -- matches scoring_categories.stat_key
+    points NUMERIC(6,2) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID REFERENCES users(id),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(league_id, season, stat_type, stat_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_league_scoring_rules_league ON league_scoring_rules(league_id, season);
+CREATE INDEX IF NOT EXISTS idx_daily_player_stats_league_date ON daily_player_stats(league_id, game_date);
@@ -0,0 +1,23 @@
+-- 047_stat_corrections.sql
+-- Log of MLB scoring changes picked up after a date was processed. The stats worker re-fetches
+-- box scores for a rolling window, diffs them against daily_player_stats and records each
+-- stat line it rewrites here.
+
+CREATE TABLE IF NOT EXISTS stat_corrections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    daily_stat_id UUID REFERENCES daily_player_stats(id) ON DELETE SET NULL,
+    player_id UUID NOT NULL REFERENCES players(id),
+    league_id UUID REFERENCES leagues(id),
+    team_id UUID REFERENCES teams(id),
+    game_pk INT NOT NULL,
+    game_date DATE NOT NULL,
+    stat_type TEXT NOT NULL,
+    old_raw_stats JSONB NOT NULL DEFAULT '{}'::jsonb,
+    new_raw_stats JSONB NOT NULL DEFAULT '{}'::jsonb,
+    old_points NUMERIC(8,2) NOT NULL DEFAULT 0,
+    new_points NUMERIC(8,2) NOT NULL DEFAULT 0,
+    corrected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stat_corrections_date ON stat_corrections(game_date);
+CREATE INDEX IF NOT EXISTS idx_stat_corrections_league ON stat_corrections(league_id, corrected_at);
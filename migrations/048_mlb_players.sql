@@ -0,0 +1,51 @@
+-- 048_mlb_players.sql
+-- Canonical identity for real players, shared by every league. Each league keeps its own
+-- players row (ownership, roster status, contract) linked through mlb_player_id. The bio
+-- columns on players (name, position, mlb_team, mlb_id, is_minor_leaguer) are mirrors kept
+-- in sync from mlb_players; imports, sync and the MLB workers write the canonical row once.
+
+CREATE TABLE IF NOT EXISTS mlb_players (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mlb_id INTEGER UNIQUE,                -- NULL until the MLB ID populator matches the player
+    first_name TEXT NOT NULL,
+    last_name TEXT NOT NULL DEFAULT '',
+    position TEXT,
+    mlb_team TEXT,
+    is_minor_leaguer BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Players without an MLB ID are identified by name, matching how the CSV import and populator work
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mlb_players_name_unmatched
+    ON mlb_players (LOWER(first_name), LOWER(last_name)) WHERE mlb_id IS NULL;
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS mlb_player_id UUID REFERENCES mlb_players(id);
+CREATE INDEX IF NOT EXISTS idx_players_mlb_player_id ON players(mlb_player_id);
+
+-- Backfill: one canonical row per MLB ID, preferring the MLB league's copy for bio data
+INSERT INTO mlb_players (mlb_id, first_name, last_name, position, mlb_team, is_minor_leaguer)
+SELECT DISTINCT ON (mlb_id) mlb_id, first_name, COALESCE(last_name, ''), position, mlb_team, is_minor_leaguer
+FROM players
+WHERE mlb_id IS NOT NULL AND mlb_id > 0
+ORDER BY mlb_id, (league_id = '11111111-1111-1111-1111-111111111111') DESC, (team_id IS NOT NULL) DESC
+ON CONFLICT (mlb_id) DO NOTHING;
+
+UPDATE players p SET mlb_player_id = mp.id
+FROM mlb_players mp
+WHERE p.mlb_id = mp.mlb_id AND p.mlb_player_id IS NULL;
+
+-- Then one per name for players the populator hasn't matched
+INSERT INTO mlb_players (first_name, last_name, position, mlb_team, is_minor_leaguer)
+SELECT DISTINCT ON (LOWER(first_name), LOWER(COALESCE(last_name, '')))
+       first_name, COALESCE(last_name, ''), position, mlb_team, is_minor_leaguer
+FROM players
+WHERE mlb_player_id IS NULL
+ORDER BY LOWER(first_name), LOWER(COALESCE(last_name, '')),
+         (league_id = '11111111-1111-1111-1111-111111111111') DESC, (team_id IS NOT NULL) DESC
+ON CONFLICT DO NOTHING;
+
+UPDATE players p SET mlb_player_id = mp.id
+FROM mlb_players mp
+WHERE p.mlb_player_id IS NULL AND mp.mlb_id IS NULL
+  AND LOWER(mp.first_name) = LOWER(p.first_name) AND LOWER(mp.last_name) = LOWER(COALESCE(p.last_name, ''));
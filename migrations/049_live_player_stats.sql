@@ -0,0 +1,24 @@
+-- 049_live_player_stats.sql
+-- Provisional stat lines for games in progress. The live scoring worker rewrites these from the
+-- MLB live feed during game hours; nightly processing writes the final lines to
+-- daily_player_stats and clears the date from here.
+
+CREATE TABLE IF NOT EXISTS live_player_stats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    mlb_id TEXT NOT NULL,
+    game_pk INT NOT NULL,
+    game_date DATE NOT NULL,
+    stat_type TEXT NOT NULL,
+    raw_stats JSONB NOT NULL DEFAULT '{}'::jsonb,
+    fantasy_points NUMERIC(8,2) NOT NULL DEFAULT 0,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    league_id UUID REFERENCES leagues(id),
+    opponent TEXT NOT NULL DEFAULT '',
+    game_state TEXT NOT NULL DEFAULT '',
+    inning_label TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(player_id, game_pk, stat_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_live_player_stats_league_date ON live_player_stats(league_id, game_date);
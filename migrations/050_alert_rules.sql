@@ -0,0 +1,56 @@
+-- 050_alert_rules.sql
+-- Per-league live alert rules. The alert monitor scans live games for events (home runs, saves,
+-- quality starts, stolen bases, no-hitters in progress, pitcher injury exits, stat thresholds)
+-- and posts to Slack when a rule's players qualify. alert_events records every event posted so
+-- restarts neither double-post nor skip plays.
+
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    event_type TEXT NOT NULL,               -- home_run, save, quality_start, stolen_base, no_hitter, injury_exit, stat_threshold
+    stat_type TEXT NOT NULL DEFAULT '',     -- stat_threshold only: pitching or hitting
+    stat_key TEXT NOT NULL DEFAULT '',      -- stat_threshold only: raw stat key, e.g. k, hr, rbi
+    threshold NUMERIC(8,2) NOT NULL DEFAULT 0, -- stat_threshold minimum; no_hitter minimum completed innings
+    scope TEXT NOT NULL DEFAULT 'rostered', -- rostered, teams, players
+    team_ids UUID[] NOT NULL DEFAULT '{}',
+    player_ids UUID[] NOT NULL DEFAULT '{}',
+    notify_type TEXT NOT NULL DEFAULT 'stat_alerts', -- league_integrations channel to post to
+    channel_id TEXT NOT NULL DEFAULT '',    -- optional Slack channel ID overriding notify_type
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_league ON alert_rules(league_id);
+
+CREATE TABLE IF NOT EXISTS alert_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    event_key TEXT NOT NULL,
+    game_pk INT NOT NULL,
+    mlb_id INT,
+    message TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(rule_id, event_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_events_sent ON alert_events(sent_at);
+
+-- Carry over the old home run monitor: MLB league, rostered players, stat alerts channel
+INSERT INTO alert_rules (league_id, name, event_type)
+SELECT id, 'Home runs', 'home_run'
+FROM leagues
+WHERE id = '11111111-1111-1111-1111-111111111111'
+  AND NOT EXISTS (SELECT 1 FROM alert_rules WHERE league_id = '11111111-1111-1111-1111-111111111111' AND event_type = 'home_run');
+
+-- Keep the home runs it already posted so the first run doesn't repeat them
+DO $$
+BEGIN
+    IF to_regclass('hr_notifications') IS NOT NULL THEN
+        INSERT INTO alert_events (rule_id, event_key, game_pk, mlb_id, sent_at)
+        SELECT r.id, h.play_key, split_part(h.play_key, '_', 1)::INT, h.mlb_id::INT, h.notified_at
+        FROM hr_notifications h
+        JOIN alert_rules r ON r.league_id = '11111111-1111-1111-1111-111111111111' AND r.event_type = 'home_run'
+        ON CONFLICT (rule_id, event_key) DO NOTHING;
+    END IF;
+END $$;
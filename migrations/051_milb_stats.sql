@@ -0,0 +1,10 @@
+-- 051_milb_stats.sql
+-- Minor-league stat lines. The stats worker also ingests AAA, AA, High-A and Single-A box scores
+-- into daily_player_stats, tagged by level. Each league decides per season whether MiLB lines
+-- score fantasy points; when off they are stored with 0 points.
+
+ALTER TABLE daily_player_stats ADD COLUMN IF NOT EXISTS level TEXT NOT NULL DEFAULT 'MLB';
+
+CREATE INDEX IF NOT EXISTS idx_daily_player_stats_level ON daily_player_stats(level);
+
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS milb_scoring BOOLEAN DEFAULT FALSE;
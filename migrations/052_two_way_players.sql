@@ -0,0 +1,21 @@
+-- 052_two_way_players.sql
+-- Explicit two-way designation. A two-way player both hits and pitches: either IL duration is
+-- allowed, they stay rotation-eligible whatever their listed position, and both their hitting
+-- and pitching lines score. The flag lives on the canonical record and is mirrored onto every
+-- league's copy. Each league decides per season how two-way players count toward the SP limit
+-- and how many 26-man slots they take.
+
+ALTER TABLE mlb_players ADD COLUMN IF NOT EXISTS is_two_way BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS is_two_way BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- MLB lists two-way players with the TWP position
+UPDATE mlb_players SET is_two_way = TRUE WHERE UPPER(position) = 'TWP';
+UPDATE players p SET is_two_way = TRUE
+FROM mlb_players mp
+WHERE p.mlb_player_id = mp.id AND mp.is_two_way = TRUE;
+
+-- pitching_role: count toward the SP limit only while assigned SP (default)
+-- always: always count; exempt: never count
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS two_way_sp_rule TEXT DEFAULT 'pitching_role';
+-- 26-man slots a two-way player occupies (1 or 2)
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS two_way_roster_slots INT DEFAULT 1;
@@ -0,0 +1,24 @@
+-- 053_matchups.sql
+-- In-house head-to-head engine. matchups holds each league's weekly schedule. A team's daily
+-- score is the fantasy points its active (26-man) players earned that day, read from the roster
+-- snapshots (054), so stat corrections and rescoring flow through to matchups and standings
+-- without re-snapshotting.
+
+CREATE TABLE IF NOT EXISTS matchups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    year INT NOT NULL,
+    week INT NOT NULL,
+    week_start DATE NOT NULL,
+    week_end DATE NOT NULL,
+    home_team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    away_team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(league_id, year, week, home_team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_matchups_league_year ON matchups(league_id, year, week);
+
+-- fantrax: standings and waiver priority come from Fantrax (native standings shown alongside
+-- for cross-checking once a schedule exists); native: computed here from matchups
+ALTER TABLE league_settings ADD COLUMN IF NOT EXISTS standings_source TEXT DEFAULT 'fantrax';
@@ -0,0 +1,24 @@
+-- 054_roster_snapshots.sql
+-- Daily roster snapshots, locked at the day's first pitch. Every rostered player's team and
+-- membership (26-man, 40-man, IL, minors) is recorded once per date; team-level points and
+-- head-to-head scores are attributed from the snapshot rather than the roster at stats time.
+
+CREATE TABLE IF NOT EXISTS roster_snapshot_days (
+    snapshot_date DATE PRIMARY KEY,
+    first_pitch TIMESTAMPTZ,              -- NULL when the first-pitch lock was missed and the stats worker locked after games
+    locked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS roster_snapshots (
+    snapshot_date DATE NOT NULL REFERENCES roster_snapshot_days(snapshot_date) ON DELETE CASCADE,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    roster_status TEXT NOT NULL,          -- '26', '40', 'IL', 'minors'
+    status_il TEXT,                       -- IL designation when roster_status = 'IL'
+    position TEXT,
+    PRIMARY KEY (snapshot_date, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_roster_snapshots_team_date ON roster_snapshots(team_id, snapshot_date);
+CREATE INDEX IF NOT EXISTS idx_roster_snapshots_league_date ON roster_snapshots(league_id, snapshot_date);
@@ -0,0 +1,34 @@
+-- 055_projections.sql
+-- Third-party season projections (Steamer, ZiPS, etc.), imported from CSV or JSON. Counting stats are
+-- stored under the same stat keys as daily_player_stats.raw_stats so each league's scoring rules
+-- turn them into projected fantasy points. Rows keyed by MLB ID; a newer import for the same
+-- season and stat type replaces the older one.
+
+CREATE TABLE IF NOT EXISTS player_projections (
+    season INT NOT NULL,
+    mlb_id INTEGER NOT NULL,
+    stat_type TEXT NOT NULL,              -- 'hitting' or 'pitching'
+    source TEXT NOT NULL DEFAULT '',
+    raw_stats JSONB NOT NULL DEFAULT '{}'::jsonb,
+    imported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (season, mlb_id, stat_type)
+);
+
+-- Imported rows without an MLB ID we know, held for a commissioner to match or dismiss
+CREATE TABLE IF NOT EXISTS projection_review_queue (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    season INT NOT NULL,
+    stat_type TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT '',
+    player_name TEXT NOT NULL DEFAULT '',
+    mlb_team TEXT NOT NULL DEFAULT '',
+    mlb_id INTEGER,                       -- as supplied in the file, if any
+    raw_stats JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL DEFAULT 'pending', -- 'pending', 'resolved', 'dismissed'
+    resolved_mlb_id INTEGER,
+    resolved_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_projection_review_queue_status ON projection_review_queue(status, season);
@@ -0,0 +1,4 @@
+-- 056_player_birth_dates.sql
+-- Birth dates on canonical players, filled from the MLB Stats API by the monthly career-stats
+-- check. Used for age curves in player valuation.
+ALTER TABLE mlb_players ADD COLUMN IF NOT EXISTS birth_date DATE;
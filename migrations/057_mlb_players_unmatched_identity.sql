@@ -0,0 +1,67 @@
+-- 057_mlb_players_unmatched_identity.sql
+-- Players without an MLB ID were identified by name alone, so distinct same-name players in
+-- different leagues shared one canonical record and the MLB ID populator stamped one ID on all
+-- of them. Identify unmatched players by name, MLB team and position instead, and split
+-- records whose linked copies disagree. Matching records merge again once the populator
+-- assigns them the same MLB ID.
+
+DROP INDEX IF EXISTS idx_mlb_players_name_unmatched;
+
+-- Fold unmatched records sharing an identity into the oldest so the identity can be unique
+WITH ranked AS (
+    SELECT id, FIRST_VALUE(id) OVER (
+               PARTITION BY LOWER(first_name), LOWER(last_name), LOWER(COALESCE(mlb_team, '')), LOWER(COALESCE(position, ''))
+               ORDER BY created_at NULLS LAST, id) AS keep_id
+    FROM mlb_players
+    WHERE mlb_id IS NULL
+)
+UPDATE players p SET mlb_player_id = r.keep_id
+FROM ranked r
+WHERE p.mlb_player_id = r.id AND r.id <> r.keep_id;
+
+DELETE FROM mlb_players WHERE id IN (
+    SELECT id FROM (
+        SELECT id, FIRST_VALUE(id) OVER (
+                   PARTITION BY LOWER(first_name), LOWER(last_name), LOWER(COALESCE(mlb_team, '')), LOWER(COALESCE(position, ''))
+                   ORDER BY created_at NULLS LAST, id) AS keep_id
+        FROM mlb_players
+        WHERE mlb_id IS NULL
+    ) ranked
+    WHERE id <> keep_id
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mlb_players_identity_unmatched
+    ON mlb_players (LOWER(first_name), LOWER(last_name), LOWER(COALESCE(mlb_team, '')), LOWER(COALESCE(position, '')))
+    WHERE mlb_id IS NULL;
+
+-- One record per identity among copies that no longer match their unmatched canonical record
+INSERT INTO mlb_players (first_name, last_name, position, mlb_team, is_minor_leaguer, is_two_way)
+SELECT DISTINCT ON (LOWER(p.first_name), LOWER(COALESCE(p.last_name, '')), LOWER(COALESCE(p.mlb_team, '')), LOWER(COALESCE(p.position, '')))
+       p.first_name, COALESCE(p.last_name, ''), p.position, p.mlb_team, p.is_minor_leaguer, p.is_two_way
+FROM players p
+JOIN mlb_players mp ON mp.id = p.mlb_player_id
+WHERE mp.mlb_id IS NULL
+  AND (LOWER(COALESCE(p.mlb_team, '')) <> LOWER(COALESCE(mp.mlb_team, ''))
+       OR LOWER(COALESCE(p.position, '')) <> LOWER(COALESCE(mp.position, '')))
+  AND NOT EXISTS (
+      SELECT 1 FROM mlb_players o
+      WHERE o.mlb_id IS NULL
+        AND LOWER(o.first_name) = LOWER(p.first_name) AND LOWER(o.last_name) = LOWER(COALESCE(p.last_name, ''))
+        AND LOWER(COALESCE(o.mlb_team, '')) = LOWER(COALESCE(p.mlb_team, ''))
+        AND LOWER(COALESCE(o.position, '')) = LOWER(COALESCE(p.position, ''))
+  )
+ORDER BY LOWER(p.first_name), LOWER(COALESCE(p.last_name, '')), LOWER(COALESCE(p.mlb_team, '')), LOWER(COALESCE(p.position, '')),
+         (p.league_id = '11111111-1111-1111-1111-111111111111') DESC;
+
+UPDATE players p SET mlb_player_id = (
+    SELECT o.id FROM mlb_players o
+    WHERE o.mlb_id IS NULL
+      AND LOWER(o.first_name) = LOWER(p.first_name) AND LOWER(o.last_name) = LOWER(COALESCE(p.last_name, ''))
+      AND LOWER(COALESCE(o.mlb_team, '')) = LOWER(COALESCE(p.mlb_team, ''))
+      AND LOWER(COALESCE(o.position, '')) = LOWER(COALESCE(p.position, ''))
+    LIMIT 1
+)
+FROM mlb_players mp
+WHERE mp.id = p.mlb_player_id AND mp.mlb_id IS NULL
+  AND (LOWER(COALESCE(p.mlb_team, '')) <> LOWER(COALESCE(mp.mlb_team, ''))
+       OR LOWER(COALESCE(p.position, '')) <> LOWER(COALESCE(mp.position, '')));